@@ -25,6 +25,7 @@ type Config struct {
 	Lifecycle       LifecycleConfig  `toml:"lifecycle"`
 	Run             RunConfig        `toml:"run"`
 	Build           BuildConfig      `toml:"build"`
+	Targets         []dist.Target    `toml:"targets"`
 }
 
 // ModuleCollection is a list of ModuleConfigs
@@ -133,6 +134,12 @@ func ValidateConfig(c Config) error {
 		}
 	}
 
+	for _, t := range c.Targets {
+		if t.OS == "" || t.Arch == "" {
+			return errors.New("targets.os and targets.arch are required")
+		}
+	}
+
 	if c.Stack.RunImage != "" && c.Run.Images[0].Image != c.Stack.RunImage {
 		return errors.New("run.images and stack.run-image do not match")
 	}
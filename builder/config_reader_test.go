@@ -10,6 +10,7 @@ import (
 	"github.com/sclevine/spec/report"
 
 	"github.com/buildpacks/pack/builder"
+	"github.com/buildpacks/pack/pkg/dist"
 	h "github.com/buildpacks/pack/testhelpers"
 )
 
@@ -79,6 +80,38 @@ func testConfig(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("targets are declared", func() {
+			it.Before(func() {
+				h.AssertNil(t, os.WriteFile(builderConfigPath, []byte(`
+[[order]]
+[[order.group]]
+  id = "buildpack/1"
+
+[[targets]]
+  os = "linux"
+  arch = "amd64"
+[[targets.distros]]
+  name = "ubuntu"
+  version = "22.04"
+
+[[targets]]
+  os = "linux"
+  arch = "arm"
+  variant = "v7"
+`), 0666))
+			})
+
+			it("returns the targets", func() {
+				builderConfig, _, err := builder.ReadConfig(builderConfigPath)
+				h.AssertNil(t, err)
+
+				h.AssertEq(t, builderConfig.Targets, []dist.Target{
+					{OS: "linux", Arch: "amd64", Distributions: []dist.Distribution{{Name: "ubuntu", Version: "22.04"}}},
+					{OS: "linux", Arch: "arm", ArchVariant: "v7"},
+				})
+			})
+		})
+
 		when("an error occurs while reading", func() {
 			it("bubbles up the error", func() {
 				_, _, err := builder.ReadConfig(builderConfigPath)
@@ -224,6 +257,21 @@ uri = "noop-buildpack.tgz"
 			h.AssertError(t, builder.ValidateConfig(config), "build.image is required")
 		})
 
+		it("returns error if a target has no arch", func() {
+			config := builder.Config{
+				Build: builder.BuildConfig{
+					Image: testBuildImage,
+				},
+				Run: builder.RunConfig{
+					Images: []builder.RunImageConfig{{
+						Image: testRunImage,
+					}},
+				},
+				Targets: []dist.Target{{OS: "linux"}},
+			}
+			h.AssertError(t, builder.ValidateConfig(config), "targets.os and targets.arch are required")
+		})
+
 		it("returns error if no stack, run, or build image", func() {
 			config := builder.Config{}
 			h.AssertError(t, builder.ValidateConfig(config), "build.image is required")
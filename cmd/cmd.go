@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"path/filepath"
+
 	"github.com/heroku/color"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -10,8 +12,11 @@ import (
 	"github.com/buildpacks/pack/internal/commands"
 	"github.com/buildpacks/pack/internal/config"
 	imagewriter "github.com/buildpacks/pack/internal/inspectimage/writer"
+	"github.com/buildpacks/pack/internal/paths"
+	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/internal/term"
 	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
 	"github.com/buildpacks/pack/pkg/logging"
 )
 
@@ -21,6 +26,7 @@ type ConfigurableLogger interface {
 	WantTime(f bool)
 	WantQuiet(f bool)
 	WantVerbose(f bool)
+	WantJSON(f bool)
 }
 
 // NewPackCommand generates a Pack command
@@ -33,7 +39,7 @@ func NewPackCommand(logger ConfigurableLogger) (*cobra.Command, error) {
 		return nil, err
 	}
 
-	packClient, err := initClient(logger, cfg)
+	packClient, err := initClient(logger, cfg, cfgPath)
 	if err != nil {
 		return nil, err
 	}
@@ -41,7 +47,7 @@ func NewPackCommand(logger ConfigurableLogger) (*cobra.Command, error) {
 	rootCmd := &cobra.Command{
 		Use:   "pack",
 		Short: "CLI for building apps using Cloud Native Buildpacks",
-		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			if fs := cmd.Flags(); fs != nil {
 				if forceColor, err := fs.GetBool("force-color"); err == nil && !forceColor {
 					if flag, err := fs.GetBool("no-color"); err == nil && flag {
@@ -62,7 +68,21 @@ func NewPackCommand(logger ConfigurableLogger) (*cobra.Command, error) {
 				if flag, err := fs.GetBool("timestamps"); err == nil {
 					logger.WantTime(flag)
 				}
+				if format, err := fs.GetString("log-format"); err == nil {
+					// --output is an alias of --log-format, unless the command has an --output flag of its own
+					if output := fs.Lookup("output"); output != nil && output == cmd.Root().PersistentFlags().Lookup("output") && output.Changed {
+						format = output.Value.String()
+					}
+					switch format {
+					case "text":
+					case "json":
+						logger.WantJSON(true)
+					default:
+						return errors.Errorf("invalid log format %s, must be one of 'text' or 'json'", style.Symbol(format))
+					}
+				}
 			}
+			return nil
 		},
 	}
 
@@ -71,6 +91,8 @@ func NewPackCommand(logger ConfigurableLogger) (*cobra.Command, error) {
 	rootCmd.PersistentFlags().Bool("timestamps", false, "Enable timestamps in output")
 	rootCmd.PersistentFlags().BoolP("quiet", "q", false, "Show less output")
 	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Show more output")
+	rootCmd.PersistentFlags().String("log-format", "text", "Format of log output, 'text' or 'json'.\nWith 'json', logs and structured build events are written as JSON lines")
+	rootCmd.PersistentFlags().String("output", "text", "Alias of --log-format, for commands without an --output flag of their own")
 	rootCmd.Flags().Bool("version", false, "Show current 'pack' version")
 
 	commands.AddHelpFlag(rootCmd, "pack")
@@ -84,6 +106,7 @@ func NewPackCommand(logger ConfigurableLogger) (*cobra.Command, error) {
 	rootCmd.AddCommand(commands.NewStackCommand(logger))
 	rootCmd.AddCommand(commands.Rebase(logger, cfg, packClient))
 	rootCmd.AddCommand(commands.NewSBOMCommand(logger, cfg, packClient))
+	rootCmd.AddCommand(commands.NewCacheCommand(logger, packClient))
 
 	rootCmd.AddCommand(commands.InspectBuildpack(logger, cfg, packClient))
 	rootCmd.AddCommand(commands.InspectBuilder(logger, cfg, packClient, builderwriter.NewFactory()))
@@ -104,6 +127,7 @@ func NewPackCommand(logger ConfigurableLogger) (*cobra.Command, error) {
 		rootCmd.AddCommand(commands.SetDefaultRegistry(logger, cfg, cfgPath))
 		rootCmd.AddCommand(commands.RemoveRegistry(logger, cfg, cfgPath))
 		rootCmd.AddCommand(commands.YankBuildpack(logger, cfg, packClient))
+		rootCmd.AddCommand(commands.NewManifestCommand(logger, packClient))
 	}
 
 	packHome, err := config.PackHome()
@@ -136,7 +160,7 @@ func initConfig() (config.Config, string, error) {
 	return cfg, path, nil
 }
 
-func initClient(logger logging.Logger, cfg config.Config) (*client.Client, error) {
+func initClient(logger logging.Logger, cfg config.Config, cfgPath string) (*client.Client, error) {
 	if err := client.ProcessDockerContext(logger); err != nil {
 		return nil, err
 	}
@@ -145,5 +169,34 @@ func initClient(logger logging.Logger, cfg config.Config) (*client.Client, error
 	if err != nil {
 		return nil, err
 	}
-	return client.NewClient(client.WithLogger(logger), client.WithExperimental(cfg.Experimental), client.WithRegistryMirrors(cfg.RegistryMirrors), client.WithDockerClient(dc))
+	policies, err := verificationPolicies(cfg, cfgPath)
+	if err != nil {
+		return nil, err
+	}
+	return client.NewClient(client.WithLogger(logger), client.WithExperimental(cfg.Experimental), client.WithRegistryMirrors(cfg.RegistryMirrors), client.WithVerificationPolicies(policies), client.WithDockerClient(dc), client.WithRemoteShellDialer(newRemoteShellDialer()))
+}
+
+// verificationPolicies returns the verification policies of the config, with public key paths and buildpack archive
+// paths relative to the config file
+func verificationPolicies(cfg config.Config, cfgPath string) ([]image.VerificationPolicy, error) {
+	var policies []image.VerificationPolicy
+	for _, policy := range cfg.VerificationPolicies {
+		var keys []string
+		for _, key := range policy.PublicKeys {
+			if !filepath.IsAbs(key) {
+				key = filepath.Join(filepath.Dir(cfgPath), key)
+			}
+			keys = append(keys, key)
+		}
+
+		uri := policy.URI
+		if uri != "" {
+			var err error
+			if uri, err = paths.FilePathToURI(uri, filepath.Dir(cfgPath)); err != nil {
+				return nil, errors.Wrapf(err, "reading verification policy for %s", style.Symbol(policy.URI))
+			}
+		}
+		policies = append(policies, image.VerificationPolicy{Image: policy.Image, URI: uri, PublicKeys: keys, Digests: policy.Digests})
+	}
+	return policies, nil
 }
@@ -16,6 +16,7 @@ import (
 	"golang.org/x/crypto/ssh"
 	"golang.org/x/term"
 
+	"github.com/buildpacks/pack/internal/build"
 	"github.com/buildpacks/pack/internal/sshdialer"
 	"github.com/buildpacks/pack/pkg/client"
 )
@@ -59,6 +60,24 @@ func tryInitSSHDockerClient() (dockerClient.CommonAPIClient, error) {
 	return dockerClient.NewClientWithOpts(dockerClientOpts...)
 }
 
+// newRemoteShellDialer returns a dialer for `pack build --remote-host`, prompting for SSH credentials when needed
+func newRemoteShellDialer() client.RemoteShellDialer {
+	return func(host *url.URL) (build.RemoteShell, error) {
+		credentialsConfig := sshdialer.Config{
+			Identity:           os.Getenv("PACK_REMOTE_HOST_SSH_IDENTITY"),
+			PassPhrase:         os.Getenv("PACK_REMOTE_HOST_SSH_IDENTITY_PASSPHRASE"),
+			PasswordCallback:   newReadSecretCbk("please enter password:"),
+			PassPhraseCallback: newReadSecretCbk("please enter passphrase to private key:"),
+			HostKeyCallback:    newHostKeyCbk(),
+		}
+		sshClient, err := sshdialer.NewClient(host, credentialsConfig)
+		if err != nil {
+			return nil, err
+		}
+		return build.NewSSHShell(sshClient), nil
+	}
+}
+
 // readSecret prompts for a secret and returns value input by user from stdin
 // Unlike terminal.ReadPassword(), $(echo $SECRET | podman...) is supported.
 // Additionally, all input after `<secret>/n` is queued to podman command.
@@ -3,6 +3,7 @@ package main
 import (
 	"os"
 
+	"github.com/docker/docker/pkg/reexec"
 	"github.com/heroku/color"
 
 	"github.com/buildpacks/pack/cmd"
@@ -13,6 +14,11 @@ import (
 )
 
 func main() {
+	// processes of the local runtime re-execute pack to set up their filesystem
+	if reexec.Init() {
+		return
+	}
+
 	// create logger with defaults
 	logger := logging.NewLogWithWriters(color.Stdout(), color.Stderr())
 
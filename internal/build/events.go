@@ -0,0 +1,110 @@
+package build
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"regexp"
+
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+var (
+	colorMatcher         = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+	participatingMatcher = regexp.MustCompile(`^\d+ of \d+ buildpacks participating$`)
+	groupEntryMatcher    = regexp.MustCompile(`^([a-zA-Z0-9][\w./-]*) (\d\S*)$`)
+	layerMatcher         = regexp.MustCompile(`^(Adding|Reusing) (cache )?layer '(.+)'$`)
+)
+
+// eventWriter passes lifecycle output through unchanged while emitting structured
+// events for the lines it recognizes: the buildpack group selected by the detector
+// and the layers added or reused by the exporter. Image layers are also counted in
+// the build stats, when provided. Close should be called to emit events for any
+// pending output.
+type eventWriter struct {
+	out    io.Writer
+	logger logging.Logger
+	phase  string
+	stats  *statsRecorder
+	buf    bytes.Buffer
+	group  []string
+	inGrp  bool
+}
+
+func newEventWriter(out io.Writer, logger logging.Logger, phase string, stats *statsRecorder) *eventWriter {
+	return &eventWriter{out: out, logger: logger, phase: phase, stats: stats}
+}
+
+func (w *eventWriter) Write(data []byte) (int, error) {
+	n, err := w.out.Write(data)
+	if err != nil {
+		return n, err
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Split(logging.ScanLinesKeepNewLine)
+	for scanner.Scan() {
+		bits := scanner.Bytes()
+		w.buf.Write(bytes.TrimRight(bits, "\n"))
+		if bits[len(bits)-1] == '\n' {
+			w.scanLine()
+		}
+	}
+
+	return n, scanner.Err()
+}
+
+func (w *eventWriter) Close() error {
+	if w.buf.Len() > 0 {
+		w.scanLine()
+	}
+	w.endGroup()
+
+	if closer, ok := w.out.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+func (w *eventWriter) scanLine() {
+	line := string(bytes.TrimSpace(colorMatcher.ReplaceAll(w.buf.Bytes(), nil)))
+	w.buf.Reset()
+
+	if w.inGrp {
+		if match := groupEntryMatcher.FindStringSubmatch(line); match != nil {
+			w.group = append(w.group, match[1]+"@"+match[2])
+			return
+		}
+		w.endGroup()
+	}
+
+	switch {
+	case participatingMatcher.MatchString(line):
+		w.inGrp = true
+	case layerMatcher.MatchString(line):
+		match := layerMatcher.FindStringSubmatch(line)
+		eventType := logging.EventLayerAdded
+		if match[1] == "Reusing" {
+			eventType = logging.EventLayerReused
+		}
+		event := logging.Event{Type: eventType, Phase: w.phase, Layer: match[3]}
+		if match[2] != "" {
+			event.CacheType = "build"
+		} else if w.stats != nil {
+			w.stats.recordLayer(eventType == logging.EventLayerReused)
+		}
+		logging.LogEvent(w.logger, event)
+	}
+}
+
+func (w *eventWriter) endGroup() {
+	if !w.inGrp {
+		return
+	}
+
+	w.inGrp = false
+	if len(w.group) > 0 {
+		logging.LogEvent(w.logger, logging.Event{Type: logging.EventGroupSelected, Phase: w.phase, Buildpacks: w.group})
+	}
+	w.group = nil
+}
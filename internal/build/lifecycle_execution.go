@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"time"
 
 	"github.com/BurntSushi/toml"
 	"github.com/buildpacks/lifecycle/api"
@@ -40,6 +41,7 @@ type LifecycleExecution struct {
 	mountPaths   mountPaths
 	opts         LifecycleOptions
 	tmpDir       string
+	stats        *statsRecorder
 }
 
 func NewLifecycleExecution(logger logging.Logger, docker DockerClient, tmpDir string, opts LifecycleOptions) (*LifecycleExecution, error) {
@@ -66,6 +68,7 @@ func NewLifecycleExecution(logger logging.Logger, docker DockerClient, tmpDir st
 		os:           osType,
 		mountPaths:   mountPathsForOS(osType, opts.Workspace),
 		tmpDir:       tmpDir,
+		stats:        &statsRecorder{},
 	}
 
 	if opts.Interactive {
@@ -164,8 +167,12 @@ func (l *LifecycleExecution) PrevImageName() string {
 }
 
 func (l *LifecycleExecution) Run(ctx context.Context, phaseFactoryCreator PhaseFactoryCreator) error {
+	start := time.Now()
 	phaseFactory := phaseFactoryCreator(l)
-	var buildCache Cache
+	var (
+		buildCache  Cache
+		remoteCache *cache.RemoteCache
+	)
 	if l.opts.CacheImage != "" || (l.opts.Cache.Build.Format == cache.CacheImage) {
 		cacheImageName := l.opts.CacheImage
 		if cacheImageName == "" {
@@ -184,6 +191,16 @@ func (l *LifecycleExecution) Run(ctx context.Context, phaseFactoryCreator PhaseF
 		case cache.CacheBind:
 			buildCache = cache.NewBindCache(l.opts.Cache.Build, l.docker)
 			l.logger.Debugf("Using build cache dir %s", style.Symbol(buildCache.Name()))
+		case cache.CacheHTTP, cache.CacheDir:
+			if l.os == "windows" {
+				return fmt.Errorf("cache format '%s' is not supported for Windows builds", l.opts.Cache.Build.Format)
+			}
+			buildCache = cache.NewVolumeCache(l.opts.Image, cache.CacheInfo{}, "build", l.docker)
+			var err error
+			if remoteCache, err = cache.NewRemoteCache(l.opts.Cache.Build, buildCache.Name()); err != nil {
+				return err
+			}
+			l.logger.Debugf("Using build cache volume %s, synchronized with %s cache", style.Symbol(buildCache.Name()), l.opts.Cache.Build.Format)
 		}
 	}
 
@@ -192,10 +209,19 @@ func (l *LifecycleExecution) Run(ctx context.Context, phaseFactoryCreator PhaseF
 			return errors.Wrap(err, "clearing build cache")
 		}
 		l.logger.Debugf("Build cache %s cleared", style.Symbol(buildCache.Name()))
+	} else if remoteCache != nil {
+		if err := l.loadRemoteCache(ctx, remoteCache, buildCache); err != nil {
+			return err
+		}
 	}
 
 	launchCache := cache.NewVolumeCache(l.opts.Image, l.opts.Cache.Launch, "launch", l.docker)
 
+	if buildCache != nil {
+		logging.LogEvent(l.logger, logging.Event{Type: logging.EventCache, CacheType: "build", Cache: buildCache.Name()})
+	}
+	logging.LogEvent(l.logger, logging.Event{Type: logging.EventCache, CacheType: "launch", Cache: launchCache.Name()})
+
 	if !l.opts.UseCreator {
 		if l.platformAPI.LessThan("0.7") {
 			l.logger.Info(style.Step("DETECTING"))
@@ -286,13 +312,25 @@ func (l *LifecycleExecution) Run(ctx context.Context, phaseFactoryCreator PhaseF
 		}
 
 		l.logger.Info(style.Step("EXPORTING"))
-		return l.Export(ctx, buildCache, launchCache, kanikoCache, phaseFactory)
+		if err := l.Export(ctx, buildCache, launchCache, kanikoCache, phaseFactory); err != nil {
+			return err
+		}
+	} else {
+		if l.platformAPI.AtLeast("0.10") && l.hasExtensions() && !l.opts.UseCreatorWithExtensions {
+			return errors.New("builder has an order for extensions which is not supported when using the creator; re-run without '--trust-builder' or re-tag builder to avoid trusting it")
+		}
+		if err := l.Create(ctx, buildCache, launchCache, phaseFactory); err != nil {
+			return err
+		}
 	}
 
-	if l.platformAPI.AtLeast("0.10") && l.hasExtensions() && !l.opts.UseCreatorWithExtensions {
-		return errors.New("builder has an order for extensions which is not supported when using the creator; re-run without '--trust-builder' or re-tag builder to avoid trusting it")
+	if remoteCache != nil {
+		if err := l.saveRemoteCache(ctx, remoteCache, buildCache); err != nil {
+			return err
+		}
 	}
-	return l.Create(ctx, buildCache, launchCache, phaseFactory)
+
+	return l.reportStats(time.Since(start))
 }
 
 func (l *LifecycleExecution) Cleanup() error {
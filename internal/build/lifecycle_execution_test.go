@@ -317,6 +317,38 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 				}
 			})
 
+			when("report destination directory is provided", func() {
+				it("writes the build stats alongside the report", func() {
+					reportDir, err := os.MkdirTemp("", "pack.report")
+					h.AssertNil(t, err)
+					defer os.RemoveAll(reportDir)
+
+					opts := build.LifecycleOptions{
+						Image:                imageName,
+						Builder:              fakeBuilder,
+						UseCreator:           true,
+						Termui:               fakeTermui,
+						ReportDestinationDir: reportDir,
+					}
+
+					lifecycle, err := build.NewLifecycleExecution(logger, docker, "some-temp-dir", opts)
+					h.AssertNil(t, err)
+
+					err = lifecycle.Run(context.Background(), func(execution *build.LifecycleExecution) build.PhaseFactory {
+						return fakePhaseFactory
+					})
+					h.AssertNil(t, err)
+
+					h.AssertContains(t, outBuf.String(), "Build stats:")
+					h.AssertContains(t, outBuf.String(), "0 layer(s) added, 0 layer(s) reused")
+
+					contents, err := os.ReadFile(filepath.Join(reportDir, "build-stats.toml"))
+					h.AssertNil(t, err)
+					h.AssertContains(t, string(contents), "duration-ms = ")
+					h.AssertContains(t, string(contents), "layers-added = 0")
+				})
+			})
+
 			when("Run with workspace dir", func() {
 				it("succeeds", func() {
 					opts := build.LifecycleOptions{
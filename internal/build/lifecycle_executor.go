@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/buildpacks/imgutil"
@@ -11,9 +12,13 @@ import (
 	"github.com/buildpacks/lifecycle/platform/files"
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
 
 	"github.com/buildpacks/pack/internal/builder"
 	"github.com/buildpacks/pack/internal/container"
+	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/cache"
 	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/logging"
@@ -102,6 +107,10 @@ type LifecycleOptions struct {
 	SBOMDestinationDir              string
 	CreationTime                    *time.Time
 	Keychain                        authn.Keychain
+	Platform                        *specs.Platform // optional - phase containers are created for the daemon's platform when unset.
+	Runtime                         string          // optional - RuntimeDocker when unset.
+	LocalRuntimeDir                 string          // optional - where the local runtime keeps volumes between builds.
+	Remote                          RemoteShell     // optional - runs the creator on a remote host rather than in containers.
 }
 
 func NewLifecycleExecutor(logger logging.Logger, docker DockerClient) *LifecycleExecutor {
@@ -114,7 +123,26 @@ func (l *LifecycleExecutor) Execute(ctx context.Context, opts LifecycleOptions)
 		return err
 	}
 
-	lifecycleExec, err := NewLifecycleExecution(l.logger, l.docker, tmpDir, opts)
+	if opts.Remote != nil {
+		defer os.RemoveAll(tmpDir)
+		lifecycleExec, err := NewLifecycleExecution(l.logger, nil, tmpDir, opts)
+		if err != nil {
+			return err
+		}
+		return lifecycleExec.RunRemote(ctx, opts.Remote)
+	}
+
+	docker := l.docker
+	if opts.Runtime == RuntimeLocal {
+		runtime, err := newLocalRuntime(opts)
+		if err != nil {
+			return err
+		}
+		defer runtime.Close()
+		docker = runtime
+	}
+
+	lifecycleExec, err := NewLifecycleExecution(l.logger, docker, tmpDir, opts)
 	if err != nil {
 		return err
 	}
@@ -129,3 +157,16 @@ func (l *LifecycleExecutor) Execute(ctx context.Context, opts LifecycleOptions)
 		lifecycleExec.Run(ctx, NewDefaultPhaseFactory)
 	})
 }
+
+func newLocalRuntime(opts LifecycleOptions) (*LocalRuntime, error) {
+	underlying, ok := opts.Builder.Image().(interface{ UnderlyingImage() v1.Image })
+	if !ok {
+		return nil, errors.Errorf("builder %s cannot be unpacked for the local runtime", style.Symbol(opts.Builder.Name()))
+	}
+
+	volumesDir := opts.LocalRuntimeDir
+	if volumesDir == "" {
+		volumesDir = filepath.Join(os.TempDir(), "pack.local-runtime.volumes")
+	}
+	return NewLocalRuntime(underlying.UnderlyingImage(), opts.Builder.Name(), volumesDir)
+}
@@ -0,0 +1,705 @@
+package build
+
+import (
+	"archive/tar"
+	"context"
+	"io"
+	"io/fs"
+	"net"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	securejoin "github.com/cyphar/filepath-securejoin"
+	"github.com/docker/docker/api/types"
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	networktypes "github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/errdefs"
+	darchive "github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/pkg/stdcopy"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+const (
+	// RuntimeDocker runs lifecycle phases as containers in a Docker daemon
+	RuntimeDocker = "docker"
+	// RuntimeLocal runs lifecycle phases as local processes in an unpacked builder filesystem, without a daemon
+	RuntimeLocal = "local"
+)
+
+// LocalRuntime runs lifecycle phases as local processes instead of containers, so that builds do not require a
+// Docker daemon. It implements the subset of the Docker API used to run phases: each container is a process
+// chrooted into the unpacked filesystem of a single image, in new user and mount namespaces, with volumes and
+// binds mounted from the host.
+//
+// Processes share the host network. When pack doesn't run as root, the user namespace maps root to the invoking
+// user and the other IDs to the invoking user's subordinate IDs, so that buildpacks run as the builder's user as they
+// would in a container. Files are unpacked and copied in the same user namespace, keeping their ownership.
+type LocalRuntime struct {
+	image      string
+	config     v1.Config
+	rootDir    string
+	rootfs     string
+	volumesDir string
+	ids        *localIDMap
+
+	mu         sync.Mutex
+	containers map[string]*localContainer
+}
+
+type localContainer struct {
+	config *containertypes.Config
+	mounts []localMount
+	output net.Conn
+	cancel func()
+	done   chan struct{}
+
+	// set when the process exits, before done is closed
+	exitCode int64
+	err      error
+}
+
+type localMount struct {
+	Source   string `json:"source"`
+	Target   string `json:"target"`
+	ReadOnly bool   `json:"readonly,omitempty"`
+}
+
+// localIDMap maps the IDs of the user namespace of the runtime to host IDs: root to the invoking user, and the IDs
+// from 1 to the invoking user's subordinate IDs.
+type localIDMap struct {
+	user    string
+	uid     int
+	gid     int
+	subUIDs idRange
+	subGIDs idRange
+}
+
+type idRange struct {
+	start int
+	count int
+}
+
+// checkBuilderIDs fails when the builder runs buildpacks as a user or group that is not mapped
+func (m *localIDMap) checkBuilderIDs(env []string) error {
+	for _, kv := range env {
+		key, value, _ := strings.Cut(kv, "=")
+		var subIDs idRange
+		switch key {
+		case "CNB_USER_ID":
+			subIDs = m.subUIDs
+		case "CNB_GROUP_ID":
+			subIDs = m.subGIDs
+		default:
+			continue
+		}
+
+		if id, err := strconv.Atoi(value); err == nil && id > subIDs.count {
+			return errors.Errorf("%s %d of the builder is beyond the %d subordinate IDs of user %s", key, id, subIDs.count, style.Symbol(m.user))
+		}
+	}
+	return nil
+}
+
+// localFSOp is an operation on the files of the runtime, run in the user namespace of the runtime so that the
+// ownership of files can be kept
+type localFSOp struct {
+	Op string `json:"op"`
+	// Rootfs and Mounts resolve the container path extracted to
+	Rootfs string       `json:"rootfs,omitempty"`
+	Mounts []localMount `json:"mounts,omitempty"`
+	// Path is the container path to extract to, or the host path to create or remove
+	Path string `json:"path"`
+	// Source is the directory of the image a new volume is mounted over
+	Source string `json:"source,omitempty"`
+}
+
+const (
+	localFSExtract = "extract"
+	localFSVolume  = "volume"
+	localFSRemove  = "remove"
+)
+
+// run performs the operation, reading the archive to extract from stdin
+func (op localFSOp) run(stdin io.Reader) error {
+	switch op.Op {
+	case localFSExtract:
+		return extractTar(stdin, func(name string) (string, error) {
+			return resolveHostPath(op.Rootfs, op.Mounts, path.Join(op.Path, name))
+		})
+	case localFSVolume:
+		return createVolume(op.Path, op.Source)
+	case localFSRemove:
+		return removeAll(op.Path)
+	default:
+		return errors.Errorf("unknown operation %s", style.Symbol(op.Op))
+	}
+}
+
+// localProcess describes a process to run in the runtime's root filesystem
+type localProcess struct {
+	Rootfs  string       `json:"rootfs"`
+	Args    []string     `json:"args"`
+	Env     []string     `json:"env"`
+	WorkDir string       `json:"workdir"`
+	Mounts  []localMount `json:"mounts"`
+}
+
+var _ DockerClient = (*LocalRuntime)(nil)
+
+// NewLocalRuntime unpacks img, which containers refer to as imageName, into a temporary directory. Named volumes are
+// kept in volumesDir, so that caches persist between builds. Close must be called to remove the unpacked image.
+func NewLocalRuntime(img v1.Image, imageName, volumesDir string) (*LocalRuntime, error) {
+	configFile, err := img.ConfigFile()
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading config of image %s", style.Symbol(imageName))
+	}
+
+	ids, err := newLocalIDMap()
+	if err != nil {
+		return nil, err
+	}
+	if ids != nil {
+		if err := ids.checkBuilderIDs(configFile.Config.Env); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := os.MkdirAll(volumesDir, 0700); err != nil {
+		return nil, errors.Wrap(err, "creating volumes directory")
+	}
+
+	rootDir, err := os.MkdirTemp("", "pack.local-runtime")
+	if err != nil {
+		return nil, err
+	}
+
+	r := &LocalRuntime{
+		image:      imageName,
+		config:     configFile.Config,
+		rootDir:    rootDir,
+		rootfs:     filepath.Join(rootDir, "rootfs"),
+		volumesDir: volumesDir,
+		ids:        ids,
+		containers: map[string]*localContainer{},
+	}
+
+	reader := mutate.Extract(img)
+	defer reader.Close()
+
+	if err := r.runFSOp(localFSOp{Op: localFSExtract, Rootfs: r.rootfs, Path: "/"}, reader); err != nil {
+		r.Close()
+		return nil, errors.Wrapf(err, "unpacking image %s", style.Symbol(imageName))
+	}
+
+	return r, nil
+}
+
+// Close stops any running processes and removes the unpacked image
+func (r *LocalRuntime) Close() error {
+	r.mu.Lock()
+	var ids []string
+	for id := range r.containers {
+		ids = append(ids, id)
+	}
+	r.mu.Unlock()
+
+	for _, id := range ids {
+		_ = r.ContainerRemove(context.Background(), id, containertypes.RemoveOptions{Force: true})
+	}
+
+	return r.runFSOp(localFSOp{Op: localFSRemove, Path: r.rootDir}, nil)
+}
+
+// runFSOp runs op in the user namespace of the runtime, or in pack itself when the runtime has no user namespace
+func (r *LocalRuntime) runFSOp(op localFSOp, stdin io.Reader) error {
+	if r.ids == nil {
+		return op.run(stdin)
+	}
+	return runLocalFSOp(op, r.ids, stdin)
+}
+
+func (r *LocalRuntime) ContainerCreate(ctx context.Context, config *containertypes.Config, hostConfig *containertypes.HostConfig, networkingConfig *networktypes.NetworkingConfig, platform *specs.Platform, containerName string) (containertypes.CreateResponse, error) {
+	if config.Image != r.image {
+		return containertypes.CreateResponse{}, errdefs.NotFound(errors.Errorf("image %s is not available to the local runtime", style.Symbol(config.Image)))
+	}
+
+	ctr := &localContainer{
+		config: config,
+		cancel: func() {},
+		done:   make(chan struct{}),
+	}
+
+	if hostConfig != nil {
+		switch hostConfig.NetworkMode {
+		case "", "default", "host", "bridge":
+		default:
+			return containertypes.CreateResponse{}, errors.Errorf("network mode %s is not supported by the local runtime", style.Symbol(string(hostConfig.NetworkMode)))
+		}
+
+		for _, bind := range hostConfig.Binds {
+			mount, err := r.parseBind(bind)
+			if err != nil {
+				return containertypes.CreateResponse{}, err
+			}
+			ctr.mounts = append(ctr.mounts, mount)
+		}
+	}
+
+	// mount parents before their children
+	sort.SliceStable(ctr.mounts, func(i, j int) bool {
+		return len(ctr.mounts[i].Target) < len(ctr.mounts[j].Target)
+	})
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := randString(12)
+	r.containers[id] = ctr
+	return containertypes.CreateResponse{ID: id}, nil
+}
+
+func (r *LocalRuntime) parseBind(bind string) (localMount, error) {
+	parts := strings.SplitN(bind, ":", 3)
+	if len(parts) < 2 || !path.IsAbs(parts[1]) {
+		return localMount{}, errors.Errorf("invalid bind %s", style.Symbol(bind))
+	}
+
+	mount := localMount{
+		Source: parts[0],
+		Target: path.Clean(parts[1]),
+	}
+	if len(parts) == 3 {
+		for _, opt := range strings.Split(parts[2], ",") {
+			if opt == "ro" {
+				mount.ReadOnly = true
+			}
+		}
+	}
+
+	if !filepath.IsAbs(mount.Source) {
+		volume, err := r.volumePath(mount.Source)
+		if err != nil {
+			return localMount{}, err
+		}
+		if _, err := os.Stat(volume); os.IsNotExist(err) {
+			imageDir, err := securejoin.SecureJoin(r.rootfs, mount.Target)
+			if err != nil {
+				return localMount{}, err
+			}
+			if err := r.runFSOp(localFSOp{Op: localFSVolume, Path: volume, Source: imageDir}, nil); err != nil {
+				return localMount{}, errors.Wrapf(err, "creating volume %s", style.Symbol(mount.Source))
+			}
+		}
+		mount.Source = volume
+	}
+
+	return mount, nil
+}
+
+func (r *LocalRuntime) volumePath(name string) (string, error) {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return "", errors.Errorf("invalid volume name %s", style.Symbol(name))
+	}
+	return filepath.Join(r.volumesDir, name), nil
+}
+
+func (r *LocalRuntime) container(id string) (*localContainer, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ctr, ok := r.containers[id]
+	if !ok {
+		return nil, errdefs.NotFound(errors.Errorf("no such container: %s", id))
+	}
+	return ctr, nil
+}
+
+func (r *LocalRuntime) ContainerAttach(ctx context.Context, container string, options containertypes.AttachOptions) (types.HijackedResponse, error) {
+	ctr, err := r.container(container)
+	if err != nil {
+		return types.HijackedResponse{}, err
+	}
+
+	client, server := net.Pipe()
+	r.mu.Lock()
+	ctr.output = server
+	r.mu.Unlock()
+
+	return types.NewHijackedResponse(client, ""), nil
+}
+
+func (r *LocalRuntime) ContainerStart(ctx context.Context, container string, options containertypes.StartOptions) error {
+	ctr, err := r.container(container)
+	if err != nil {
+		return err
+	}
+
+	var stdout, stderr io.Writer = io.Discard, io.Discard
+	r.mu.Lock()
+	if ctr.output != nil {
+		stdout = stdcopy.NewStdWriter(ctr.output, stdcopy.Stdout)
+		stderr = stdcopy.NewStdWriter(ctr.output, stdcopy.Stderr)
+	}
+	r.mu.Unlock()
+
+	process := localProcess{
+		Rootfs:  r.rootfs,
+		Args:    r.args(ctr.config),
+		Env:     r.env(ctr.config),
+		WorkDir: r.workDir(ctr.config),
+		Mounts:  ctr.mounts,
+	}
+	if len(process.Args) == 0 {
+		return errors.New("no command specified")
+	}
+
+	wait, kill, err := startLocalProcess(process, r.ids, stdout, stderr)
+	if err != nil {
+		return errors.Wrapf(err, "starting %s", style.Symbol(process.Args[0]))
+	}
+
+	stop := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			kill()
+		case <-stop:
+		}
+	}()
+
+	r.mu.Lock()
+	ctr.cancel = kill
+	r.mu.Unlock()
+
+	go func() {
+		exitCode, err := wait()
+		close(stop)
+
+		r.mu.Lock()
+		ctr.exitCode, ctr.err = exitCode, err
+		if ctr.output != nil {
+			ctr.output.Close()
+		}
+		r.mu.Unlock()
+		close(ctr.done)
+	}()
+
+	return nil
+}
+
+func (r *LocalRuntime) args(config *containertypes.Config) []string {
+	var args []string
+	for _, arg := range config.Entrypoint {
+		if arg != "" {
+			args = append(args, arg)
+		}
+	}
+	if len(args) == 0 && len(config.Entrypoint) == 0 {
+		args = append(args, r.config.Entrypoint...)
+	}
+	return append(args, config.Cmd...)
+}
+
+func (r *LocalRuntime) env(config *containertypes.Config) []string {
+	env := map[string]string{}
+	var keys []string
+	for _, kv := range append(append([]string{}, r.config.Env...), config.Env...) {
+		key, value, _ := strings.Cut(kv, "=")
+		if _, ok := env[key]; !ok {
+			keys = append(keys, key)
+		}
+		env[key] = value
+	}
+
+	var result []string
+	for _, key := range keys {
+		result = append(result, key+"="+env[key])
+	}
+	return result
+}
+
+func (r *LocalRuntime) workDir(config *containertypes.Config) string {
+	switch {
+	case config.WorkingDir != "":
+		return config.WorkingDir
+	case r.config.WorkingDir != "":
+		return r.config.WorkingDir
+	default:
+		return "/"
+	}
+}
+
+func (r *LocalRuntime) ContainerWait(ctx context.Context, container string, condition containertypes.WaitCondition) (<-chan containertypes.WaitResponse, <-chan error) {
+	bodyChan := make(chan containertypes.WaitResponse, 1)
+	errChan := make(chan error, 1)
+
+	ctr, err := r.container(container)
+	if err != nil {
+		errChan <- err
+		return bodyChan, errChan
+	}
+
+	go func() {
+		select {
+		case <-ctr.done:
+			if ctr.err != nil {
+				errChan <- ctr.err
+				return
+			}
+			bodyChan <- containertypes.WaitResponse{StatusCode: ctr.exitCode}
+		case <-ctx.Done():
+			errChan <- ctx.Err()
+		}
+	}()
+
+	return bodyChan, errChan
+}
+
+func (r *LocalRuntime) ContainerInspect(ctx context.Context, container string) (types.ContainerJSON, error) {
+	ctr, err := r.container(container)
+	if err != nil {
+		return types.ContainerJSON{}, err
+	}
+
+	state := &types.ContainerState{Status: "created"}
+	select {
+	case <-ctr.done:
+		state.Status = "exited"
+		state.ExitCode = int(ctr.exitCode)
+	default:
+	}
+
+	return types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{
+			ID:    container,
+			Image: ctr.config.Image,
+			State: state,
+		},
+		Config: ctr.config,
+	}, nil
+}
+
+func (r *LocalRuntime) ContainerRemove(ctx context.Context, container string, options containertypes.RemoveOptions) error {
+	ctr, err := r.container(container)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	kill := ctr.cancel
+	r.mu.Unlock()
+	kill()
+
+	r.mu.Lock()
+	delete(r.containers, container)
+	r.mu.Unlock()
+	return nil
+}
+
+func (r *LocalRuntime) CopyToContainer(ctx context.Context, container, dstPath string, content io.Reader, options types.CopyToContainerOptions) error {
+	ctr, err := r.container(container)
+	if err != nil {
+		return err
+	}
+
+	return r.runFSOp(localFSOp{Op: localFSExtract, Rootfs: r.rootfs, Mounts: ctr.mounts, Path: dstPath}, content)
+}
+
+func (r *LocalRuntime) CopyFromContainer(ctx context.Context, container, srcPath string) (io.ReadCloser, types.ContainerPathStat, error) {
+	ctr, err := r.container(container)
+	if err != nil {
+		return nil, types.ContainerPathStat{}, err
+	}
+
+	hostPath, err := resolveHostPath(r.rootfs, ctr.mounts, srcPath)
+	if err != nil {
+		return nil, types.ContainerPathStat{}, err
+	}
+
+	fi, err := os.Lstat(hostPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, types.ContainerPathStat{}, errdefs.NotFound(errors.Errorf("could not find the file %s in container %s", srcPath, container))
+		}
+		return nil, types.ContainerPathStat{}, err
+	}
+
+	reader, err := darchive.TarResourceRebase(hostPath, path.Base(srcPath))
+	if err != nil {
+		return nil, types.ContainerPathStat{}, err
+	}
+
+	return reader, types.ContainerPathStat{
+		Name:  path.Base(srcPath),
+		Size:  fi.Size(),
+		Mode:  fi.Mode(),
+		Mtime: fi.ModTime(),
+	}, nil
+}
+
+// resolveHostPath resolves a path in a container to the host, following the container's mounts
+func resolveHostPath(rootfs string, mounts []localMount, ctrPath string) (string, error) {
+	ctrPath = path.Clean("/" + ctrPath)
+	for i := len(mounts) - 1; i >= 0; i-- {
+		mount := mounts[i]
+		if ctrPath == mount.Target || strings.HasPrefix(ctrPath, strings.TrimSuffix(mount.Target, "/")+"/") {
+			return securejoin.SecureJoin(mount.Source, strings.TrimPrefix(ctrPath, mount.Target))
+		}
+	}
+	return securejoin.SecureJoin(rootfs, ctrPath)
+}
+
+func (r *LocalRuntime) VolumeRemove(ctx context.Context, volumeID string, force bool) error {
+	volume, err := r.volumePath(volumeID)
+	if err != nil {
+		return err
+	}
+	return r.runFSOp(localFSOp{Op: localFSRemove, Path: volume}, nil)
+}
+
+// ImageRemove does nothing, as the local runtime does not store images
+func (r *LocalRuntime) ImageRemove(ctx context.Context, image string, options types.ImageRemoveOptions) ([]image.DeleteResponse, error) {
+	return nil, nil
+}
+
+// extractTar writes the entries of a tar archive to the host paths returned by resolve. Ownership is kept when running
+// as root, in the user namespace of the runtime or otherwise.
+func extractTar(reader io.Reader, resolve func(name string) (string, error)) error {
+	keepOwnership := os.Geteuid() == 0
+	tr := tar.NewReader(reader)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		target, err := resolve(hdr.Name)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+
+		mode := os.FileMode(hdr.Mode).Perm()
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if fi, err := os.Lstat(target); err == nil && !fi.IsDir() {
+				if err := os.Remove(target); err != nil {
+					return err
+				}
+			}
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+			// keep directories writable, so that they can be removed
+			if err := os.Chmod(target, mode|0700); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := removeNonDir(target); err != nil {
+				return err
+			}
+			if err := writeFile(target, tr, mode); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if err := removeNonDir(target); err != nil {
+				return err
+			}
+			if err := os.Symlink(hdr.Linkname, target); err != nil {
+				return err
+			}
+		case tar.TypeLink:
+			source, err := resolve(hdr.Linkname)
+			if err != nil {
+				return err
+			}
+			if err := removeNonDir(target); err != nil {
+				return err
+			}
+			if err := os.Link(source, target); err != nil {
+				return err
+			}
+		default:
+			// device files and fifos cannot be created without privileges, and are not needed to run the lifecycle
+			continue
+		}
+
+		if keepOwnership {
+			if err := os.Lchown(target, hdr.Uid, hdr.Gid); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// createVolume creates the directory of a named volume. Like Docker, a new volume takes the mode and ownership of the
+// directory it is mounted over in the image, so that the builder's user can write to it.
+func createVolume(volume, imageDir string) error {
+	if err := os.MkdirAll(volume, 0755); err != nil {
+		return err
+	}
+
+	fi, err := os.Stat(imageDir)
+	if err != nil || !fi.IsDir() {
+		return nil
+	}
+	if err := os.Chmod(volume, fi.Mode().Perm()|0700); err != nil {
+		return err
+	}
+	if uid, gid, ok := fileOwner(fi); ok && os.Geteuid() == 0 {
+		return os.Chown(volume, uid, gid)
+	}
+	return nil
+}
+
+func removeNonDir(path string) error {
+	if fi, err := os.Lstat(path); err == nil && fi.IsDir() {
+		return removeAll(path)
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func writeFile(path string, content io.Reader, mode os.FileMode) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, content); err != nil {
+		return err
+	}
+	return f.Chmod(mode)
+}
+
+// removeAll removes a directory even if processes left read-only directories in it
+func removeAll(dir string) error {
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && d.IsDir() {
+			if fi, err := d.Info(); err == nil && fi.Mode().Perm()&0700 != 0700 {
+				_ = os.Chmod(path, fi.Mode().Perm()|0700)
+			}
+		}
+		return nil
+	})
+	return os.RemoveAll(dir)
+}
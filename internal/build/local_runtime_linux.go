@@ -0,0 +1,393 @@
+//go:build linux
+// +build linux
+
+package build
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	securejoin "github.com/cyphar/filepath-securejoin"
+	"github.com/docker/docker/pkg/reexec"
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+const (
+	// localRuntimeInit is the name pack is re-executed with to set up the filesystem of a local runtime process
+	localRuntimeInit = "pack-local-runtime-init"
+	// localRuntimeFS is the name pack is re-executed with to operate on the files of a local runtime
+	localRuntimeFS = "pack-local-runtime-fs"
+	// localRuntimeUserNS is the name pack is re-executed with to wait for the IDs of a new user namespace to be mapped.
+	// Executing before then drops all capabilities, so pack is executed again once root is mapped.
+	localRuntimeUserNS = "pack-local-runtime-userns"
+
+	// specFD is the file descriptor re-executed pack reads its spec from, once its user namespace is set up
+	specFD = 3
+	// syncFD is closed by pack once the IDs of the user namespace are mapped
+	syncFD = 4
+)
+
+func init() {
+	reexec.Register(localRuntimeInit, localRuntimeInitMain)
+	reexec.Register(localRuntimeFS, localRuntimeFSMain)
+	reexec.Register(localRuntimeUserNS, localRuntimeUserNSMain)
+}
+
+// newLocalIDMap returns the IDs to map in the user namespace of the runtime, or nil when pack runs as root and
+// processes keep the IDs of the host. Mapping the subordinate IDs of the invoking user requires the setuid
+// newuidmap and newgidmap tools.
+func newLocalIDMap() (*localIDMap, error) {
+	uid, gid := os.Geteuid(), os.Getegid()
+	if uid == 0 {
+		return nil, nil
+	}
+
+	for _, tool := range []string{"newuidmap", "newgidmap"} {
+		if _, err := exec.LookPath(tool); err != nil {
+			return nil, errors.Errorf("the local runtime requires %s to run buildpacks as the builder's user, it is usually provided by the uidmap package", style.Symbol(tool))
+		}
+	}
+
+	current, err := user.Current()
+	if err != nil {
+		return nil, errors.Wrap(err, "looking up current user")
+	}
+	subUIDs, err := lookupSubIDs("/etc/subuid", current.Username, uid)
+	if err != nil {
+		return nil, err
+	}
+	subGIDs, err := lookupSubIDs("/etc/subgid", current.Username, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	return &localIDMap{user: current.Username, uid: uid, gid: gid, subUIDs: subUIDs, subGIDs: subGIDs}, nil
+}
+
+// lookupSubIDs returns the first range of subordinate IDs of a user in file, in the format of /etc/subuid
+func lookupSubIDs(file, username string, uid int) (idRange, error) {
+	contents, err := os.ReadFile(file)
+	if err != nil && !os.IsNotExist(err) {
+		return idRange{}, errors.Wrapf(err, "reading %s", style.Symbol(file))
+	}
+
+	for _, line := range strings.Split(string(contents), "\n") {
+		fields := strings.Split(strings.TrimSpace(line), ":")
+		if len(fields) != 3 || (fields[0] != username && fields[0] != strconv.Itoa(uid)) {
+			continue
+		}
+		start, startErr := strconv.Atoi(fields[1])
+		count, countErr := strconv.Atoi(fields[2])
+		if startErr == nil && countErr == nil && count > 0 {
+			return idRange{start: start, count: count}, nil
+		}
+	}
+	return idRange{}, errors.Errorf("the local runtime requires subordinate IDs of user %s in %s to run buildpacks as the builder's user", style.Symbol(username), style.Symbol(file))
+}
+
+// apply maps the IDs of the user namespace of process pid
+func (m *localIDMap) apply(pid int) error {
+	for _, mapping := range []struct {
+		tool   string
+		id     int
+		subIDs idRange
+	}{
+		{tool: "newuidmap", id: m.uid, subIDs: m.subUIDs},
+		{tool: "newgidmap", id: m.gid, subIDs: m.subGIDs},
+	} {
+		cmd := exec.Command(mapping.tool, strconv.Itoa(pid),
+			"0", strconv.Itoa(mapping.id), "1",
+			"1", strconv.Itoa(mapping.subIDs.start), strconv.Itoa(mapping.subIDs.count),
+		)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return errors.Wrapf(err, "mapping IDs with %s: %s", mapping.tool, strings.TrimSpace(string(out)))
+		}
+	}
+	return nil
+}
+
+// startInNamespace re-executes pack as name, in a new user namespace when ids are to be mapped, and writes spec for
+// it to read once the IDs are mapped.
+func startInNamespace(name string, spec interface{}, ids *localIDMap, cloneflags uintptr, stdin io.Reader, stdout, stderr io.Writer) (*exec.Cmd, error) {
+	specBytes, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	specReader, specWriter, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	defer specWriter.Close()
+	syncReader, syncWriter, err := os.Pipe()
+	if err != nil {
+		specReader.Close()
+		return nil, err
+	}
+	defer syncWriter.Close()
+
+	args := []string{name}
+	if ids != nil {
+		args = []string{localRuntimeUserNS, name}
+	}
+	cmd := reexec.Command(args...)
+	cmd.Stdin = stdin
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.Env = []string{}
+	cmd.ExtraFiles = []*os.File{specReader, syncReader}
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Cloneflags: cloneflags,
+		Pdeathsig:  syscall.SIGKILL,
+	}
+	if ids != nil {
+		cmd.SysProcAttr.Cloneflags |= syscall.CLONE_NEWUSER
+	}
+
+	err = cmd.Start()
+	specReader.Close()
+	syncReader.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	if ids != nil {
+		if err := ids.apply(cmd.Process.Pid); err != nil {
+			_ = cmd.Process.Kill()
+			_ = cmd.Wait()
+			return nil, err
+		}
+	}
+	syncWriter.Close()
+	if _, err := specWriter.Write(specBytes); err != nil {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		return nil, err
+	}
+	return cmd, nil
+}
+
+func localRuntimeUserNSMain() {
+	syncFile := os.NewFile(syncFD, "sync")
+	_, _ = io.Copy(io.Discard, syncFile)
+	syncFile.Close()
+
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, "ERROR: no command specified")
+		os.Exit(127)
+	}
+	if err := unix.Exec(reexec.Self(), os.Args[1:], os.Environ()); err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: failed to enter user namespace: %s\n", err)
+		os.Exit(127)
+	}
+}
+
+// readSpec reads the spec written by startInNamespace
+func readSpec(spec interface{}) error {
+	specFile := os.NewFile(specFD, "spec")
+	defer specFile.Close()
+
+	return errors.Wrap(json.NewDecoder(specFile).Decode(spec), "reading spec")
+}
+
+// runLocalFSOp re-executes pack in the user namespace of the runtime, where localRuntimeFSMain runs op
+func runLocalFSOp(op localFSOp, ids *localIDMap, stdin io.Reader) error {
+	var stderr bytes.Buffer
+	cmd, err := startInNamespace(localRuntimeFS, op, ids, 0, stdin, io.Discard, &stderr)
+	if err != nil {
+		return err
+	}
+
+	if err := cmd.Wait(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return errors.New(msg)
+		}
+		return err
+	}
+	return nil
+}
+
+func localRuntimeFSMain() {
+	var op localFSOp
+	err := readSpec(&op)
+	if err == nil {
+		err = op.run(os.Stdin)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// startLocalProcess re-executes pack in new user and mount namespaces, where localRuntimeInitMain mounts the
+// process's volumes and binds, then changes root into the image filesystem and executes the process.
+func startLocalProcess(process localProcess, ids *localIDMap, stdout, stderr io.Writer) (wait func() (int64, error), kill func(), err error) {
+	cmd, err := startInNamespace(localRuntimeInit, process, ids, syscall.CLONE_NEWNS, nil, stdout, stderr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	wait = func() (int64, error) {
+		err := cmd.Wait()
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+				return 128 + int64(status.Signal()), nil
+			}
+			return int64(exitErr.ExitCode()), nil
+		}
+		return 0, err
+	}
+	kill = func() {
+		_ = cmd.Process.Kill()
+	}
+	return wait, kill, nil
+}
+
+func localRuntimeInitMain() {
+	if err := execLocalProcess(); err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: failed to start process: %s\n", err)
+		os.Exit(127)
+	}
+}
+
+func execLocalProcess() error {
+	var process localProcess
+	if err := readSpec(&process); err != nil {
+		return err
+	}
+	if len(process.Args) == 0 {
+		return errors.New("no command specified")
+	}
+
+	// keep the mounts below from propagating to the host
+	if err := unix.Mount("", "/", "", unix.MS_REC|unix.MS_PRIVATE, ""); err != nil {
+		return errors.Wrap(err, "making mounts private")
+	}
+
+	mounts := []localMount{
+		{Source: "/dev", Target: "/dev"},
+		{Source: "/proc", Target: "/proc"},
+		{Source: "/sys", Target: "/sys", ReadOnly: true},
+	}
+	if _, err := os.Stat("/etc/resolv.conf"); err == nil {
+		mounts = append(mounts, localMount{Source: "/etc/resolv.conf", Target: "/etc/resolv.conf", ReadOnly: true})
+	}
+	for _, mount := range append(mounts, process.Mounts...) {
+		if err := bindMount(process.Rootfs, mount); err != nil {
+			return errors.Wrapf(err, "mounting %s", mount.Target)
+		}
+	}
+
+	if err := unix.Chroot(process.Rootfs); err != nil {
+		return errors.Wrap(err, "changing root")
+	}
+	if err := os.MkdirAll(process.WorkDir, 0755); err != nil {
+		return err
+	}
+	if err := os.Chdir(process.WorkDir); err != nil {
+		return err
+	}
+
+	executable, err := lookPath(process.Args[0], process.Env)
+	if err != nil {
+		return err
+	}
+	return unix.Exec(executable, process.Args, process.Env)
+}
+
+func bindMount(rootfs string, mount localMount) error {
+	fi, err := os.Stat(mount.Source)
+	if err != nil {
+		return err
+	}
+
+	target, err := securejoin.SecureJoin(rootfs, mount.Target)
+	if err != nil {
+		return err
+	}
+	if fi.IsDir() {
+		err = os.MkdirAll(target, 0755)
+	} else {
+		err = createEmptyFile(target)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := unix.Mount(mount.Source, target, "", unix.MS_BIND|unix.MS_REC, ""); err != nil {
+		return err
+	}
+	if !mount.ReadOnly {
+		return nil
+	}
+
+	// flags the source was mounted with must be kept when remounting in a user namespace
+	var stat unix.Statfs_t
+	if err := unix.Statfs(target, &stat); err != nil {
+		return err
+	}
+	flags := uintptr(unix.MS_REMOUNT | unix.MS_BIND | unix.MS_RDONLY)
+	for statFlag, mountFlag := range map[int64]uintptr{
+		unix.ST_NOSUID: unix.MS_NOSUID,
+		unix.ST_NODEV:  unix.MS_NODEV,
+		unix.ST_NOEXEC: unix.MS_NOEXEC,
+	} {
+		if stat.Flags&statFlag != 0 {
+			flags |= mountFlag
+		}
+	}
+	return unix.Mount("", target, "", flags, "")
+}
+
+func fileOwner(fi os.FileInfo) (uid, gid int, ok bool) {
+	stat, ok := fi.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, 0, false
+	}
+	return int(stat.Uid), int(stat.Gid), true
+}
+
+func createEmptyFile(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// lookPath finds an executable using the PATH in the process environment, rather than the environment of pack
+func lookPath(name string, env []string) (string, error) {
+	if strings.Contains(name, "/") {
+		return name, nil
+	}
+
+	for _, kv := range env {
+		key, value, _ := strings.Cut(kv, "=")
+		if key != "PATH" {
+			continue
+		}
+		for _, dir := range filepath.SplitList(value) {
+			path := filepath.Join(dir, name)
+			if fi, err := os.Stat(path); err == nil && !fi.IsDir() && fi.Mode()&0111 != 0 {
+				return path, nil
+			}
+		}
+	}
+	return "", errors.Errorf("executable file %s not found in $PATH", name)
+}
@@ -0,0 +1,302 @@
+package build_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	dcontainer "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/reexec"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/build"
+	"github.com/buildpacks/pack/internal/container"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestMain(m *testing.M) {
+	// processes of the local runtime re-execute the test binary to set up their filesystem
+	if reexec.Init() {
+		return
+	}
+	os.Exit(m.Run())
+}
+
+func TestLocalRuntime(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "LocalRuntime", testLocalRuntime, spec.Report(report.Terminal{}), spec.Sequential())
+}
+
+func testLocalRuntime(t *testing.T, when spec.G, it spec.S) {
+	var (
+		localRuntime *build.LocalRuntime
+		volumesDir   string
+		hostDir      string
+		ctx          = context.Background()
+	)
+
+	var createTar = func(entries map[string]string) *bytes.Buffer {
+		buf := &bytes.Buffer{}
+		tw := tar.NewWriter(buf)
+		for name, contents := range entries {
+			if contents == "" {
+				// directories are owned by the builder's user, as in builder images
+				h.AssertNil(t, tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeDir, Mode: 0755, Uid: 1000, Gid: 1000}))
+				continue
+			}
+			h.AssertNil(t, tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(contents))}))
+			_, err := tw.Write([]byte(contents))
+			h.AssertNil(t, err)
+		}
+		h.AssertNil(t, tw.Close())
+		return buf
+	}
+
+	var readTar = func(reader io.Reader) map[string]string {
+		entries := map[string]string{}
+		tr := tar.NewReader(reader)
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				return entries
+			}
+			h.AssertNil(t, err)
+			contents, err := io.ReadAll(tr)
+			h.AssertNil(t, err)
+			entries[hdr.Name] = string(contents)
+		}
+	}
+
+	it.Before(func() {
+		var err error
+		volumesDir = t.TempDir()
+		hostDir = t.TempDir()
+
+		layer, err := tarball.LayerFromOpener(func() (io.ReadCloser, error) {
+			return io.NopCloser(createTar(map[string]string{
+				"cnb/":                  "",
+				"cnb/lifecycle/creator": "some-creator",
+				"etc/":                  "",
+				"etc/os-release":        "some-os",
+			})), nil
+		})
+		h.AssertNil(t, err)
+		img, err := mutate.AppendLayers(empty.Image, layer)
+		h.AssertNil(t, err)
+
+		localRuntime, err = build.NewLocalRuntime(img, "some/builder", volumesDir)
+		h.AssertNil(t, err)
+	})
+
+	it.After(func() {
+		h.AssertNil(t, localRuntime.Close())
+	})
+
+	when("#ContainerCreate", func() {
+		it("fails for images other than the unpacked image", func() {
+			_, err := localRuntime.ContainerCreate(ctx, &dcontainer.Config{Image: "some/other-image"}, nil, nil, nil, "")
+			h.AssertError(t, err, "image 'some/other-image' is not available to the local runtime")
+			h.AssertTrue(t, errdefs.IsNotFound(err))
+		})
+
+		it("fails for unsupported network modes", func() {
+			_, err := localRuntime.ContainerCreate(ctx, &dcontainer.Config{Image: "some/builder"}, &dcontainer.HostConfig{NetworkMode: "some-network"}, nil, nil, "")
+			h.AssertError(t, err, "network mode 'some-network' is not supported by the local runtime")
+		})
+
+		it("creates named volumes", func() {
+			_, err := localRuntime.ContainerCreate(ctx, &dcontainer.Config{Image: "some/builder"}, &dcontainer.HostConfig{
+				Binds: []string{"some-volume:/layers"},
+			}, nil, nil, "")
+			h.AssertNil(t, err)
+			h.AssertTrue(t, fileExists(filepath.Join(volumesDir, "some-volume")))
+		})
+
+		it("fails for volume names that are paths", func() {
+			_, err := localRuntime.ContainerCreate(ctx, &dcontainer.Config{Image: "some/builder"}, &dcontainer.HostConfig{
+				Binds: []string{"../some-volume:/layers"},
+			}, nil, nil, "")
+			h.AssertError(t, err, "invalid volume name '../some-volume'")
+		})
+	})
+
+	when("copying files", func() {
+		var ctrID string
+
+		it.Before(func() {
+			ctr, err := localRuntime.ContainerCreate(ctx, &dcontainer.Config{Image: "some/builder"}, &dcontainer.HostConfig{
+				Binds: []string{"some-volume:/layers", hostDir + ":/workspace"},
+			}, nil, nil, "")
+			h.AssertNil(t, err)
+			ctrID = ctr.ID
+		})
+
+		it("copies files into the image filesystem, volumes and binds", func() {
+			err := localRuntime.CopyToContainer(ctx, ctrID, "/", createTar(map[string]string{
+				"layers/some-file":    "some-layer",
+				"workspace/some-file": "some-app",
+				"etc/some-file":       "some-config",
+			}), types.CopyToContainerOptions{})
+			h.AssertNil(t, err)
+
+			contents, err := os.ReadFile(filepath.Join(volumesDir, "some-volume", "some-file"))
+			h.AssertNil(t, err)
+			h.AssertEq(t, string(contents), "some-layer")
+
+			contents, err = os.ReadFile(filepath.Join(hostDir, "some-file"))
+			h.AssertNil(t, err)
+			h.AssertEq(t, string(contents), "some-app")
+
+			reader, _, err := localRuntime.CopyFromContainer(ctx, ctrID, "/etc/some-file")
+			h.AssertNil(t, err)
+			defer reader.Close()
+			h.AssertEq(t, readTar(reader), map[string]string{"some-file": "some-config"})
+		})
+
+		it("copies directories out of the container under the container path's name", func() {
+			h.AssertNil(t, os.WriteFile(filepath.Join(hostDir, "report.toml"), []byte("some-report"), 0600))
+
+			reader, stat, err := localRuntime.CopyFromContainer(ctx, ctrID, "/workspace")
+			h.AssertNil(t, err)
+			defer reader.Close()
+			h.AssertEq(t, stat.Name, "workspace")
+			h.AssertEq(t, readTar(reader), map[string]string{
+				"workspace/":            "",
+				"workspace/report.toml": "some-report",
+			})
+		})
+
+		it("returns a not found error for missing files", func() {
+			_, _, err := localRuntime.CopyFromContainer(ctx, ctrID, "/layers/missing")
+			h.AssertTrue(t, errdefs.IsNotFound(err))
+		})
+
+		it("keeps paths within the image filesystem", func() {
+			err := localRuntime.CopyToContainer(ctx, ctrID, "/", createTar(map[string]string{
+				"../../some-file": "some-contents",
+			}), types.CopyToContainerOptions{})
+			h.AssertNil(t, err)
+
+			reader, _, err := localRuntime.CopyFromContainer(ctx, ctrID, "/some-file")
+			h.AssertNil(t, err)
+			defer reader.Close()
+			h.AssertEq(t, readTar(reader), map[string]string{"some-file": "some-contents"})
+		})
+	})
+
+	when("ownership", func() {
+		var (
+			ctrID  string
+			owners func(reader io.Reader) map[string]int
+		)
+
+		it.Before(func() {
+			h.SkipIf(t, os.Geteuid() != 0, "files can only be owned by other users when running as root or in a user namespace")
+
+			owners = func(reader io.Reader) map[string]int {
+				result := map[string]int{}
+				tr := tar.NewReader(reader)
+				for {
+					hdr, err := tr.Next()
+					if err == io.EOF {
+						return result
+					}
+					h.AssertNil(t, err)
+					result[hdr.Name] = hdr.Uid
+				}
+			}
+
+			ctr, err := localRuntime.ContainerCreate(ctx, &dcontainer.Config{Image: "some/builder"}, &dcontainer.HostConfig{
+				Binds: []string{"some-volume:/cnb"},
+			}, nil, nil, "")
+			h.AssertNil(t, err)
+			ctrID = ctr.ID
+		})
+
+		it("keeps the ownership of copied files", func() {
+			buf := &bytes.Buffer{}
+			tw := tar.NewWriter(buf)
+			h.AssertNil(t, tw.WriteHeader(&tar.Header{Name: "workspace/", Typeflag: tar.TypeDir, Mode: 0755, Uid: 1000, Gid: 1000}))
+			h.AssertNil(t, tw.WriteHeader(&tar.Header{Name: "workspace/app.txt", Typeflag: tar.TypeReg, Mode: 0644, Uid: 1000, Gid: 1000}))
+			h.AssertNil(t, tw.Close())
+			h.AssertNil(t, localRuntime.CopyToContainer(ctx, ctrID, "/", buf, types.CopyToContainerOptions{}))
+
+			reader, _, err := localRuntime.CopyFromContainer(ctx, ctrID, "/workspace")
+			h.AssertNil(t, err)
+			defer reader.Close()
+			h.AssertEq(t, owners(reader), map[string]int{"workspace/": 1000, "workspace/app.txt": 1000})
+		})
+
+		it("creates volumes owned by the owner of the image directory they are mounted over", func() {
+			reader, _, err := localRuntime.CopyFromContainer(ctx, ctrID, "/cnb")
+			h.AssertNil(t, err)
+			defer reader.Close()
+			h.AssertEq(t, owners(reader), map[string]int{"cnb/": 1000})
+		})
+	})
+
+	when("#VolumeRemove", func() {
+		it("removes the volume directory", func() {
+			_, err := localRuntime.ContainerCreate(ctx, &dcontainer.Config{Image: "some/builder"}, &dcontainer.HostConfig{
+				Binds: []string{"some-volume:/layers"},
+			}, nil, nil, "")
+			h.AssertNil(t, err)
+			h.AssertNil(t, os.MkdirAll(filepath.Join(volumesDir, "some-volume", "read-only"), 0500))
+
+			h.AssertNil(t, localRuntime.VolumeRemove(ctx, "some-volume", true))
+			h.AssertFalse(t, fileExists(filepath.Join(volumesDir, "some-volume")))
+		})
+	})
+
+	when("running processes", func() {
+		it.Before(func() {
+			h.SkipIf(t, runtime.GOOS != "linux", "the local runtime is only supported on Linux")
+		})
+
+		it("runs the command and reports its output and exit code", func() {
+			// the host's shell is mounted, as the unpacked image has none
+			var binds []string
+			for _, dir := range []string{"/bin", "/lib", "/lib64", "/usr"} {
+				if fileExists(dir) {
+					binds = append(binds, dir+":"+dir+":ro")
+				}
+			}
+
+			ctr, err := localRuntime.ContainerCreate(ctx, &dcontainer.Config{
+				Image: "some/builder",
+				Cmd:   []string{"/bin/sh", "-c", `cat /etc/os-release; echo " $CNB_USER_ID" >&2; exit 3`},
+				Env:   []string{"CNB_USER_ID=1000"},
+			}, &dcontainer.HostConfig{Binds: binds}, nil, nil, "")
+			h.AssertNil(t, err)
+			defer localRuntime.ContainerRemove(ctx, ctr.ID, dcontainer.RemoveOptions{Force: true})
+
+			outBuf, errBuf := &bytes.Buffer{}, &bytes.Buffer{}
+			err = container.RunWithHandler(ctx, localRuntime, ctr.ID, container.DefaultHandler(outBuf, errBuf))
+
+			var exitErr *container.ExitError
+			h.AssertTrue(t, errors.As(err, &exitErr))
+			h.AssertEq(t, exitErr.StatusCode, int64(3))
+			h.AssertEq(t, outBuf.String(), "some-os")
+			h.AssertEq(t, errBuf.String(), " 1000\n")
+		})
+	})
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
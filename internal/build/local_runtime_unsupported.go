@@ -0,0 +1,28 @@
+//go:build !linux
+// +build !linux
+
+package build
+
+import (
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// newLocalIDMap returns nil, as processes cannot run on this platform and files keep the IDs of the host
+func newLocalIDMap() (*localIDMap, error) {
+	return nil, nil
+}
+
+func runLocalFSOp(op localFSOp, ids *localIDMap, stdin io.Reader) error {
+	return errors.New("the local runtime is only supported on Linux")
+}
+
+func fileOwner(fi os.FileInfo) (uid, gid int, ok bool) {
+	return 0, 0, false
+}
+
+func startLocalProcess(process localProcess, ids *localIDMap, stdout, stderr io.Writer) (wait func() (int64, error), kill func(), err error) {
+	return nil, nil, errors.New("the local runtime is only supported on Linux")
+}
@@ -3,38 +3,72 @@ package build
 import (
 	"context"
 	"io"
+	"time"
 
 	dcontainer "github.com/docker/docker/api/types/container"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
 
 	"github.com/buildpacks/pack/internal/container"
+	"github.com/buildpacks/pack/pkg/logging"
 )
 
 type Phase struct {
 	name                string
+	logger              logging.Logger
 	infoWriter          io.Writer
 	errorWriter         io.Writer
 	docker              DockerClient
 	handler             container.Handler
 	ctrConf             *dcontainer.Config
 	hostConf            *dcontainer.HostConfig
+	platform            *specs.Platform
 	ctr                 dcontainer.CreateResponse
 	uid, gid            int
 	appPath             string
 	containerOps        []ContainerOperation
 	postContainerRunOps []ContainerOperation
 	fileFilter          func(string) bool
+	stats               *statsRecorder
 }
 
 func (p *Phase) Run(ctx context.Context) error {
+	start := time.Now()
+	logging.LogEvent(p.logger, logging.Event{Type: logging.EventPhaseStarted, Phase: p.name})
+
+	docker := &uploadCountingClient{DockerClient: p.docker}
+	err := p.run(ctx, docker)
+
+	duration := time.Since(start)
+	if p.stats != nil {
+		p.stats.recordPhase(p.name, duration, docker.uploaded())
+	}
+
+	event := logging.Event{Type: logging.EventPhaseFinished, Phase: p.name}.WithDuration(duration)
+	var exitErr *container.ExitError
+	switch {
+	case err == nil:
+		event = event.WithExitCode(0)
+	case errors.As(err, &exitErr):
+		event = event.WithExitCode(exitErr.StatusCode)
+		event.Error = err.Error()
+	default:
+		event.Error = err.Error()
+	}
+	logging.LogEvent(p.logger, event)
+
+	return err
+}
+
+func (p *Phase) run(ctx context.Context, docker DockerClient) error {
 	var err error
-	p.ctr, err = p.docker.ContainerCreate(ctx, p.ctrConf, p.hostConf, nil, nil, "")
+	p.ctr, err = docker.ContainerCreate(ctx, p.ctrConf, p.hostConf, nil, p.platform, "")
 	if err != nil {
 		return errors.Wrapf(err, "failed to create '%s' container", p.name)
 	}
 
 	for _, containerOp := range p.containerOps {
-		if err := containerOp(p.docker, ctx, p.ctr.ID, p.infoWriter, p.errorWriter); err != nil {
+		if err := containerOp(docker, ctx, p.ctr.ID, p.infoWriter, p.errorWriter); err != nil {
 			return err
 		}
 	}
@@ -46,7 +80,7 @@ func (p *Phase) Run(ctx context.Context) error {
 
 	err = container.RunWithHandler(
 		ctx,
-		p.docker,
+		docker,
 		p.ctr.ID,
 		handler)
 	if err != nil {
@@ -54,7 +88,7 @@ func (p *Phase) Run(ctx context.Context) error {
 	}
 
 	for _, containerOp := range p.postContainerRunOps {
-		if err := containerOp(p.docker, ctx, p.ctr.ID, p.infoWriter, p.errorWriter); err != nil {
+		if err := containerOp(docker, ctx, p.ctr.ID, p.infoWriter, p.errorWriter); err != nil {
 			return err
 		}
 	}
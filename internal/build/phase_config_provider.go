@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/docker/docker/api/types/container"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
 
 	pcontainer "github.com/buildpacks/pack/internal/container"
 	"github.com/buildpacks/pack/internal/style"
@@ -26,6 +27,7 @@ type PhaseConfigProvider struct {
 	hostConf            *container.HostConfig
 	name                string
 	os                  string
+	platform            *specs.Platform
 	containerOps        []ContainerOperation
 	postContainerRunOps []ContainerOperation
 	infoWriter          io.Writer
@@ -39,6 +41,7 @@ func NewPhaseConfigProvider(name string, lifecycleExec *LifecycleExecution, ops
 		hostConf:    new(container.HostConfig),
 		name:        name,
 		os:          lifecycleExec.os,
+		platform:    lifecycleExec.opts.Platform,
 		infoWriter:  logging.GetWriterForLevel(lifecycleExec.logger, logging.InfoLevel),
 		errorWriter: logging.GetWriterForLevel(lifecycleExec.logger, logging.ErrorLevel),
 	}
@@ -63,6 +66,14 @@ func NewPhaseConfigProvider(name string, lifecycleExec *LifecycleExecution, ops
 		op(provider)
 	}
 
+	switch {
+	case name == "exporter" || name == "creator":
+		// the exporter output is always scanned, to count the layers in the build stats
+		provider.infoWriter = newEventWriter(provider.infoWriter, lifecycleExec.logger, name, lifecycleExec.stats)
+	case logging.EventsEnabled(lifecycleExec.logger):
+		provider.infoWriter = newEventWriter(provider.infoWriter, lifecycleExec.logger, name, nil)
+	}
+
 	provider.ctrConf.Entrypoint = []string{""} // override entrypoint in case it is set
 	provider.ctrConf.Cmd = append([]string{"/cnb/lifecycle/" + name}, provider.ctrConf.Cmd...)
 
@@ -73,6 +84,9 @@ func NewPhaseConfigProvider(name string, lifecycleExec *LifecycleExecution, ops
 	lifecycleExec.logger.Debugf("  Image: %s", style.Symbol(provider.ctrConf.Image))
 	lifecycleExec.logger.Debugf("  User: %s", style.Symbol(provider.ctrConf.User))
 	lifecycleExec.logger.Debugf("  Labels: %s", style.Symbol(fmt.Sprintf("%s", provider.ctrConf.Labels)))
+	if provider.platform != nil {
+		lifecycleExec.logger.Debugf("  Platform: %s", style.Symbol(strings.TrimSuffix(provider.platform.OS+"/"+provider.platform.Architecture+"/"+provider.platform.Variant, "/")))
+	}
 
 	lifecycleExec.logger.Debug("Host Settings:")
 	lifecycleExec.logger.Debugf("  Binds: %s", style.Symbol(strings.Join(provider.hostConf.Binds, " ")))
@@ -117,6 +131,11 @@ func (p *PhaseConfigProvider) Handler() pcontainer.Handler {
 	return p.handler
 }
 
+// Platform returns the platform the phase container is created for, or nil to use the daemon's platform.
+func (p *PhaseConfigProvider) Platform() *specs.Platform {
+	return p.platform
+}
+
 func (p *PhaseConfigProvider) Name() string {
 	return p.name
 }
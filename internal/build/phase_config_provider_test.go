@@ -10,6 +10,7 @@ import (
 	"github.com/docker/docker/api/types/strslice"
 	"github.com/docker/docker/client"
 	"github.com/heroku/color"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
 	"github.com/sclevine/spec"
 	"github.com/sclevine/spec/report"
@@ -59,6 +60,7 @@ func testPhaseConfigProvider(t *testing.T, when spec.G, it spec.S) {
 			h.AssertSliceContainsMatch(t, phaseConfigProvider.HostConfig().Binds, "pack-app-.*:/workspace")
 
 			h.AssertEq(t, phaseConfigProvider.HostConfig().Isolation, container.IsolationEmpty)
+			h.AssertNil(t, phaseConfigProvider.Platform())
 		})
 
 		when("building for Windows", func() {
@@ -75,6 +77,63 @@ func testPhaseConfigProvider(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("building for a platform", func() {
+			it("sets the container platform", func() {
+				platform := &specs.Platform{OS: "linux", Architecture: "arm64"}
+				lifecycle := newTestLifecycleExec(t, false, "some-temp-dir", func(opts *build.LifecycleOptions) {
+					opts.Platform = platform
+				})
+
+				phaseConfigProvider := build.NewPhaseConfigProvider("some-name", lifecycle)
+
+				h.AssertEq(t, phaseConfigProvider.Platform(), platform)
+			})
+		})
+
+		when("the logger emits events", func() {
+			it("emits events for the lifecycle output it recognizes", func() {
+				var outBuf bytes.Buffer
+				logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+				logger.WantJSON(true)
+				docker, err := client.NewClientWithOpts(client.FromEnv, client.WithVersion("1.38"))
+				h.AssertNil(t, err)
+				fakeBuilder, err := fakes.NewFakeBuilder()
+				h.AssertNil(t, err)
+				lifecycle, err := build.NewLifecycleExecution(logger, docker, "some-temp-dir", build.LifecycleOptions{Builder: fakeBuilder, Termui: &fakes.FakeTermui{}})
+				h.AssertNil(t, err)
+
+				phaseConfigProvider := build.NewPhaseConfigProvider("creator", lifecycle)
+				writer := phaseConfigProvider.InfoWriter()
+				_, err = writer.Write([]byte("2 of 3 buildpacks participating\nsome/bp 1.0.0\nother/bp 2.0.0\n===> ANALYZING\n"))
+				h.AssertNil(t, err)
+				_, err = writer.Write([]byte("Adding layer 'some/bp:launch'\nReusing cache layer 'other/bp:cache'\n"))
+				h.AssertNil(t, err)
+				h.AssertNil(t, writer.(io.Closer).Close())
+
+				output := outBuf.String()
+				h.AssertContains(t, output, `"type":"log","level":"info","message":"some/bp 1.0.0"`)
+				h.AssertContains(t, output, `"type":"group_selected","phase":"creator","buildpacks":["some/bp@1.0.0","other/bp@2.0.0"]`)
+				h.AssertContains(t, output, `"type":"layer_added","phase":"creator","layer":"some/bp:launch"`)
+				h.AssertContains(t, output, `"type":"layer_reused","phase":"creator","cache_type":"build","layer":"other/bp:cache"`)
+			})
+		})
+
+		when("the phase exports the image", func() {
+			it("counts the layers added and reused", func() {
+				lifecycle := newTestLifecycleExec(t, false, "some-temp-dir")
+
+				phaseConfigProvider := build.NewPhaseConfigProvider("exporter", lifecycle)
+				writer := phaseConfigProvider.InfoWriter()
+				_, err := writer.Write([]byte("Adding layer 'some/bp:launch'\nReusing layer 'other/bp:launch'\nReusing layer 'launcher'\nAdding cache layer 'other/bp:cache'\n"))
+				h.AssertNil(t, err)
+				h.AssertNil(t, writer.(io.Closer).Close())
+
+				stats := lifecycle.Stats()
+				h.AssertEq(t, stats.LayersAdded, 1)
+				h.AssertEq(t, stats.LayersReused, 2)
+			})
+		})
+
 		when("building with interactive mode", func() {
 			it("returns a phase config provider with interactive args", func() {
 				handler := func(bodyChan <-chan container.WaitResponse, errChan <-chan error, reader io.Reader) error {
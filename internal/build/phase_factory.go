@@ -25,7 +25,9 @@ func (m *DefaultPhaseFactory) New(provider *PhaseConfigProvider) RunnerCleaner {
 	return &Phase{
 		ctrConf:             provider.ContainerConfig(),
 		hostConf:            provider.HostConfig(),
+		platform:            provider.Platform(),
 		name:                provider.Name(),
+		logger:              m.lifecycleExec.logger,
 		docker:              m.lifecycleExec.docker,
 		infoWriter:          provider.InfoWriter(),
 		errorWriter:         provider.ErrorWriter(),
@@ -36,5 +38,6 @@ func (m *DefaultPhaseFactory) New(provider *PhaseConfigProvider) RunnerCleaner {
 		containerOps:        provider.containerOps,
 		postContainerRunOps: provider.postContainerRunOps,
 		fileFilter:          m.lifecycleExec.opts.FileFilter,
+		stats:               m.lifecycleExec.stats,
 	}
 }
@@ -0,0 +1,466 @@
+package build
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/buildpacks/lifecycle/auth"
+	darchive "github.com/docker/docker/pkg/archive"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/buildpacks/pack/internal/builder"
+	"github.com/buildpacks/pack/internal/container"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/archive"
+	"github.com/buildpacks/pack/pkg/cache"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// remoteCacheDir is where build caches are kept between builds, relative to the home directory of the remote user
+const remoteCacheDir = ".pack/remote/cache"
+
+// RemoteShell runs commands on the host that builds are shipped to by `pack build --remote-host`.
+type RemoteShell interface {
+	// Run runs cmd with the shell of the remote user. A non-zero exit status is returned as a *container.ExitError.
+	Run(ctx context.Context, cmd string, stdin io.Reader, stdout, stderr io.Writer) error
+	// Close releases the connection to the remote host.
+	Close() error
+}
+
+type sshShell struct {
+	client *ssh.Client
+}
+
+// NewSSHShell returns a RemoteShell that runs each command in a new session of client. Closing the shell closes client.
+func NewSSHShell(client *ssh.Client) RemoteShell {
+	return &sshShell{client: client}
+}
+
+func (s *sshShell) Close() error {
+	return s.client.Close()
+}
+
+func (s *sshShell) Run(ctx context.Context, cmd string, stdin io.Reader, stdout, stderr io.Writer) error {
+	session, err := s.client.NewSession()
+	if err != nil {
+		return errors.Wrap(err, "opening ssh session")
+	}
+	defer session.Close()
+
+	session.Stdin = stdin
+	session.Stdout = stdout
+	session.Stderr = stderr
+	if err := session.Start(cmd); err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- session.Wait()
+	}()
+
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		_ = session.Signal(ssh.SIGTERM)
+		return ctx.Err()
+	}
+
+	var exitErr *ssh.ExitError
+	if errors.As(err, &exitErr) {
+		return &container.ExitError{StatusCode: int64(exitErr.ExitStatus())}
+	}
+	return err
+}
+
+// RunRemote runs the creator on the remote host reachable through shell. The lifecycle, buildpacks and app are
+// uploaded to a temporary directory, so the remote host needs nothing but a POSIX shell and tar, and the build
+// cache is kept in the home directory of the remote user. The lifecycle and buildpacks run directly on the remote
+// host, which must have the OS and architecture of the builder.
+func (l *LifecycleExecution) RunRemote(ctx context.Context, shell RemoteShell) error {
+	start := time.Now()
+	if l.os == "windows" {
+		return errors.New("remote builds are not supported for Windows builders")
+	}
+	if l.platformAPI.AtLeast("0.10") && l.hasExtensions() {
+		return errors.New("builder has an order for extensions which is not supported by remote builds")
+	}
+
+	underlying, ok := l.opts.Builder.Image().(interface{ UnderlyingImage() v1.Image })
+	if !ok {
+		return errors.Errorf("builder %s cannot be unpacked for a remote build", style.Symbol(l.opts.Builder.Name()))
+	}
+	if err := l.checkRemotePlatform(ctx, shell); err != nil {
+		return err
+	}
+
+	out := &bytes.Buffer{}
+	if err := shell.Run(ctx, `mktemp -d && echo "$HOME"`, nil, out, logging.GetWriterForLevel(l.logger, logging.ErrorLevel)); err != nil {
+		return errors.Wrap(err, "creating remote work directory")
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		return errors.Errorf("unexpected output creating remote work directory: %s", style.Symbol(out.String()))
+	}
+	workDir, homeDir := strings.TrimSpace(lines[0]), strings.TrimSpace(lines[1])
+	l.logger.Debugf("Using remote work directory %s", style.Symbol(workDir))
+	defer func() {
+		if err := shell.Run(context.Background(), "rm -rf "+shellQuote(workDir), nil, io.Discard, io.Discard); err != nil {
+			l.logger.Warnf("failed to clean up remote work directory %s: %s", style.Symbol(workDir), err)
+		}
+	}()
+
+	flags, cacheDir, err := l.remoteCreatorFlags(workDir, homeDir)
+	if err != nil {
+		return err
+	}
+	if cacheDir != "" {
+		prepareCache := "mkdir -p " + shellQuote(cacheDir)
+		if l.opts.ClearCache {
+			prepareCache = "rm -rf " + shellQuote(cacheDir) + " && " + prepareCache
+			l.logger.Debugf("Build cache %s cleared", style.Symbol(cacheDir))
+		}
+		if err := shell.Run(ctx, prepareCache, nil, io.Discard, logging.GetWriterForLevel(l.logger, logging.ErrorLevel)); err != nil {
+			return errors.Wrap(err, "preparing remote build cache")
+		}
+		logging.LogEvent(l.logger, logging.Event{Type: logging.EventCache, CacheType: "build", Cache: cacheDir})
+	}
+
+	env, err := l.remoteEnv()
+	if err != nil {
+		return err
+	}
+
+	phaseStart := time.Now()
+	logging.LogEvent(l.logger, logging.Event{Type: logging.EventPhaseStarted, Phase: "creator"})
+
+	uploads := &uploadCountingClient{}
+	inputs := archive.GenerateTar(func(tw archive.TarWriter) error {
+		return l.writeRemoteInputs(tw, underlying.UnderlyingImage(), env)
+	})
+	defer inputs.Close()
+	err = shell.Run(ctx, "tar -x -f - -C "+shellQuote(workDir), &countingReader{reader: inputs, client: uploads}, io.Discard, logging.GetWriterForLevel(l.logger, logging.ErrorLevel))
+	if err != nil {
+		err = errors.Wrap(err, "uploading build inputs")
+	} else {
+		infoWriter := newEventWriter(logging.GetWriterForLevel(l.logger, logging.InfoLevel), l.logger, "creator", l.stats)
+		cmd := fmt.Sprintf("cd %s && . ./env && exec %s", shellQuote(workDir), shellJoin(append([]string{path.Join(workDir, "cnb", "lifecycle", "creator")}, flags...)))
+		l.logger.Debugf("Running the %s on the remote host with:", style.Symbol("creator"))
+		l.logger.Debugf("  Args: %s", style.Symbol(strings.Join(flags, " ")))
+		err = shell.Run(ctx, cmd, nil, infoWriter, logging.GetWriterForLevel(l.logger, logging.ErrorLevel))
+		infoWriter.Close()
+	}
+
+	duration := time.Since(phaseStart)
+	l.stats.recordPhase("creator", duration, uploads.uploaded())
+	event := logging.Event{Type: logging.EventPhaseFinished, Phase: "creator"}.WithDuration(duration)
+	var exitErr *container.ExitError
+	switch {
+	case err == nil:
+		event = event.WithExitCode(0)
+	case errors.As(err, &exitErr):
+		event = event.WithExitCode(exitErr.StatusCode)
+		event.Error = err.Error()
+	default:
+		event.Error = err.Error()
+	}
+	logging.LogEvent(l.logger, event)
+	if err != nil {
+		return err
+	}
+
+	layersDir := path.Join(workDir, "layers")
+	if l.opts.SBOMDestinationDir != "" {
+		if err := l.downloadRemote(ctx, shell, layersDir, "sbom", l.opts.SBOMDestinationDir); err != nil {
+			return errors.Wrap(err, "downloading sbom")
+		}
+	}
+	if l.opts.ReportDestinationDir != "" {
+		if err := l.downloadRemote(ctx, shell, layersDir, "report.toml", l.opts.ReportDestinationDir); err != nil {
+			return errors.Wrap(err, "downloading report")
+		}
+	}
+
+	return l.reportStats(time.Since(start))
+}
+
+// checkRemotePlatform fails unless the OS and architecture of the remote host are those of the builder, as its
+// lifecycle and buildpacks run directly on the host
+func (l *LifecycleExecution) checkRemotePlatform(ctx context.Context, shell RemoteShell) error {
+	arch, err := l.opts.Builder.Image().Architecture()
+	if err != nil {
+		return errors.Wrapf(err, "reading architecture of builder %s", style.Symbol(l.opts.Builder.Name()))
+	}
+
+	out := &bytes.Buffer{}
+	if err := shell.Run(ctx, "uname -s && uname -m", nil, out, logging.GetWriterForLevel(l.logger, logging.ErrorLevel)); err != nil {
+		return errors.Wrap(err, "reading remote platform")
+	}
+	fields := strings.Fields(out.String())
+	if len(fields) != 2 {
+		return errors.Errorf("unexpected output reading remote platform: %s", style.Symbol(out.String()))
+	}
+
+	hostOS, hostArch := strings.ToLower(fields[0]), unameArch(fields[1])
+	if hostOS != l.os || (arch != "" && hostArch != arch) {
+		return errors.Errorf(
+			"remote host platform %s does not match platform %s of builder %s",
+			style.Symbol(hostOS+"/"+hostArch),
+			style.Symbol(l.os+"/"+arch),
+			style.Symbol(l.opts.Builder.Name()),
+		)
+	}
+	return nil
+}
+
+// unameArch returns the architecture of images for the machine hardware name printed by `uname -m`
+func unameArch(machine string) string {
+	switch machine {
+	case "x86_64":
+		return "amd64"
+	case "aarch64", "arm64":
+		return "arm64"
+	case "i386", "i686":
+		return "386"
+	}
+	if strings.HasPrefix(machine, "armv") {
+		return "arm"
+	}
+	return machine
+}
+
+// remoteCreatorFlags returns the creator flags for a build in workDir, and the remote build cache directory unless
+// the build cache is an image
+func (l *LifecycleExecution) remoteCreatorFlags(workDir, homeDir string) ([]string, string, error) {
+	cnbDir := path.Join(workDir, "cnb")
+	layersDir := path.Join(workDir, "layers")
+
+	flags := addTags([]string{
+		"-app", path.Join(workDir, l.mountPaths.appDirName()),
+		"-layers", layersDir,
+		"-platform", path.Join(workDir, "platform"),
+		"-buildpacks", path.Join(cnbDir, "buildpacks"),
+		"-launcher", path.Join(cnbDir, "lifecycle", "launcher"),
+		"-order", path.Join(cnbDir, "order.toml"),
+		"-stack", path.Join(cnbDir, "stack.toml"),
+		"-report", path.Join(layersDir, "report.toml"),
+		"-project-metadata", path.Join(layersDir, "project-metadata.toml"),
+		"-run-image", l.opts.RunImage,
+	}, l.opts.AdditionalTags)
+
+	if l.platformAPI.AtLeast("0.11") {
+		flags = append(flags,
+			"-build-config", path.Join(cnbDir, "build-config"),
+			"-launcher-sbom", path.Join(cnbDir, "lifecycle"),
+		)
+	}
+	if l.platformAPI.AtLeast("0.12") {
+		flags = append(flags, "-run", path.Join(cnbDir, "run.toml"))
+	}
+
+	if l.opts.ClearCache {
+		flags = append(flags, "-skip-restore")
+	}
+	if l.opts.GID >= overrideGID {
+		flags = append(flags, "-gid", strconv.Itoa(l.opts.GID))
+	}
+	if l.opts.UID >= overrideUID {
+		flags = append(flags, "-uid", strconv.Itoa(l.opts.UID))
+	}
+	if l.opts.PreviousImage != "" {
+		flags = append(flags, "-previous-image", l.opts.PreviousImage)
+	}
+	if processType := determineDefaultProcessType(l.platformAPI, l.opts.DefaultProcessType); processType != "" {
+		flags = append(flags, "-process-type", processType)
+	}
+
+	var cacheDir string
+	switch {
+	case l.opts.CacheImage != "":
+		flags = append(flags, "-cache-image", l.opts.CacheImage)
+	case l.opts.Cache.Build.Format == cache.CacheImage:
+		flags = append(flags, "-cache-image", l.opts.Cache.Build.Source)
+	case l.opts.Cache.Build.Format == cache.CacheVolume:
+		cacheDir = path.Join(homeDir, remoteCacheDir, cache.NewVolumeCache(l.opts.Image, l.opts.Cache.Build, "build", nil).Name())
+	case l.opts.Cache.Build.Format == cache.CacheBind:
+		// the source of a bind cache is a directory on the remote host
+		cacheDir = l.opts.Cache.Build.Source
+	default:
+		return nil, "", errors.Errorf("cache format %s is not supported for remote builds", style.Symbol(l.opts.Cache.Build.Format.String()))
+	}
+	if cacheDir != "" {
+		flags = append(flags, "-cache-dir", cacheDir)
+	}
+
+	return append(l.withLogLevel(flags...), l.opts.Image.String()), cacheDir, nil
+}
+
+// remoteEnv returns the environment of the remote creator as shell statements. The creator runs as the remote user,
+// and exports layers owned by the user of the builder, as the app is.
+func (l *LifecycleExecution) remoteEnv() (string, error) {
+	env := [][2]string{
+		{platformAPIEnvVar, l.platformAPI.String()},
+		{builder.EnvUID, strconv.Itoa(l.opts.Builder.UID())},
+		{builder.EnvGID, strconv.Itoa(l.opts.Builder.GID())},
+	}
+
+	authConfig, err := auth.BuildEnvVar(l.opts.Keychain, l.opts.Image.String(), l.opts.RunImage, l.opts.CacheImage, l.opts.PreviousImage)
+	if err != nil {
+		return "", err
+	}
+	env = append(env, [2]string{auth.EnvRegistryAuth, authConfig})
+
+	for _, proxy := range [][2]string{{"HTTP_PROXY", l.opts.HTTPProxy}, {"HTTPS_PROXY", l.opts.HTTPSProxy}, {"NO_PROXY", l.opts.NoProxy}} {
+		if proxy[1] != "" {
+			env = append(env, proxy, [2]string{strings.ToLower(proxy[0]), proxy[1]})
+		}
+	}
+	if l.opts.CreationTime != nil && l.platformAPI.AtLeast("0.9") {
+		env = append(env, [2]string{sourceDateEpochEnv, strconv.Itoa(int(l.opts.CreationTime.Unix()))})
+	}
+
+	buf := &strings.Builder{}
+	for _, kv := range env {
+		fmt.Fprintf(buf, "export %s=%s\n", kv[0], shellQuote(kv[1]))
+	}
+	return buf.String(), nil
+}
+
+// writeRemoteInputs writes everything the creator reads to tw: the lifecycle, buildpacks and configuration from
+// the builder, the app, the project metadata, and an env file to be sourced before running the creator
+func (l *LifecycleExecution) writeRemoteInputs(tw archive.TarWriter, builderImage v1.Image, env string) error {
+	for _, dir := range []string{"layers", "platform"} {
+		if err := tw.WriteHeader(&tar.Header{Name: dir, Typeflag: tar.TypeDir, Mode: 0755, ModTime: archive.NormalizedDateTime}); err != nil {
+			return err
+		}
+	}
+
+	if err := writeBuilderFiles(tw, builderImage, "cnb", "platform"); err != nil {
+		return errors.Wrapf(err, "reading builder %s", style.Symbol(l.opts.Builder.Name()))
+	}
+
+	fi, err := os.Stat(l.opts.AppPath)
+	if err != nil {
+		return errors.Wrapf(err, "reading app %s", style.Symbol(l.opts.AppPath))
+	}
+	appDir := l.mountPaths.appDirName()
+	if fi.IsDir() {
+		err = archive.WriteDirToTar(tw, l.opts.AppPath, appDir, l.opts.Builder.UID(), l.opts.Builder.GID(), -1, false, true, l.opts.FileFilter)
+	} else {
+		err = archive.WriteZipToTar(tw, l.opts.AppPath, appDir, l.opts.Builder.UID(), l.opts.Builder.GID(), -1, false, l.opts.FileFilter)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "reading app %s", style.Symbol(l.opts.AppPath))
+	}
+
+	projectMetadata := &bytes.Buffer{}
+	if err := toml.NewEncoder(projectMetadata).Encode(l.opts.ProjectMetadata); err != nil {
+		return errors.Wrap(err, "encoding project metadata")
+	}
+	if err := writeTarFile(tw, "layers/project-metadata.toml", 0644, projectMetadata.Bytes()); err != nil {
+		return err
+	}
+
+	return writeTarFile(tw, "env", 0600, []byte(env))
+}
+
+// writeBuilderFiles copies the files under dirs from the builder image filesystem to tw
+func writeBuilderFiles(tw archive.TarWriter, builderImage v1.Image, dirs ...string) error {
+	rc := mutate.Extract(builderImage)
+	defer rc.Close()
+
+	tr := tar.NewReader(rc)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		header.Name = relativeTarPath(header.Name)
+		if !withinDirs(header.Name, dirs) {
+			continue
+		}
+		if header.Typeflag == tar.TypeLink {
+			header.Linkname = relativeTarPath(header.Linkname)
+		}
+
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := io.Copy(tw, tr); err != nil {
+			return err
+		}
+	}
+}
+
+func relativeTarPath(name string) string {
+	return strings.TrimPrefix(path.Clean("/"+name), "/")
+}
+
+func withinDirs(name string, dirs []string) bool {
+	for _, dir := range dirs {
+		if name == dir || strings.HasPrefix(name, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func writeTarFile(tw archive.TarWriter, name string, mode int64, contents []byte) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     name,
+		Typeflag: tar.TypeReg,
+		Mode:     mode,
+		Size:     int64(len(contents)),
+		ModTime:  archive.NormalizedDateTime,
+	}); err != nil {
+		return err
+	}
+	_, err := tw.Write(contents)
+	return err
+}
+
+// downloadRemote copies name from dir on the remote host to dest, in the same way CopyOutTo copies it out of a container
+func (l *LifecycleExecution) downloadRemote(ctx context.Context, shell RemoteShell, dir, name, dest string) error {
+	reader, writer := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		defer reader.Close()
+		done <- darchive.CopyTo(reader, darchive.CopyInfo{Path: path.Join(dir, name), IsDir: true}, dest)
+	}()
+
+	err := shell.Run(ctx, fmt.Sprintf("tar -c -f - -C %s %s", shellQuote(dir), shellQuote(name)), nil, writer, logging.GetWriterForLevel(l.logger, logging.ErrorLevel))
+	writer.CloseWithError(err)
+	if copyErr := <-done; err == nil {
+		err = copyErr
+	}
+	return err
+}
+
+// shellQuote quotes s for a POSIX shell
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func shellJoin(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = shellQuote(arg)
+	}
+	return strings.Join(quoted, " ")
+}
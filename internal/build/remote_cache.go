@@ -0,0 +1,91 @@
+package build
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/docker/docker/api/types"
+	dcontainer "github.com/docker/docker/api/types/container"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/cache"
+)
+
+// loadRemoteCache restores the latest snapshot of a remote cache into the build cache volume. Failures are reported
+// as warnings, leaving the volume empty, since the build can always proceed without a cache.
+func (l *LifecycleExecution) loadRemoteCache(ctx context.Context, remoteCache *cache.RemoteCache, buildCache Cache) error {
+	snapshot, err := remoteCache.Load(ctx)
+	if err == cache.ErrSnapshotNotFound {
+		l.logger.Debugf("No snapshot of build cache %s found", style.Symbol(remoteCache.Name()))
+		return nil
+	}
+	if err != nil {
+		l.logger.Warnf("Unable to restore build cache: %s", err)
+		return nil
+	}
+	defer snapshot.Close()
+
+	ctrID, err := l.createCacheContainer(ctx, buildCache.Name())
+	if err != nil {
+		return err
+	}
+	defer l.docker.ContainerRemove(context.Background(), ctrID, dcontainer.RemoveOptions{Force: true})
+
+	// snapshot entries are prefixed by the name of the cache directory, see saveRemoteCache
+	if err := l.docker.CopyToContainer(ctx, ctrID, path.Dir(l.mountPaths.cacheDir()), snapshot, types.CopyToContainerOptions{}); err != nil {
+		l.logger.Warnf("Unable to restore build cache: %s", err)
+		if err := buildCache.Clear(ctx); err != nil {
+			return errors.Wrap(err, "clearing build cache")
+		}
+		return nil
+	}
+
+	l.logger.Debugf("Restored build cache %s", style.Symbol(remoteCache.Name()))
+	return nil
+}
+
+// saveRemoteCache stores a snapshot of the build cache volume in a remote cache. Failures are reported as warnings,
+// since the image has already been exported.
+func (l *LifecycleExecution) saveRemoteCache(ctx context.Context, remoteCache *cache.RemoteCache, buildCache Cache) error {
+	ctrID, err := l.createCacheContainer(ctx, buildCache.Name())
+	if err != nil {
+		return err
+	}
+	defer l.docker.ContainerRemove(context.Background(), ctrID, dcontainer.RemoveOptions{Force: true})
+
+	snapshot, _, err := l.docker.CopyFromContainer(ctx, ctrID, l.mountPaths.cacheDir())
+	if err != nil {
+		l.logger.Warnf("Unable to save build cache: %s", err)
+		return nil
+	}
+	defer snapshot.Close()
+
+	if err := remoteCache.Save(ctx, snapshot); err != nil {
+		l.logger.Warnf("Unable to save build cache: %s", err)
+		return nil
+	}
+
+	l.logger.Debugf("Saved build cache %s", style.Symbol(remoteCache.Name()))
+	return nil
+}
+
+// createCacheContainer creates (but does not start) a container with the build cache volume mounted,
+// so that its contents can be read and written through the daemon's archive API
+func (l *LifecycleExecution) createCacheContainer(ctx context.Context, volume string) (string, error) {
+	ctr, err := l.docker.ContainerCreate(ctx,
+		&dcontainer.Config{
+			Image: l.opts.Builder.Name(),
+			Cmd:   []string{"true"}, // never run
+		},
+		&dcontainer.HostConfig{
+			Binds: []string{fmt.Sprintf("%s:%s", volume, l.mountPaths.cacheDir())},
+		},
+		nil, l.opts.Platform, "",
+	)
+	if err != nil {
+		return "", errors.Wrap(err, "creating build cache container")
+	}
+	return ctr.ID, nil
+}
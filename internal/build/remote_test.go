@@ -0,0 +1,318 @@
+package build_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	ifakes "github.com/buildpacks/imgutil/fakes"
+	"github.com/buildpacks/lifecycle/api"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/buildpacks/pack/internal/build"
+	"github.com/buildpacks/pack/internal/build/fakes"
+	"github.com/buildpacks/pack/internal/container"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+const fakeCreator = `#!/bin/sh
+echo "platform api $CNB_PLATFORM_API, user $CNB_USER_ID:$CNB_GROUP_ID"
+while [ $# -gt 1 ]; do
+  case "$1" in
+    -app) app="$2" ;;
+    -report) report="$2" ;;
+    -cache-dir) cache="$2" ;;
+  esac
+  shift
+done
+echo "image $1"
+if [ -f "$app/fail" ]; then
+  echo "some-error" >&2
+  exit 3
+fi
+cp "$app/some-file" "$report"
+echo "some-build" >> "$cache/builds"
+`
+
+func TestRemote(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Remote", testRemote, spec.Report(report.Terminal{}), spec.Sequential())
+}
+
+func testRemote(t *testing.T, when spec.G, it spec.S) {
+	var (
+		executor  *build.LifecycleExecutor
+		shell     build.RemoteShell
+		opts      build.LifecycleOptions
+		outBuf    bytes.Buffer
+		remoteDir string
+		appDir    string
+		reportDir string
+		stopSSH   func()
+		image     *ifakes.Image
+	)
+
+	it.Before(func() {
+		h.SkipIf(t, runtime.GOOS == "windows", "remote builds run the creator with a POSIX shell")
+
+		remoteDir = t.TempDir()
+		h.AssertNil(t, os.MkdirAll(filepath.Join(remoteDir, "home"), 0755))
+		h.AssertNil(t, os.MkdirAll(filepath.Join(remoteDir, "tmp"), 0755))
+
+		var addr string
+		addr, stopSSH = startSSHServer(t, []string{
+			"HOME=" + filepath.Join(remoteDir, "home"),
+			"TMPDIR=" + filepath.Join(remoteDir, "tmp"),
+			"PATH=" + os.Getenv("PATH"),
+		})
+		sshClient, err := ssh.Dial("tcp", addr, &ssh.ClientConfig{
+			User:            "some-user",
+			HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		})
+		h.AssertNil(t, err)
+		shell = build.NewSSHShell(sshClient)
+
+		appDir = t.TempDir()
+		h.AssertNil(t, os.WriteFile(filepath.Join(appDir, "some-file"), []byte("some-report"), 0644))
+		reportDir = t.TempDir()
+
+		image = ifakes.NewImage("some/builder", "", nil)
+		h.AssertNil(t, image.SetOS(runtime.GOOS))
+		h.AssertNil(t, image.SetArchitecture(runtime.GOARCH))
+		fakeBuilder, err := fakes.NewFakeBuilder(
+			fakes.WithSupportedPlatformAPIs([]*api.Version{api.MustParse("0.12")}),
+			fakes.WithImage(&unpackableImage{Image: image, image: remoteBuilderImage(t)}),
+			fakes.WithUID(1234),
+			fakes.WithGID(4321),
+		)
+		h.AssertNil(t, err)
+
+		imageRef, err := name.ParseReference("some/image")
+		h.AssertNil(t, err)
+
+		dockerConfigDir := t.TempDir()
+		t.Setenv("DOCKER_CONFIG", dockerConfigDir)
+
+		outBuf.Reset()
+		executor = build.NewLifecycleExecutor(logging.NewLogWithWriters(&outBuf, &outBuf), nil)
+		opts = build.LifecycleOptions{
+			AppPath:              appDir,
+			Image:                imageRef,
+			Builder:              fakeBuilder,
+			RunImage:             "some/run",
+			Publish:              true,
+			UseCreator:           true,
+			GID:                  -1,
+			UID:                  -1,
+			ReportDestinationDir: reportDir,
+			Keychain:             authn.DefaultKeychain,
+			Remote:               shell,
+		}
+	})
+
+	it.After(func() {
+		if shell != nil {
+			h.AssertNil(t, shell.Close())
+		}
+		if stopSSH != nil {
+			stopSSH()
+		}
+	})
+
+	it("runs the creator on the remote host and streams its output", func() {
+		h.AssertNil(t, executor.Execute(context.Background(), opts))
+
+		h.AssertContains(t, outBuf.String(), "platform api 0.12, user 1234:4321")
+		h.AssertContains(t, outBuf.String(), "image some/image")
+	})
+
+	it("fails before uploading anything when the remote host doesn't have the platform of the builder", func() {
+		h.AssertNil(t, image.SetArchitecture("some-arch"))
+
+		err := executor.Execute(context.Background(), opts)
+
+		h.AssertError(t, err, fmt.Sprintf("remote host platform '%s/", runtime.GOOS))
+		h.AssertError(t, err, fmt.Sprintf("does not match platform '%s/some-arch' of builder 'some/builder'", runtime.GOOS))
+		entries, err := os.ReadDir(filepath.Join(remoteDir, "tmp"))
+		h.AssertNil(t, err)
+		h.AssertEq(t, len(entries), 0)
+	})
+
+	it("downloads the report", func() {
+		h.AssertNil(t, executor.Execute(context.Background(), opts))
+
+		contents, err := os.ReadFile(filepath.Join(reportDir, "report.toml"))
+		h.AssertNil(t, err)
+		h.AssertEq(t, string(contents), "some-report")
+	})
+
+	it("keeps the build cache in the home directory of the remote user", func() {
+		h.AssertNil(t, executor.Execute(context.Background(), opts))
+		h.AssertNil(t, executor.Execute(context.Background(), opts))
+
+		matches, err := filepath.Glob(filepath.Join(remoteDir, "home", ".pack", "remote", "cache", "*.build", "builds"))
+		h.AssertNil(t, err)
+		h.AssertEq(t, len(matches), 1)
+		contents, err := os.ReadFile(matches[0])
+		h.AssertNil(t, err)
+		h.AssertEq(t, string(contents), "some-build\nsome-build\n")
+	})
+
+	it("removes the remote work directory", func() {
+		h.AssertNil(t, executor.Execute(context.Background(), opts))
+
+		entries, err := os.ReadDir(filepath.Join(remoteDir, "tmp"))
+		h.AssertNil(t, err)
+		h.AssertEq(t, len(entries), 0)
+	})
+
+	it("returns the exit code of a failed creator", func() {
+		h.AssertNil(t, os.WriteFile(filepath.Join(appDir, "fail"), nil, 0644))
+
+		err := executor.Execute(context.Background(), opts)
+
+		var exitErr *container.ExitError
+		h.AssertTrue(t, errors.As(err, &exitErr))
+		h.AssertEq(t, exitErr.StatusCode, int64(3))
+		h.AssertContains(t, outBuf.String(), "some-error")
+	})
+}
+
+// unpackableImage is a builder image whose filesystem can be read, like the layout images remote builds fetch
+type unpackableImage struct {
+	*ifakes.Image
+	image v1.Image
+}
+
+func (i *unpackableImage) UnderlyingImage() v1.Image {
+	return i.image
+}
+
+func remoteBuilderImage(t *testing.T) v1.Image {
+	layer, err := tarball.LayerFromOpener(func() (io.ReadCloser, error) {
+		buf := &bytes.Buffer{}
+		tw := tar.NewWriter(buf)
+		for _, dir := range []string{"/cnb", "/cnb/lifecycle", "/cnb/buildpacks"} {
+			if err := tw.WriteHeader(&tar.Header{Name: dir, Typeflag: tar.TypeDir, Mode: 0755}); err != nil {
+				return nil, err
+			}
+		}
+		if err := tw.WriteHeader(&tar.Header{Name: "/cnb/lifecycle/creator", Typeflag: tar.TypeReg, Mode: 0755, Size: int64(len(fakeCreator))}); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write([]byte(fakeCreator)); err != nil {
+			return nil, err
+		}
+		if err := tw.Close(); err != nil {
+			return nil, err
+		}
+		return io.NopCloser(buf), nil
+	})
+	h.AssertNil(t, err)
+
+	img, err := mutate.AppendLayers(empty.Image, layer)
+	h.AssertNil(t, err)
+	return img
+}
+
+// startSSHServer starts an SSH server that runs exec requests with `sh -c` in env
+func startSSHServer(t *testing.T, env []string) (addr string, stop func()) {
+	_, hostKey, err := ed25519.GenerateKey(rand.Reader)
+	h.AssertNil(t, err)
+	signer, err := ssh.NewSignerFromKey(hostKey)
+	h.AssertNil(t, err)
+
+	config := &ssh.ServerConfig{NoClientAuth: true}
+	config.AddHostKey(signer)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	h.AssertNil(t, err)
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveSSHConn(conn, config, env)
+		}
+	}()
+
+	return listener.Addr().String(), func() { listener.Close() }
+}
+
+func serveSSHConn(conn net.Conn, config *ssh.ServerConfig, env []string) {
+	_, channels, requests, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(requests)
+
+	for newChannel := range channels {
+		if newChannel.ChannelType() != "session" {
+			newChannel.Reject(ssh.UnknownChannelType, "unsupported channel type")
+			continue
+		}
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			continue
+		}
+		go serveSSHSession(channel, requests, env)
+	}
+}
+
+func serveSSHSession(channel ssh.Channel, requests <-chan *ssh.Request, env []string) {
+	defer channel.Close()
+
+	for req := range requests {
+		if req.Type != "exec" {
+			req.Reply(false, nil)
+			continue
+		}
+		var payload struct{ Command string }
+		if err := ssh.Unmarshal(req.Payload, &payload); err != nil {
+			req.Reply(false, nil)
+			return
+		}
+		req.Reply(true, nil)
+
+		cmd := exec.Command("sh", "-c", payload.Command)
+		cmd.Env = env
+		cmd.Stdin = channel
+		cmd.Stdout = channel
+		cmd.Stderr = channel.Stderr()
+
+		var status uint32
+		if err := cmd.Run(); err != nil {
+			status = 255
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				status = uint32(exitErr.ExitCode())
+			}
+		}
+		channel.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{status}))
+		return
+	}
+}
@@ -0,0 +1,192 @@
+package build
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/docker/docker/api/types"
+	"github.com/dustin/go-humanize"
+	"github.com/pkg/errors"
+)
+
+// statsFileName is the name of the file written next to report.toml when a report destination directory is provided
+const statsFileName = "build-stats.toml"
+
+// BuildStats summarizes where the time of a build was spent, so that performance regressions can be tracked.
+type BuildStats struct {
+	Duration      time.Duration
+	Phases        []PhaseStats
+	UploadedBytes int64
+	LayersAdded   int
+	LayersReused  int
+}
+
+// PhaseStats records the wall time of a single lifecycle phase, and the number of bytes copied into its container.
+// For the detector and creator, this is mostly the app source.
+type PhaseStats struct {
+	Name          string
+	Duration      time.Duration
+	UploadedBytes int64
+}
+
+// statsRecorder collects BuildStats from phases that may run concurrently
+type statsRecorder struct {
+	sync.Mutex
+	stats BuildStats
+}
+
+func (r *statsRecorder) recordPhase(name string, duration time.Duration, uploadedBytes int64) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.stats.Phases = append(r.stats.Phases, PhaseStats{Name: name, Duration: duration, UploadedBytes: uploadedBytes})
+	r.stats.UploadedBytes += uploadedBytes
+}
+
+func (r *statsRecorder) recordLayer(reused bool) {
+	r.Lock()
+	defer r.Unlock()
+
+	if reused {
+		r.stats.LayersReused++
+	} else {
+		r.stats.LayersAdded++
+	}
+}
+
+func (r *statsRecorder) snapshot() BuildStats {
+	r.Lock()
+	defer r.Unlock()
+
+	stats := r.stats
+	stats.Phases = append([]PhaseStats(nil), r.stats.Phases...)
+	return stats
+}
+
+// Stats returns the statistics recorded so far for this build.
+func (l *LifecycleExecution) Stats() BuildStats {
+	return l.stats.snapshot()
+}
+
+// reportStats prints a summary of the build and, when a report destination directory is provided, writes it
+// alongside report.toml
+func (l *LifecycleExecution) reportStats(duration time.Duration) error {
+	l.stats.Lock()
+	l.stats.stats.Duration = duration
+	l.stats.Unlock()
+
+	stats := l.Stats()
+	l.logger.Info(formatStats(stats))
+
+	if l.opts.ReportDestinationDir == "" {
+		return nil
+	}
+
+	if err := writeStats(filepath.Join(l.opts.ReportDestinationDir, statsFileName), stats); err != nil {
+		return errors.Wrap(err, "writing build stats")
+	}
+	return nil
+}
+
+func formatStats(stats BuildStats) string {
+	buf := &bytes.Buffer{}
+	buf.WriteString("Build stats:\n")
+
+	tw := tabwriter.NewWriter(buf, 0, 0, 2, ' ', 0)
+	for _, phase := range stats.Phases {
+		fmt.Fprintf(tw, "  %s\t%s\t", phase.Name, roundDuration(phase.Duration))
+		if phase.UploadedBytes > 0 {
+			fmt.Fprintf(tw, "(uploaded %s)", humanize.Bytes(uint64(phase.UploadedBytes)))
+		}
+		fmt.Fprintln(tw)
+	}
+	fmt.Fprintf(tw, "  total\t%s\t\n", roundDuration(stats.Duration))
+	tw.Flush()
+
+	fmt.Fprintf(buf, "  %d layer(s) added, %d layer(s) reused", stats.LayersAdded, stats.LayersReused)
+	return buf.String()
+}
+
+func roundDuration(d time.Duration) time.Duration {
+	return d.Round(10 * time.Millisecond)
+}
+
+type statsFile struct {
+	DurationMS    int64            `toml:"duration-ms"`
+	UploadedBytes int64            `toml:"uploaded-bytes"`
+	LayersAdded   int              `toml:"layers-added"`
+	LayersReused  int              `toml:"layers-reused"`
+	Phases        []phaseStatsFile `toml:"phases"`
+}
+
+type phaseStatsFile struct {
+	Name          string `toml:"name"`
+	DurationMS    int64  `toml:"duration-ms"`
+	UploadedBytes int64  `toml:"uploaded-bytes"`
+}
+
+func writeStats(path string, stats BuildStats) error {
+	contents := statsFile{
+		DurationMS:    stats.Duration.Milliseconds(),
+		UploadedBytes: stats.UploadedBytes,
+		LayersAdded:   stats.LayersAdded,
+		LayersReused:  stats.LayersReused,
+	}
+	for _, phase := range stats.Phases {
+		contents.Phases = append(contents.Phases, phaseStatsFile{
+			Name:          phase.Name,
+			DurationMS:    phase.Duration.Milliseconds(),
+			UploadedBytes: phase.UploadedBytes,
+		})
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return toml.NewEncoder(f).Encode(contents)
+}
+
+// uploadCountingClient counts the bytes copied into containers through the archive API
+type uploadCountingClient struct {
+	DockerClient
+	sync.Mutex
+	count int64
+}
+
+func (c *uploadCountingClient) CopyToContainer(ctx context.Context, container, path string, content io.Reader, options types.CopyToContainerOptions) error {
+	return c.DockerClient.CopyToContainer(ctx, container, path, &countingReader{reader: content, client: c}, options)
+}
+
+func (c *uploadCountingClient) uploaded() int64 {
+	c.Lock()
+	defer c.Unlock()
+	return c.count
+}
+
+type countingReader struct {
+	reader io.Reader
+	client *uploadCountingClient
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.client.Lock()
+	r.client.count += int64(n)
+	r.client.Unlock()
+	return n, err
+}
@@ -775,6 +775,19 @@ func resolveRef(moduleList []dist.ModuleInfo, ref dist.ModuleRef, kind string) (
 		ref.Version = matching[0].Version
 	}
 
+	if buildpack.IsVersionRange(ref.Version) {
+		var versions []string
+		for _, bp := range matching {
+			versions = append(versions, bp.Version)
+		}
+		resolved, found := buildpack.ResolveVersionRange(ref.Version, versions)
+		if !found {
+			return dist.ModuleRef{},
+				fmt.Errorf("no version of %s %s matching %s was found on the builder", kind, style.Symbol(ref.ID), style.Symbol(ref.Version))
+		}
+		ref.Version = resolved
+	}
+
 	if !hasElementWithVersion(matching, ref.Version) {
 		return dist.ModuleRef{},
 			fmt.Errorf("%s %s with version %s was not found on the builder", kind, style.Symbol(ref.ID), style.Symbol(ref.Version))
@@ -584,6 +584,51 @@ func testBuilder(t *testing.T, when spec.G, it spec.S) {
 						})
 					})
 				})
+
+				when("has multiple buildpacks with same ID and semantic versions", func() {
+					it.Before(func() {
+						for _, version := range []string{"1.2.0", "1.4.1", "2.0.0"} {
+							bp, err := ifakes.NewFakeBuildpack(dist.BuildpackDescriptor{
+								WithAPI:    api.MustParse("0.2"),
+								WithInfo:   dist.ModuleInfo{ID: "buildpack-1-id", Version: version},
+								WithStacks: []dist.Stack{{ID: "some.stack.id"}},
+							}, 0644)
+							h.AssertNil(t, err)
+							subject.AddBuildpack(bp)
+						}
+					})
+
+					when("order sets a version range", func() {
+						it("should resolve the highest matching version", func() {
+							subject.SetOrder(dist.Order{{
+								Group: []dist.ModuleRef{
+									{ModuleInfo: dist.ModuleInfo{ID: "buildpack-1-id", Version: "^1.2"}}},
+							}})
+
+							err := subject.Save(logger, builder.CreatorMetadata{})
+							h.AssertNil(t, err)
+
+							layerTar, err := baseImage.FindLayerWithPath("/cnb/order.toml")
+							h.AssertNil(t, err)
+							h.AssertOnTarEntry(t, layerTar, "/cnb/order.toml", h.ContentEquals(`[[order]]
+
+  [[order.group]]
+    id = "buildpack-1-id"
+    version = "1.4.1"
+`))
+						})
+
+						it("should error when no version matches", func() {
+							subject.SetOrder(dist.Order{{
+								Group: []dist.ModuleRef{
+									{ModuleInfo: dist.ModuleInfo{ID: "buildpack-1-id", Version: "~3.0"}}},
+							}})
+
+							err := subject.Save(logger, builder.CreatorMetadata{})
+							h.AssertError(t, err, "no version of buildpack 'buildpack-1-id' matching '~3.0' was found on the builder")
+						})
+					})
+				})
 			})
 
 			when("validating buildpacks", func() {
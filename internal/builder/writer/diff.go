@@ -0,0 +1,147 @@
+package writer
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// BuilderDiffWriter prints the differences between two builders, as calculated by client.DiffBuilders.
+type BuilderDiffWriter interface {
+	PrintDiff(logger logging.Logger, oldName, newName string, diff *client.BuilderDiff) error
+}
+
+type BuilderDiffWriterFactory interface {
+	DiffWriter(kind string) (BuilderDiffWriter, error)
+}
+
+type DiffOutput struct {
+	OldBuilder string              `json:"old_builder" yaml:"old_builder" toml:"old_builder"`
+	NewBuilder string              `json:"new_builder" yaml:"new_builder" toml:"new_builder"`
+	Diff       *client.BuilderDiff `json:"diff" yaml:"diff" toml:"diff"`
+}
+
+// DiffWriter returns a writer for the given output format. The formats are the same as those supported by Writer.
+func (f *Factory) DiffWriter(kind string) (BuilderDiffWriter, error) {
+	writer, err := f.Writer(kind)
+	if err != nil {
+		return nil, err
+	}
+	return writer.(BuilderDiffWriter), nil
+}
+
+func (w *StructuredFormat) PrintDiff(logger logging.Logger, oldName, newName string, diff *client.BuilderDiff) error {
+	output, err := w.MarshalFunc(DiffOutput{OldBuilder: oldName, NewBuilder: newName, Diff: diff})
+	if err != nil {
+		return fmt.Errorf("untested, unexpected failure while marshaling: %w", err)
+	}
+
+	logger.Info(string(output))
+	return nil
+}
+
+func (h *HumanReadable) PrintDiff(logger logging.Logger, oldName, newName string, diff *client.BuilderDiff) error {
+	logger.Infof("Comparing builder %s with %s\n", style.Symbol(oldName), style.Symbol(newName))
+
+	if diff.Empty() {
+		logger.Info("\nNo differences found\n")
+		return nil
+	}
+
+	buf := &bytes.Buffer{}
+	if !diff.Lifecycle.Empty() {
+		buf.WriteString("\nLifecycle:\n")
+		writeValueChange(buf, "  ", "Version", diff.Lifecycle.Version)
+		writeListDiff(buf, "  ", "Buildpack APIs", diff.Lifecycle.BuildpackAPIs)
+		writeListDiff(buf, "  ", "Deprecated Buildpack APIs", diff.Lifecycle.DeprecatedBuildpackAPIs)
+		writeListDiff(buf, "  ", "Platform APIs", diff.Lifecycle.PlatformAPIs)
+		writeListDiff(buf, "  ", "Deprecated Platform APIs", diff.Lifecycle.DeprecatedPlatformAPIs)
+	}
+	if diff.Stack != nil {
+		buf.WriteString("\n")
+		writeValueChange(buf, "", "Stack", diff.Stack)
+	}
+	for _, section := range []struct {
+		title string
+		diff  client.ListDiff
+	}{
+		{"Mixins", diff.Mixins},
+		{"Run Images", diff.RunImages},
+		{"Run Image Mirrors", diff.RunImageMirrors},
+	} {
+		if !section.diff.Empty() {
+			buf.WriteString("\n")
+			writeListDiff(buf, "", section.title, section.diff)
+		}
+	}
+	writeModuleDiff(buf, "Buildpacks", diff.Buildpacks)
+	writeOrderDiff(buf, "Detection Order", diff.Order)
+	writeModuleDiff(buf, "Extensions", diff.Extensions)
+	writeOrderDiff(buf, "Detection Order (Extensions)", diff.OrderExtensions)
+
+	logger.Info(buf.String())
+	return nil
+}
+
+func writeValueChange(buf *bytes.Buffer, indent, title string, change *client.ValueChange) {
+	if change == nil {
+		return
+	}
+	fmt.Fprintf(buf, "%s%s: %s -> %s\n", indent, title, valueOrNone(change.Old), valueOrNone(change.New))
+}
+
+func writeListDiff(buf *bytes.Buffer, indent, title string, diff client.ListDiff) {
+	if diff.Empty() {
+		return
+	}
+	fmt.Fprintf(buf, "%s%s:\n", indent, title)
+	for _, entry := range diff.Added {
+		fmt.Fprintf(buf, "%s  + %s\n", indent, entry)
+	}
+	for _, entry := range diff.Removed {
+		fmt.Fprintf(buf, "%s  - %s\n", indent, entry)
+	}
+}
+
+func writeModuleDiff(buf *bytes.Buffer, title string, diff client.ModuleDiff) {
+	if diff.Empty() {
+		return
+	}
+	fmt.Fprintf(buf, "\n%s:\n", title)
+	for _, module := range diff.Added {
+		fmt.Fprintf(buf, "  + %s\n", module.FullName())
+	}
+	for _, module := range diff.Removed {
+		fmt.Fprintf(buf, "  - %s\n", module.FullName())
+	}
+	for _, change := range diff.Changed {
+		fmt.Fprintf(buf, "  ~ %s: %s -> %s\n", change.ID, valueOrNone(change.OldVersion), valueOrNone(change.NewVersion))
+	}
+}
+
+func writeOrderDiff(buf *bytes.Buffer, title string, diffs []client.OrderGroupDiff) {
+	if len(diffs) == 0 {
+		return
+	}
+	fmt.Fprintf(buf, "\n%s:\n", title)
+	for _, group := range diffs {
+		fmt.Fprintf(buf, "  Group #%d:\n", group.Group)
+		for _, entry := range group.Old {
+			fmt.Fprintf(buf, "    - %s\n", entry)
+		}
+		for _, entry := range group.New {
+			fmt.Fprintf(buf, "    + %s\n", entry)
+		}
+	}
+}
+
+func valueOrNone(value string) string {
+	if strings.TrimSpace(value) == "" {
+		return none
+	}
+	return value
+}
@@ -0,0 +1,144 @@
+package writer_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/builder/writer"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestDiffWriter(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Builder Diff Writer", testDiffWriter, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testDiffWriter(t *testing.T, when spec.G, it spec.S) {
+	var (
+		assert = h.NewAssertionManager(t)
+		outBuf bytes.Buffer
+		logger logging.Logger
+
+		diff = &client.BuilderDiff{
+			Lifecycle: client.LifecycleDiff{
+				Version:      &client.ValueChange{Old: "0.17.0", New: "0.18.0"},
+				PlatformAPIs: client.ListDiff{Added: []string{"0.13"}, Removed: []string{"0.11"}},
+			},
+			RunImages: client.ListDiff{Added: []string{"other/run-image"}},
+			Buildpacks: client.ModuleDiff{
+				Added:   []dist.ModuleInfo{{ID: "new.bp", Version: "0.1.0"}},
+				Removed: []dist.ModuleInfo{{ID: "old.bp", Version: "2.0.0"}},
+				Changed: []client.ModuleChange{{ID: "some.bp", OldVersion: "1.0.0", NewVersion: "1.1.0"}},
+			},
+			Order: []client.OrderGroupDiff{
+				{Group: 1, Old: []string{"some.bp@1.0.0"}, New: []string{"some.bp@1.1.0"}},
+			},
+		}
+	)
+
+	it.Before(func() {
+		outBuf.Reset()
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+	})
+
+	when("human-readable", func() {
+		it("prints the sections that changed", func() {
+			subject := writer.NewHumanReadable()
+
+			err := subject.PrintDiff(logger, "some/builder", "other/builder", diff)
+			assert.Nil(err)
+
+			assert.Contains(outBuf.String(), "Comparing builder 'some/builder' with 'other/builder'")
+			assert.Contains(outBuf.String(), `
+Lifecycle:
+  Version: 0.17.0 -> 0.18.0
+  Platform APIs:
+    + 0.13
+    - 0.11
+`)
+			assert.Contains(outBuf.String(), `
+Run Images:
+  + other/run-image
+`)
+			assert.Contains(outBuf.String(), `
+Buildpacks:
+  + new.bp@0.1.0
+  - old.bp@2.0.0
+  ~ some.bp: 1.0.0 -> 1.1.0
+`)
+			assert.Contains(outBuf.String(), `
+Detection Order:
+  Group #1:
+    - some.bp@1.0.0
+    + some.bp@1.1.0
+`)
+			assert.NotContains(outBuf.String(), "Stack")
+			assert.NotContains(outBuf.String(), "Extensions")
+		})
+
+		when("there are no differences", func() {
+			it("says so", func() {
+				subject := writer.NewHumanReadable()
+
+				err := subject.PrintDiff(logger, "some/builder", "other/builder", &client.BuilderDiff{})
+				assert.Nil(err)
+
+				assert.Contains(outBuf.String(), "No differences found")
+				assert.NotContains(outBuf.String(), "Lifecycle")
+			})
+		})
+	})
+
+	when("json", func() {
+		it("prints the builder names and differences", func() {
+			subject := writer.NewJSON()
+
+			err := subject.(writer.BuilderDiffWriter).PrintDiff(logger, "some/builder", "other/builder", diff)
+			assert.Nil(err)
+
+			assert.Contains(outBuf.String(), `"old_builder": "some/builder"`)
+			assert.Contains(outBuf.String(), `"new_builder": "other/builder"`)
+			assert.Contains(outBuf.String(), `"changed": [
+        {
+          "id": "some.bp",
+          "old_version": "1.0.0",
+          "new_version": "1.1.0"
+        }
+      ]`)
+		})
+	})
+
+	when("yaml", func() {
+		it("prints the builder names and differences", func() {
+			subject := writer.NewYAML()
+
+			err := subject.(writer.BuilderDiffWriter).PrintDiff(logger, "some/builder", "other/builder", diff)
+			assert.Nil(err)
+
+			assert.Contains(outBuf.String(), "old_builder: some/builder")
+			assert.Contains(outBuf.String(), "new_builder: other/builder")
+			assert.Contains(outBuf.String(), "old_version: 1.0.0")
+		})
+	})
+
+	when("toml", func() {
+		it("prints the builder names and differences", func() {
+			subject := writer.NewTOML()
+
+			err := subject.(writer.BuilderDiffWriter).PrintDiff(logger, "some/builder", "other/builder", diff)
+			assert.Nil(err)
+
+			assert.Contains(outBuf.String(), `old_builder = "some/builder"`)
+			assert.Contains(outBuf.String(), `new_builder = "other/builder"`)
+			assert.Contains(outBuf.String(), `old_version = "1.0.0"`)
+		})
+	})
+}
@@ -90,4 +90,28 @@ func testFactory(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 	})
+
+	when("DiffWriter", func() {
+		it("returns a diff writer for the output format", func() {
+			factory := writer.NewFactory()
+
+			returnedWriter, err := factory.DiffWriter("json")
+			assert.Nil(err)
+
+			_, ok := returnedWriter.(*writer.JSON)
+			assert.TrueWithMessage(
+				ok,
+				fmt.Sprintf("expected %T to be assignable to type `*writer.JSON`", returnedWriter),
+			)
+		})
+
+		when("output format is not supported", func() {
+			it("returns an error", func() {
+				factory := writer.NewFactory()
+
+				_, err := factory.DiffWriter("mind-beam")
+				assert.ErrorWithMessage(err, "output format 'mind-beam' is not supported")
+			})
+		})
+	})
 }
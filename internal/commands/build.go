@@ -13,6 +13,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
+	"github.com/buildpacks/pack/internal/build"
 	"github.com/buildpacks/pack/internal/config"
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/client"
@@ -51,10 +52,23 @@ type BuildFlags struct {
 	UID                  int
 	PreviousImage        string
 	SBOMDestinationDir   string
+	SBOMPolicy           string
+	SignKey              string
+	ProvenanceOutput     string
 	ReportDestinationDir string
 	DateTime             string
 	PreBuildpacks        []string
 	PostBuildpacks       []string
+	Platform             string
+	Runtime              string
+	RemoteHost           string
+	DryRunFormat         string
+	DryRun               bool
+	Watch                bool
+	VerifyReproducible   bool
+	Profile              string
+	Lock                 bool
+	UpdateLock           bool
 }
 
 // Build an image from source code
@@ -88,6 +102,16 @@ func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cob
 				logger.Debugf("Using project descriptor located at %s", style.Symbol(actualDescriptorPath))
 			}
 
+			if flags.Profile != "" {
+				if actualDescriptorPath == "" {
+					return errors.Errorf("profile %s requires a project descriptor", style.Symbol(flags.Profile))
+				}
+				if descriptor, err = project.ApplyProfile(descriptor, flags.Profile); err != nil {
+					return err
+				}
+				logger.Debugf("Using profile %s of project descriptor", style.Symbol(flags.Profile))
+			}
+
 			builder := flags.Builder
 			// We only override the builder to the one in the project descriptor
 			// if it was not explicitly set by the user
@@ -100,6 +124,11 @@ func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cob
 				return client.NewSoftError()
 			}
 
+			runImage := flags.RunImage
+			if !cmd.Flags().Changed("run-image") && descriptor.Build.RunImage != "" {
+				runImage = descriptor.Build.RunImage
+			}
+
 			buildpacks := flags.Buildpacks
 			extensions := flags.Extensions
 
@@ -160,7 +189,7 @@ func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cob
 				Registry:          flags.Registry,
 				AdditionalMirrors: getMirrors(cfg),
 				AdditionalTags:    flags.AdditionalTags,
-				RunImage:          flags.RunImage,
+				RunImage:          runImage,
 				Env:               env,
 				Image:             inputImageName.Name(),
 				Publish:           flags.Publish,
@@ -188,10 +217,22 @@ func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cob
 				PreviousImage:            inputPreviousImage.Name(),
 				Interactive:              flags.Interactive,
 				SBOMDestinationDir:       flags.SBOMDestinationDir,
+				SBOMPolicy:               flags.SBOMPolicy,
+				Sign:                     signOptions(flags.SignKey),
+				ProvenanceOutput:         flags.ProvenanceOutput,
+				LockFile:                 lockFile(flags, actualDescriptorPath),
+				UpdateLock:               flags.UpdateLock,
 				ReportDestinationDir:     flags.ReportDestinationDir,
 				CreationTime:             dateTime,
 				PreBuildpacks:            flags.PreBuildpacks,
 				PostBuildpacks:           flags.PostBuildpacks,
+				Platform:                 flags.Platform,
+				Runtime:                  flags.Runtime,
+				RemoteHost:               flags.RemoteHost,
+				Watch:                    flags.Watch,
+				VerifyReproducible:       flags.VerifyReproducible,
+				DryRun:                   flags.DryRun,
+				DryRunFormat:             flags.DryRunFormat,
 				LayoutConfig: &client.LayoutConfig{
 					Sparse:             flags.Sparse,
 					InputImage:         inputImageName,
@@ -201,6 +242,9 @@ func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cob
 			}); err != nil {
 				return errors.Wrap(err, "failed to build")
 			}
+			if flags.DryRun || flags.Watch {
+				return nil
+			}
 			logger.Infof("Successfully built image %s", style.Symbol(inputImageName.Name()))
 			return nil
 		}),
@@ -210,6 +254,24 @@ func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cob
 	return cmd
 }
 
+// lockFile returns the path of the lockfile of the build, next to the project descriptor when there is one, or else
+// in the app directory. Builds with a profile are locked in a file of their own, so that building with different
+// profiles doesn't report drift. It is empty when the build is not locked.
+func lockFile(flags BuildFlags, descriptorPath string) string {
+	if !flags.Lock && !flags.UpdateLock {
+		return ""
+	}
+	fileName := client.BuildLockFileName
+	if flags.Profile != "" {
+		ext := filepath.Ext(fileName)
+		fileName = strings.TrimSuffix(fileName, ext) + "." + flags.Profile + ext
+	}
+	if descriptorPath != "" {
+		return filepath.Join(filepath.Dir(descriptorPath), fileName)
+	}
+	return filepath.Join(flags.AppPath, fileName)
+}
+
 func parseTime(providedTime string) (*time.Time, error) {
 	var parsedTime time.Time
 	switch providedTime {
@@ -229,7 +291,7 @@ func parseTime(providedTime string) (*time.Time, error) {
 
 func buildCommandFlags(cmd *cobra.Command, buildFlags *BuildFlags, cfg config.Config) {
 	cmd.Flags().StringVarP(&buildFlags.AppPath, "path", "p", "", "Path to app dir or zip-formatted file (defaults to current working directory)")
-	cmd.Flags().StringSliceVarP(&buildFlags.Buildpacks, "buildpack", "b", nil, "Buildpack to use. One of:\n  a buildpack by id and version in the form of '<buildpack>@<version>',\n  path to a buildpack directory (not supported on Windows),\n  path/URL to a buildpack .tar or .tgz file, or\n  a packaged buildpack image name in the form of '<hostname>/<repo>[:<tag>]'"+stringSliceHelp("buildpack"))
+	cmd.Flags().StringSliceVarP(&buildFlags.Buildpacks, "buildpack", "b", nil, "Buildpack to use. One of:\n  a buildpack by id and version in the form of '<buildpack>@<version>', where version may be a range such as '^1.2', '~2.0' or 'latest',\n  path to a buildpack directory (not supported on Windows),\n  path/URL to a buildpack .tar or .tgz file, or\n  a packaged buildpack image name in the form of '<hostname>/<repo>[:<tag>]'"+stringSliceHelp("buildpack"))
 	cmd.Flags().StringSliceVarP(&buildFlags.Extensions, "extension", "", nil, "Extension to use. One of:\n  an extension by id and version in the form of '<extension>@<version>',\n  path to an extension directory (not supported on Windows),\n  path/URL to an extension .tar or .tgz file, or\n  a packaged extension image name in the form of '<hostname>/<repo>[:<tag>]'"+stringSliceHelp("extension"))
 	cmd.Flags().StringVarP(&buildFlags.Builder, "builder", "B", cfg.DefaultBuilder, "Builder image")
 	cmd.Flags().Var(&buildFlags.Cache, "cache",
@@ -238,17 +300,24 @@ func buildCommandFlags(cmd *cobra.Command, buildFlags *BuildFlags, cfg config.Co
 - Cache as image (requires --publish): 'type=<build/launch>;format=image;name=<registry image name>'
 - Cache as volume: 'type=<build/launch>;format=volume;[name=<volume name>]'
     - If no name is provided, a random name will be generated.
+- Cache in a directory: 'type=build;format=dir;source=<path to directory>'
+- Cache on an HTTP server: 'type=build;format=http;url=<base url>'
+    - The server must support GET, HEAD and PUT requests.
+    - The cache is restored into a volume before the build, and saved to the directory or server after export.
 `)
 	cmd.Flags().StringVar(&buildFlags.CacheImage, "cache-image", "", `Cache build layers in remote registry. Requires --publish`)
 	cmd.Flags().BoolVar(&buildFlags.ClearCache, "clear-cache", false, "Clear image's associated cache before building")
 	cmd.Flags().StringVar(&buildFlags.DateTime, "creation-time", "", "Desired create time in the output image config. Accepted values are Unix timestamps (e.g., '1641013200'), or 'now'. Platform API version must be at least 0.9 to use this feature.")
 	cmd.Flags().StringVarP(&buildFlags.DescriptorPath, "descriptor", "d", "", "Path to the project descriptor file")
 	cmd.Flags().StringVarP(&buildFlags.DefaultProcessType, "default-process", "D", "", `Set the default process type. (default "web")`)
+	cmd.Flags().BoolVar(&buildFlags.DryRun, "dry-run", false, "Resolve the builder, run image, lifecycle and buildpacks, and print the build plan without running the build")
+	cmd.Flags().StringVar(&buildFlags.DryRunFormat, "dry-run-format", "", `Format of the build plan printed by --dry-run. Accepted values are text and json. (default "text")`)
 	cmd.Flags().StringArrayVarP(&buildFlags.Env, "env", "e", []string{}, "Build-time environment variable, in the form 'VAR=VALUE' or 'VAR'.\nWhen using latter value-less form, value will be taken from current\n  environment at the time this command is executed.\nThis flag may be specified multiple times and will override\n  individual values defined by --env-file."+stringArrayHelp("env")+"\nNOTE: These are NOT available at image runtime.")
 	cmd.Flags().StringArrayVar(&buildFlags.EnvFiles, "env-file", []string{}, "Build-time environment variables file\nOne variable per line, of the form 'VAR=VALUE' or 'VAR'\nWhen using latter value-less form, value will be taken from current\n  environment at the time this command is executed\nNOTE: These are NOT available at image runtime.\"")
 	cmd.Flags().StringVar(&buildFlags.Network, "network", "", "Connect detect and build containers to network")
 	cmd.Flags().StringArrayVar(&buildFlags.PreBuildpacks, "pre-buildpack", []string{}, "Buildpacks to prepend to the groups in the builder's order")
 	cmd.Flags().StringArrayVar(&buildFlags.PostBuildpacks, "post-buildpack", []string{}, "Buildpacks to append to the groups in the builder's order")
+	cmd.Flags().StringVar(&buildFlags.Platform, "platform", "", "Platform to build for, in the form 'os/arch[/variant]' (e.g. 'linux/arm64').\nThe builder and run image must be available for this platform.\nBuilding for a platform other than the daemon's requires emulation (e.g. QEMU) on the host.")
 	cmd.Flags().BoolVar(&buildFlags.Publish, "publish", false, "Publish the application image directly to the container registry specified in <image-name>, instead of the daemon. The run image must also reside in the registry.")
 	cmd.Flags().StringVar(&buildFlags.DockerHost, "docker-host", "",
 		`Address to docker daemon that will be exposed to the build container.
@@ -259,21 +328,40 @@ This option may set DOCKER_HOST environment variable for the build container if
 	cmd.Flags().StringVar(&buildFlags.LifecycleImage, "lifecycle-image", cfg.LifecycleImage, `Custom lifecycle image to use for analysis, restore, and export when builder is untrusted.`)
 	cmd.Flags().StringVar(&buildFlags.Policy, "pull-policy", "", `Pull policy to use. Accepted values are always, never, and if-not-present. (default "always")`)
 	cmd.Flags().StringVarP(&buildFlags.Registry, "buildpack-registry", "r", cfg.DefaultRegistryName, "Buildpack Registry by name")
+	cmd.Flags().StringVar(&buildFlags.Runtime, "runtime", "", `Runtime used to run the lifecycle phases. Accepted values are docker and local. (default "docker")
+- docker: run the phases in containers, using the Docker daemon.
+- local: run the phases as processes in the unpacked builder, using user namespaces instead of a daemon. When not run as root, it requires newuidmap, newgidmap and subordinate IDs of the user in /etc/subuid and /etc/subgid.
+    Linux only. Requires a trusted builder, and --publish or an OCI layout image name.`)
+	cmd.Flags().StringVar(&buildFlags.RemoteHost, "remote-host", "", `Run the lifecycle on a remote host over SSH, in the form ssh://[user@]host[:port].
+The builder and app are uploaded and the lifecycle runs as the SSH user, so the host needs only a shell and tar,
+and the OS and architecture of the builder.
+Requires a trusted builder and --publish. An SSH identity may be provided with PACK_REMOTE_HOST_SSH_IDENTITY.
+To build with a container runtime on a remote host instead, set DOCKER_HOST=ssh://[user@]host[/path/to/socket].`)
+	cmd.Flags().StringVar(&buildFlags.Profile, "profile", "", "Name of the profile of the project descriptor to build with.\nThe builder, run image, env, buildpacks and files declared by the profile override those of the descriptor.")
 	cmd.Flags().StringVar(&buildFlags.RunImage, "run-image", "", "Run image (defaults to default stack's run image)")
 	cmd.Flags().StringSliceVarP(&buildFlags.AdditionalTags, "tag", "t", nil, "Additional tags to push the output image to.\nTags should be in the format 'image:tag' or 'repository/image:tag'."+stringSliceHelp("tag"))
 	cmd.Flags().BoolVar(&buildFlags.TrustBuilder, "trust-builder", false, "Trust the provided builder.\nAll lifecycle phases will be run in a single container.\nFor more on trusted builders, and when to trust or untrust a builder, check out our docs here: https://buildpacks.io/docs/tools/pack/concepts/trusted_builders")
+	cmd.Flags().BoolVar(&buildFlags.Watch, "watch", false, "Rebuild the image whenever files in the app dir change, until interrupted.\nFiles excluded by the project descriptor are not watched.")
+	cmd.Flags().BoolVar(&buildFlags.VerifyReproducible, "verify-reproducible", false, "Verify that the build is reproducible, by comparing the built image with --previous-image, or else with a second build from a clean cache.\nWith --previous-image, the build doesn't reuse its layers and starts from a clean cache. The layers and files that differ are reported, and the build fails.")
 	cmd.Flags().StringArrayVar(&buildFlags.Volumes, "volume", nil, "Mount host volume into the build container, in the form '<host path>:<target path>[:<options>]'.\n- 'host path': Name of the volume or absolute directory path to mount.\n- 'target path': The path where the file or directory is available in the container.\n- 'options' (default \"ro\"): An optional comma separated list of mount options.\n    - \"ro\", volume contents are read-only.\n    - \"rw\", volume contents are readable and writeable.\n    - \"volume-opt=<key>=<value>\", can be specified more than once, takes a key-value pair consisting of the option name and its value."+stringArrayHelp("volume"))
 	cmd.Flags().StringVar(&buildFlags.Workspace, "workspace", "", "Location at which to mount the app dir in the build image")
 	cmd.Flags().IntVar(&buildFlags.GID, "gid", 0, `Override GID of user's group in the stack's build and run images. The provided value must be a positive number`)
 	cmd.Flags().IntVar(&buildFlags.UID, "uid", 0, `Override UID of user in the stack's build and run images. The provided value must be a positive number`)
 	cmd.Flags().StringVar(&buildFlags.PreviousImage, "previous-image", "", "Set previous image to a particular tag reference, digest reference, or (when performing a daemon build) image ID")
 	cmd.Flags().StringVar(&buildFlags.SBOMDestinationDir, "sbom-output-dir", "", "Path to export SBoM contents.\nOmitting the flag will yield no SBoM content.")
-	cmd.Flags().StringVar(&buildFlags.ReportDestinationDir, "report-output-dir", "", "Path to export build report.toml.\nOmitting the flag yield no report file.")
+	cmd.Flags().StringVar(&buildFlags.SignKey, "sign-key", "", "Path to a cosign private key to sign the image with. Requires --publish.\nThe provenance of the build and the SBoM of the image are attached as signed attestations.\nThe password of an encrypted key is read from COSIGN_PASSWORD.")
+	cmd.Flags().StringVar(&buildFlags.ProvenanceOutput, "provenance-output", "", "Path to write the SLSA provenance of the build to, as an in-toto statement.")
+	cmd.Flags().BoolVar(&buildFlags.Lock, "lock", false, "Pin the resolved digests of the builder, run image, lifecycle image, buildpacks and extensions in project.lock, next to the project descriptor, or in project.<profile>.lock when building with --profile.\nThe lockfile is written when it doesn't exist; otherwise the build fails if any of these inputs changed.")
+	cmd.Flags().BoolVar(&buildFlags.UpdateLock, "update-lock", false, "Rewrite the lockfile with the inputs resolved for this build. Implies --lock.")
+	cmd.Flags().StringVar(&buildFlags.SBOMPolicy, "sbom-policy", "", "Path to a policy file to check the SBoM of the built image against, as used by 'pack sbom check'.\nThe build fails if any package violates the policy. With --publish, the pushed image is checked in the registry.")
+	cmd.Flags().StringVar(&buildFlags.ReportDestinationDir, "report-output-dir", "", "Path to export build report.toml and build-stats.toml.\nOmitting the flag yield no report file.")
 	cmd.Flags().BoolVar(&buildFlags.Interactive, "interactive", false, "Launch a terminal UI to depict the build process")
 	cmd.Flags().BoolVar(&buildFlags.Sparse, "sparse", false, "Use this flag to avoid saving on disk the run-image layers when the application image is exported to OCI layout format")
 	if !cfg.Experimental {
 		cmd.Flags().MarkHidden("interactive")
 		cmd.Flags().MarkHidden("sparse")
+		cmd.Flags().MarkHidden("runtime")
+		cmd.Flags().MarkHidden("remote-host")
 	}
 }
 
@@ -290,6 +378,10 @@ func validateBuildFlags(flags *BuildFlags, cfg config.Config, inputImageRef clie
 		return errors.New("'cache' flag with 'image' format cannot be used with 'cache-image' flag.")
 	}
 
+	if (flags.Cache.Build.Format == cache.CacheHTTP || flags.Cache.Build.Format == cache.CacheDir) && flags.CacheImage != "" {
+		return errors.Errorf("'cache' flag with '%s' format cannot be used with 'cache-image' flag.", flags.Cache.Build.Format)
+	}
+
 	if flags.Cache.Build.Format == cache.CacheImage && !flags.Publish {
 		return errors.New("image cache format requires the 'publish' flag")
 	}
@@ -314,6 +406,58 @@ func validateBuildFlags(flags *BuildFlags, cfg config.Config, inputImageRef clie
 		return client.NewExperimentError("Exporting to OCI layout is currently experimental.")
 	}
 
+	if flags.Runtime != "" && !cfg.Experimental {
+		return client.NewExperimentError("Selecting a runtime is currently experimental.")
+	}
+
+	if flags.Runtime != "" && flags.Runtime != build.RuntimeDocker && flags.Runtime != build.RuntimeLocal {
+		return errors.Errorf("invalid runtime %s, must be one of %s or %s", style.Symbol(flags.Runtime), style.Symbol(build.RuntimeDocker), style.Symbol(build.RuntimeLocal))
+	}
+
+	if flags.Runtime == build.RuntimeLocal && !flags.Publish && !inputImageRef.Layout() {
+		return errors.New("local runtime requires the publish flag or an OCI layout image name")
+	}
+
+	if flags.RemoteHost != "" && !cfg.Experimental {
+		return client.NewExperimentError("Building on a remote host is currently experimental.")
+	}
+
+	if flags.RemoteHost != "" && !flags.Publish {
+		return errors.New("remote-host flag requires the publish flag")
+	}
+
+	if flags.SBOMPolicy != "" && inputImageRef.Layout() {
+		return errors.New("sbom-policy flag cannot be used when exporting to OCI layout format")
+	}
+
+	if flags.ProvenanceOutput != "" && inputImageRef.Layout() {
+		return errors.New("provenance-output flag cannot be used when exporting to OCI layout format")
+	}
+
+	if flags.SignKey != "" && !flags.Publish {
+		return errors.New("sign-key flag requires the publish flag")
+	}
+
+	if flags.VerifyReproducible && flags.Publish && flags.PreviousImage == "" {
+		return errors.New("verify-reproducible flag requires the previous-image flag when publishing")
+	}
+
+	if flags.Watch && flags.DryRun {
+		return errors.New("watch flag cannot be used with the dry-run flag")
+	}
+
+	if flags.Watch && flags.Interactive {
+		return errors.New("watch flag cannot be used with the interactive flag")
+	}
+
+	if flags.DryRunFormat != "" && !flags.DryRun {
+		return errors.New("dry-run-format flag requires the dry-run flag")
+	}
+
+	if flags.DryRunFormat != "" && flags.DryRunFormat != client.DryRunFormatText && flags.DryRunFormat != client.DryRunFormatJSON {
+		return errors.Errorf("invalid dry-run format %s, must be one of %s or %s", style.Symbol(flags.DryRunFormat), style.Symbol(client.DryRunFormatText), style.Symbol(client.DryRunFormatJSON))
+	}
+
 	return nil
 }
 
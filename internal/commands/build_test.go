@@ -114,6 +114,19 @@ func testBuildCommand(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 
+			when("a verification policy requires the builder to be signed", func() {
+				it("doesn't trust the builder", func() {
+					mockClient.EXPECT().
+						Build(gomock.Any(), EqBuildOptionsWithTrustedBuilder(false)).
+						Return(nil)
+
+					cfg := config.Config{VerificationPolicies: []config.VerificationPolicy{{Image: "index.docker.io/library/my-builder", PublicKeys: []string{"cosign.pub"}}}}
+					command = commands.Build(logger, cfg, mockClient)
+					command.SetArgs([]string{"image", "--builder", "my-builder:latest"})
+					h.AssertNil(t, command.Execute())
+				})
+			})
+
 			when("the builder is suggested", func() {
 				it("sets the trust builder option", func() {
 					mockClient.EXPECT().
@@ -394,6 +407,25 @@ func testBuildCommand(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("--cache with a remote format", func() {
+			it("passes the cache options", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithCacheFlags("type=build;format=http;url=http://cache.example.com/Some-Project;type=launch;format=volume;")).
+					Return(nil)
+
+				command.SetArgs([]string{"--builder", "my-builder", "image", "--cache", "type=build;format=http;url=http://cache.example.com/Some-Project"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			when("used together with --cache-image", func() {
+				it("errors", func() {
+					command.SetArgs([]string{"--builder", "my-builder", "image", "--cache-image", "some-cache-image", "--publish", "--cache", "type=build;format=dir;source=some-dir"})
+					err := command.Execute()
+					h.AssertError(t, err, "'cache' flag with 'dir' format cannot be used with 'cache-image' flag")
+				})
+			})
+		})
+
 		when("a valid lifecycle-image is provided", func() {
 			when("only the image repo is provided", func() {
 				it("uses the provided lifecycle-image and parses it correctly", func() {
@@ -763,6 +795,334 @@ builder = "my-builder"
 			})
 		})
 
+		when("--platform", func() {
+			it("passes the platform to the build", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithPlatform("linux/arm64")).
+					Return(nil)
+
+				command.SetArgs([]string{"--builder", "my-builder", "image", "--platform", "linux/arm64"})
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
+		when("--watch", func() {
+			it("passes the watch option to the build", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithWatch()).
+					Return(nil)
+
+				command.SetArgs([]string{"--builder", "my-builder", "image", "--watch"})
+				h.AssertNil(t, command.Execute())
+				h.AssertNotContains(t, outBuf.String(), "Successfully built image")
+			})
+
+			when("--dry-run is provided", func() {
+				it("errors", func() {
+					command.SetArgs([]string{"--builder", "my-builder", "image", "--watch", "--dry-run"})
+					h.AssertError(t, command.Execute(), "watch flag cannot be used with the dry-run flag")
+				})
+			})
+		})
+
+		when("--dry-run", func() {
+			it("passes the dry-run options to the build", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithDryRun("json")).
+					Return(nil)
+
+				command.SetArgs([]string{"--builder", "my-builder", "image", "--dry-run", "--dry-run-format", "json"})
+				h.AssertNil(t, command.Execute())
+				h.AssertNotContains(t, outBuf.String(), "Successfully built image")
+			})
+
+			when("--dry-run-format is invalid", func() {
+				it("errors", func() {
+					command.SetArgs([]string{"--builder", "my-builder", "image", "--dry-run", "--dry-run-format", "yaml"})
+					h.AssertError(t, command.Execute(), "invalid dry-run format 'yaml', must be one of 'text' or 'json'")
+				})
+			})
+
+			when("--dry-run-format is provided without --dry-run", func() {
+				it("errors", func() {
+					command.SetArgs([]string{"--builder", "my-builder", "image", "--dry-run-format", "json"})
+					h.AssertError(t, command.Execute(), "dry-run-format flag requires the dry-run flag")
+				})
+			})
+		})
+
+		when("--runtime", func() {
+			when("experimental is enabled", func() {
+				it.Before(func() {
+					command = commands.Build(logger, config.Config{Experimental: true}, mockClient)
+				})
+
+				it("passes the runtime to the build", func() {
+					mockClient.EXPECT().
+						Build(gomock.Any(), EqBuildOptionsWithRuntime("local")).
+						Return(nil)
+
+					command.SetArgs([]string{"--builder", "my-builder", "image", "--runtime", "local", "--publish"})
+					h.AssertNil(t, command.Execute())
+				})
+
+				when("the runtime is unknown", func() {
+					it("errors", func() {
+						command.SetArgs([]string{"--builder", "my-builder", "image", "--runtime", "podman"})
+						h.AssertError(t, command.Execute(), "invalid runtime 'podman', must be one of 'docker' or 'local'")
+					})
+				})
+
+				when("the local runtime is used without --publish", func() {
+					it("errors", func() {
+						command.SetArgs([]string{"--builder", "my-builder", "image", "--runtime", "local"})
+						h.AssertError(t, command.Execute(), "local runtime requires the publish flag or an OCI layout image name")
+					})
+				})
+			})
+
+			when("experimental is disabled", func() {
+				it("errors", func() {
+					command.SetArgs([]string{"--builder", "my-builder", "image", "--runtime", "local", "--publish"})
+					h.AssertError(t, command.Execute(), "Selecting a runtime is currently experimental.")
+				})
+			})
+		})
+
+		when("--remote-host", func() {
+			when("experimental is enabled", func() {
+				it.Before(func() {
+					command = commands.Build(logger, config.Config{Experimental: true}, mockClient)
+				})
+
+				it("passes the remote host to the build", func() {
+					mockClient.EXPECT().
+						Build(gomock.Any(), EqBuildOptionsWithRemoteHost("ssh://some-host")).
+						Return(nil)
+
+					command.SetArgs([]string{"--builder", "my-builder", "image", "--remote-host", "ssh://some-host", "--publish"})
+					h.AssertNil(t, command.Execute())
+				})
+
+				when("the image is not published", func() {
+					it("errors", func() {
+						command.SetArgs([]string{"--builder", "my-builder", "image", "--remote-host", "ssh://some-host"})
+						h.AssertError(t, command.Execute(), "remote-host flag requires the publish flag")
+					})
+				})
+			})
+
+			when("experimental is disabled", func() {
+				it("errors", func() {
+					command.SetArgs([]string{"--builder", "my-builder", "image", "--remote-host", "ssh://some-host", "--publish"})
+					h.AssertError(t, command.Execute(), "Building on a remote host is currently experimental.")
+				})
+			})
+		})
+
+		when("--verify-reproducible", func() {
+			it("passes the option to the build", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithVerifyReproducible(true)).
+					Return(nil)
+
+				command.SetArgs([]string{"--builder", "my-builder", "image", "--verify-reproducible"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			when("publishing without a previous image", func() {
+				it("errors", func() {
+					command.SetArgs([]string{"--builder", "my-builder", "image", "--verify-reproducible", "--publish"})
+					h.AssertError(t, command.Execute(), "verify-reproducible flag requires the previous-image flag when publishing")
+				})
+			})
+		})
+
+		when("--sign-key", func() {
+			it("passes the signing key to the build", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithSignKey("cosign.key")).
+					Return(nil)
+
+				command.SetArgs([]string{"--builder", "my-builder", "image", "--publish", "--sign-key", "cosign.key"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			when("not publishing", func() {
+				it("errors", func() {
+					command.SetArgs([]string{"--builder", "my-builder", "image", "--sign-key", "cosign.key"})
+					h.AssertError(t, command.Execute(), "sign-key flag requires the publish flag")
+				})
+			})
+		})
+
+		when("--provenance-output", func() {
+			it("passes the path to the build", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithProvenanceOutput("provenance.json")).
+					Return(nil)
+
+				command.SetArgs([]string{"--builder", "my-builder", "image", "--provenance-output", "provenance.json"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			when("exporting to OCI layout format", func() {
+				it("errors", func() {
+					command = commands.Build(logger, config.Config{Experimental: true}, mockClient)
+					command.SetArgs([]string{"--builder", "my-builder", "oci:image", "--provenance-output", "provenance.json"})
+					h.AssertError(t, command.Execute(), "provenance-output flag cannot be used when exporting to OCI layout format")
+				})
+			})
+		})
+
+		when("--profile", func() {
+			var projectTomlPath string
+
+			it.Before(func() {
+				projectTomlPath = filepath.Join(t.TempDir(), "project.toml")
+				h.AssertNil(t, os.WriteFile(projectTomlPath, []byte(`
+[_]
+schema-version = "0.3"
+
+[io.buildpacks]
+builder = "my-builder"
+run-image = "my-run-image"
+
+[io.buildpacks.profiles.prod]
+run-image = "my-hardened-run-image"
+
+[io.buildpacks.profiles.dev]
+builder = "my-debug-builder"
+`), 0600))
+			})
+
+			it("builds with the builder and run image of the descriptor by default", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithBuilderAndRunImage("my-builder", "my-run-image")).
+					Return(nil)
+
+				command.SetArgs([]string{"--descriptor", projectTomlPath, "image"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("builds with the overrides of the profile", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithBuilderAndRunImage("my-builder", "my-hardened-run-image")).
+					Return(nil)
+
+				command.SetArgs([]string{"--descriptor", projectTomlPath, "image", "--profile", "prod"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("prefers the builder and run image flags to the profile", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithBuilderAndRunImage("flag-builder", "flag-run-image")).
+					Return(nil)
+
+				command.SetArgs([]string{"--descriptor", projectTomlPath, "image", "--profile", "dev", "--builder", "flag-builder", "--run-image", "flag-run-image"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("locks the build in a lockfile of the profile", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithLock(filepath.Join(filepath.Dir(projectTomlPath), "project.prod.lock"), false)).
+					Return(nil)
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithLock(filepath.Join(filepath.Dir(projectTomlPath), "project.dev.lock"), true)).
+					Return(nil)
+
+				command.SetArgs([]string{"--descriptor", projectTomlPath, "image", "--profile", "prod", "--lock"})
+				h.AssertNil(t, command.Execute())
+
+				command = commands.Build(logger, cfg, mockClient)
+				command.SetArgs([]string{"--descriptor", projectTomlPath, "image", "--profile", "dev", "--update-lock"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("errors when the profile is not declared", func() {
+				command.SetArgs([]string{"--descriptor", projectTomlPath, "image", "--profile", "staging"})
+				h.AssertError(t, command.Execute(), "profile 'staging' not found, must be one of dev, prod")
+			})
+
+			it("errors without a project descriptor", func() {
+				command.SetArgs([]string{"--builder", "my-builder", "image", "--profile", "prod"})
+				h.AssertError(t, command.Execute(), "profile 'prod' requires a project descriptor")
+			})
+		})
+
+		when("--lock", func() {
+			it("passes the lockfile in the app dir to the build", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithLock(filepath.Join("some-app", "project.lock"), false)).
+					Return(nil)
+
+				command.SetArgs([]string{"--builder", "my-builder", "image", "--path", "some-app", "--lock"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("passes the lockfile next to the project descriptor to the build", func() {
+				tmpDir := t.TempDir()
+				projectTomlPath := filepath.Join(tmpDir, "project.toml")
+				h.AssertNil(t, os.WriteFile(projectTomlPath, []byte("[project]\nname = \"Sample\"\n"), 0600))
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithLock(filepath.Join(tmpDir, "project.lock"), false)).
+					Return(nil)
+
+				command.SetArgs([]string{"--builder", "my-builder", "image", "--descriptor", projectTomlPath, "--lock"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("doesn't lock the build by default", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithLock("", false)).
+					Return(nil)
+
+				command.SetArgs([]string{"--builder", "my-builder", "image"})
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
+		when("--update-lock", func() {
+			it("updates the lockfile of the build", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithLock("project.lock", true)).
+					Return(nil)
+
+				command.SetArgs([]string{"--builder", "my-builder", "image", "--update-lock"})
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
+		when("--sbom-policy", func() {
+			it("passes the policy to the build", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithSBOMPolicy("policy.toml")).
+					Return(nil)
+
+				command.SetArgs([]string{"--builder", "my-builder", "image", "--sbom-policy", "policy.toml"})
+				h.AssertNil(t, command.Execute())
+			})
+
+			when("exporting to OCI layout format", func() {
+				it("errors", func() {
+					command = commands.Build(logger, config.Config{Experimental: true}, mockClient)
+					command.SetArgs([]string{"--builder", "my-builder", "oci:image", "--sbom-policy", "policy.toml"})
+					h.AssertError(t, command.Execute(), "sbom-policy flag cannot be used when exporting to OCI layout format")
+				})
+			})
+
+			when("publishing", func() {
+				it("checks the published image", func() {
+					mockClient.EXPECT().
+						Build(gomock.Any(), EqBuildOptionsWithSBOMPolicy("policy.toml")).
+						Return(nil)
+
+					command.SetArgs([]string{"--builder", "my-builder", "image", "--sbom-policy", "policy.toml", "--publish"})
+					h.AssertNil(t, command.Execute())
+				})
+			})
+		})
+
 		when("previous-image flag is provided", func() {
 			when("image is invalid", func() {
 				it("error must be thrown", func() {
@@ -1012,11 +1372,20 @@ func EqBuildOptionsWithBuilder(builder string) gomock.Matcher {
 	}
 }
 
+func EqBuildOptionsWithBuilderAndRunImage(builder, runImage string) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("Builder=%s and RunImage=%s", builder, runImage),
+		equals: func(o client.BuildOptions) bool {
+			return o.Builder == builder && o.RunImage == runImage
+		},
+	}
+}
+
 func EqBuildOptionsWithTrustedBuilder(trustBuilder bool) gomock.Matcher {
 	return buildOptionsMatcher{
 		description: fmt.Sprintf("Trust Builder=%t", trustBuilder),
 		equals: func(o client.BuildOptions) bool {
-			return o.TrustBuilder(o.Builder)
+			return o.TrustBuilder(o.Builder) == trustBuilder
 		},
 	}
 }
@@ -1085,6 +1454,96 @@ func EqBuildOptionsWithPreviousImage(prevImage string) gomock.Matcher {
 	}
 }
 
+func EqBuildOptionsWithPlatform(platform string) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("Platform=%s", platform),
+		equals: func(o client.BuildOptions) bool {
+			return o.Platform == platform
+		},
+	}
+}
+
+func EqBuildOptionsWithWatch() gomock.Matcher {
+	return buildOptionsMatcher{
+		description: "Watch=true",
+		equals: func(o client.BuildOptions) bool {
+			return o.Watch
+		},
+	}
+}
+
+func EqBuildOptionsWithRemoteHost(remoteHost string) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("RemoteHost=%s", remoteHost),
+		equals: func(o client.BuildOptions) bool {
+			return o.RemoteHost == remoteHost
+		},
+	}
+}
+
+func EqBuildOptionsWithSBOMPolicy(policy string) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("SBOMPolicy=%s", policy),
+		equals: func(o client.BuildOptions) bool {
+			return o.SBOMPolicy == policy
+		},
+	}
+}
+
+func EqBuildOptionsWithVerifyReproducible(verify bool) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("VerifyReproducible=%t", verify),
+		equals: func(o client.BuildOptions) bool {
+			return o.VerifyReproducible == verify
+		},
+	}
+}
+
+func EqBuildOptionsWithProvenanceOutput(path string) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("ProvenanceOutput=%s", path),
+		equals: func(o client.BuildOptions) bool {
+			return o.ProvenanceOutput == path
+		},
+	}
+}
+
+func EqBuildOptionsWithLock(lockFile string, updateLock bool) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("LockFile=%s UpdateLock=%t", lockFile, updateLock),
+		equals: func(o client.BuildOptions) bool {
+			return o.LockFile == lockFile && o.UpdateLock == updateLock
+		},
+	}
+}
+
+func EqBuildOptionsWithSignKey(keyPath string) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("Sign.KeyPath=%s", keyPath),
+		equals: func(o client.BuildOptions) bool {
+			return o.Sign != nil && o.Sign.KeyPath == keyPath
+		},
+	}
+}
+
+func EqBuildOptionsWithRuntime(runtime string) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("Runtime=%s", runtime),
+		equals: func(o client.BuildOptions) bool {
+			return o.Runtime == runtime
+		},
+	}
+}
+
+func EqBuildOptionsWithDryRun(format string) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("DryRun=true and DryRunFormat=%s", format),
+		equals: func(o client.BuildOptions) bool {
+			return o.DryRun && o.DryRunFormat == format
+		},
+	}
+}
+
 func EqBuildOptionsWithSBOMOutputDir(s string) interface{} {
 	return buildOptionsMatcher{
 		description: fmt.Sprintf("sbom-destination-dir=%s", s),
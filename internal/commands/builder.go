@@ -18,6 +18,7 @@ func NewBuilderCommand(logger logging.Logger, cfg config.Config, client PackClie
 
 	cmd.AddCommand(BuilderCreate(logger, cfg, client))
 	cmd.AddCommand(BuilderInspect(logger, cfg, client, builderwriter.NewFactory()))
+	cmd.AddCommand(BuilderDiff(logger, client, builderwriter.NewFactory()))
 	cmd.AddCommand(BuilderSuggest(logger, client))
 	AddHelpFlag(cmd, "builder")
 	return cmd
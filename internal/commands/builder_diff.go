@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/builder"
+	"github.com/buildpacks/pack/internal/builder/writer"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type BuilderDiffFlags struct {
+	OutputFormat string
+}
+
+func BuilderDiff(logger logging.Logger,
+	inspector BuilderInspector,
+	writerFactory writer.BuilderDiffWriterFactory,
+) *cobra.Command {
+	var flags BuilderDiffFlags
+	cmd := &cobra.Command{
+		Use:   "diff <old-builder-image-name> [<new-builder-image-name>]",
+		Args:  cobra.RangeArgs(1, 2),
+		Short: "Show the differences between two builders",
+		Example: "pack builder diff cnbs/sample-builder:bionic cnbs/sample-builder:jammy\n" +
+			"pack builder diff cnbs/sample-builder:jammy",
+		Long: "Show the differences between two builders: their lifecycle and supported APIs, stack, run images, " +
+			"buildpacks, extensions and detection order.\n\n" +
+			"Each builder is looked up in the daemon first, then in the registry. When a single builder is provided, " +
+			"the copy in the daemon is compared with the copy in the registry.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			writer, err := writerFactory.DiffWriter(flags.OutputFormat)
+			if err != nil {
+				return err
+			}
+
+			var oldName, newName string
+			var oldInfo, newInfo *client.BuilderInfo
+			if len(args) == 1 {
+				oldName, newName = args[0]+" (daemon)", args[0]+" (registry)"
+				if oldInfo, err = inspectBuilderFrom(inspector, args[0], true); err != nil {
+					return err
+				}
+				if newInfo, err = inspectBuilderFrom(inspector, args[0], false); err != nil {
+					return err
+				}
+			} else {
+				oldName, newName = args[0], args[1]
+				if oldInfo, err = findBuilder(inspector, oldName); err != nil {
+					return err
+				}
+				if newInfo, err = findBuilder(inspector, newName); err != nil {
+					return err
+				}
+			}
+
+			return writer.PrintDiff(logger, oldName, newName, client.DiffBuilders(oldInfo, newInfo))
+		}),
+	}
+
+	cmd.Flags().StringVarP(&flags.OutputFormat, "output", "o", "human-readable", "Output format to display the differences (json, yaml, toml, human-readable).\nOmission of this flag will display as human-readable.")
+	AddHelpFlag(cmd, "diff")
+	return cmd
+}
+
+// findBuilder looks up a builder in the daemon, falling back to the registry
+func findBuilder(inspector BuilderInspector, imageName string) (*client.BuilderInfo, error) {
+	info, err := inspector.InspectBuilder(imageName, true, client.WithDetectionOrderDepth(builder.OrderDetectionNone))
+	if err != nil {
+		return nil, errors.Wrapf(err, "inspecting builder %s", style.Symbol(imageName))
+	}
+	if info != nil {
+		return info, nil
+	}
+
+	info, err = inspector.InspectBuilder(imageName, false, client.WithDetectionOrderDepth(builder.OrderDetectionNone))
+	if err != nil {
+		return nil, errors.Wrapf(err, "inspecting builder %s", style.Symbol(imageName))
+	}
+	if info == nil {
+		return nil, errors.Errorf("unable to find builder %s locally or remotely", style.Symbol(imageName))
+	}
+	return info, nil
+}
+
+func inspectBuilderFrom(inspector BuilderInspector, imageName string, daemon bool) (*client.BuilderInfo, error) {
+	info, err := inspector.InspectBuilder(imageName, daemon, client.WithDetectionOrderDepth(builder.OrderDetectionNone))
+	if err != nil {
+		return nil, errors.Wrapf(err, "inspecting builder %s", style.Symbol(imageName))
+	}
+	if info == nil {
+		location := "remotely"
+		if daemon {
+			location = "locally"
+		}
+		return nil, errors.Errorf("unable to find builder %s %s", style.Symbol(imageName), location)
+	}
+	return info, nil
+}
@@ -0,0 +1,174 @@
+package commands_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	pubbldr "github.com/buildpacks/pack/builder"
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/fakes"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBuilderDiffCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "BuilderDiffCommand", testBuilderDiffCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBuilderDiffCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		logger        logging.Logger
+		outBuf        bytes.Buffer
+		inspector     *builderInspectorByName
+		diffWriter    *fakes.FakeBuilderDiffWriter
+		writerFactory *fakes.FakeBuilderDiffWriterFactory
+		assert        = h.NewAssertionManager(t)
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		inspector = &builderInspectorByName{
+			local: map[string]*client.BuilderInfo{
+				"some/builder": {Stack: "some.stack", Lifecycle: minimalLifecycleDescriptor},
+			},
+			remote: map[string]*client.BuilderInfo{
+				"some/builder":  {Stack: "registry.stack", Lifecycle: minimalLifecycleDescriptor},
+				"other/builder": {Stack: "other.stack", Lifecycle: minimalLifecycleDescriptor},
+			},
+		}
+		diffWriter = &fakes.FakeBuilderDiffWriter{PrintForDiff: "Sample diff"}
+		writerFactory = &fakes.FakeBuilderDiffWriterFactory{ReturnForWriter: diffWriter}
+	})
+
+	when("two builders are provided", func() {
+		it("prints the differences between them", func() {
+			command := commands.BuilderDiff(logger, inspector, writerFactory)
+			command.SetArgs([]string{"some/builder", "other/builder"})
+
+			assert.Nil(command.Execute())
+
+			assert.Equal(writerFactory.ReceivedForKind, "human-readable")
+			assert.Equal(diffWriter.ReceivedOldName, "some/builder")
+			assert.Equal(diffWriter.ReceivedNewName, "other/builder")
+			assert.Equal(diffWriter.ReceivedDiff.Stack, &client.ValueChange{Old: "some.stack", New: "other.stack"})
+			assert.Contains(outBuf.String(), "DIFF:\nSample diff")
+		})
+
+		it("does not expand the detection order", func() {
+			command := commands.BuilderDiff(logger, inspector, writerFactory)
+			command.SetArgs([]string{"some/builder", "other/builder"})
+
+			assert.Nil(command.Execute())
+
+			assert.Equal(inspector.receivedDepths, []int{pubbldr.OrderDetectionNone, pubbldr.OrderDetectionNone, pubbldr.OrderDetectionNone})
+		})
+
+		when("a builder cannot be found", func() {
+			it("returns an error", func() {
+				command := commands.BuilderDiff(logger, inspector, writerFactory)
+				command.SetArgs([]string{"some/builder", "missing/builder"})
+
+				err := command.Execute()
+				assert.ErrorWithMessage(err, "unable to find builder 'missing/builder' locally or remotely")
+			})
+		})
+
+		when("inspecting a builder fails", func() {
+			it("returns an error", func() {
+				inspector.err = errors.New("some inspect error")
+				command := commands.BuilderDiff(logger, inspector, writerFactory)
+				command.SetArgs([]string{"some/builder", "other/builder"})
+
+				err := command.Execute()
+				assert.ErrorWithMessage(err, "inspecting builder 'some/builder': some inspect error")
+			})
+		})
+	})
+
+	when("one builder is provided", func() {
+		it("compares the daemon copy with the registry copy", func() {
+			command := commands.BuilderDiff(logger, inspector, writerFactory)
+			command.SetArgs([]string{"some/builder"})
+
+			assert.Nil(command.Execute())
+
+			assert.Equal(diffWriter.ReceivedOldName, "some/builder (daemon)")
+			assert.Equal(diffWriter.ReceivedNewName, "some/builder (registry)")
+			assert.Equal(diffWriter.ReceivedDiff.Stack, &client.ValueChange{Old: "some.stack", New: "registry.stack"})
+		})
+
+		when("the builder is not in the daemon", func() {
+			it("returns an error", func() {
+				command := commands.BuilderDiff(logger, inspector, writerFactory)
+				command.SetArgs([]string{"other/builder"})
+
+				err := command.Execute()
+				assert.ErrorWithMessage(err, "unable to find builder 'other/builder' locally")
+			})
+		})
+	})
+
+	when("output format is provided", func() {
+		it("passes it to the writer factory", func() {
+			command := commands.BuilderDiff(logger, inspector, writerFactory)
+			command.SetArgs([]string{"some/builder", "other/builder", "--output", "json"})
+
+			assert.Nil(command.Execute())
+
+			assert.Equal(writerFactory.ReceivedForKind, "json")
+		})
+	})
+
+	when("the writer factory fails", func() {
+		it("returns an error", func() {
+			writerFactory.ErrorForWriter = errors.New("some writer error")
+			command := commands.BuilderDiff(logger, inspector, writerFactory)
+			command.SetArgs([]string{"some/builder", "other/builder"})
+
+			err := command.Execute()
+			assert.ErrorWithMessage(err, "some writer error")
+		})
+	})
+
+	when("no builder is provided", func() {
+		it("returns an error", func() {
+			command := commands.BuilderDiff(logger, inspector, writerFactory)
+			command.SetArgs([]string{})
+
+			err := command.Execute()
+			assert.ErrorWithMessage(err, "accepts between 1 and 2 arg(s), received 0")
+		})
+	})
+}
+
+type builderInspectorByName struct {
+	local  map[string]*client.BuilderInfo
+	remote map[string]*client.BuilderInfo
+	err    error
+
+	receivedDepths []int
+}
+
+func (i *builderInspectorByName) InspectBuilder(name string, daemon bool, modifiers ...client.BuilderInspectionModifier) (*client.BuilderInfo, error) {
+	var config client.BuilderInspectionConfig
+	for _, mod := range modifiers {
+		mod(&config)
+	}
+	i.receivedDepths = append(i.receivedDepths, config.OrderDetectionDepth)
+
+	if i.err != nil {
+		return nil, i.err
+	}
+	if daemon {
+		return i.local[name], nil
+	}
+	return i.remote[name], nil
+}
@@ -237,6 +237,25 @@ func testPackageCommand(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("--target", func() {
+			it("passes the parsed targets", func() {
+				cmd := packageCommand(withBuildpackPackager(fakeBuildpackPackager))
+				cmd.SetArgs([]string{
+					"some-image-name",
+					"--config", "/path/to/some/file",
+					"--target", "linux/amd64",
+					"--target", "linux/arm/v7",
+				})
+				h.AssertNil(t, cmd.Execute())
+
+				receivedOptions := fakeBuildpackPackager.CreateCalledWithOptions
+				h.AssertEq(t, receivedOptions.Targets, []dist.Target{
+					{OS: "linux", Arch: "amd64"},
+					{OS: "linux", Arch: "arm", ArchVariant: "v7"},
+				})
+			})
+		})
+
 		when("no config path is specified", func() {
 			when("no path is specified", func() {
 				it("creates a default config with the uri set to the current working directory", func() {
@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// BuildpackSearchFlags consist of flags applicable to the `buildpack search` command
+type BuildpackSearchFlags struct {
+	// BuildpackRegistry is the name of the buildpack registry to search in
+	BuildpackRegistry string
+	OutputFormat      string
+}
+
+// BuildpackSearch finds buildpacks in a buildpack registry
+func BuildpackSearch(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var flags BuildpackSearchFlags
+
+	cmd := &cobra.Command{
+		Use:     "search <term>",
+		Args:    cobra.ExactArgs(1),
+		Short:   "Search for buildpacks in a registry",
+		Long:    "Search for buildpacks whose ID contains term in a registry, showing the latest version of each buildpack",
+		Example: "pack buildpack search java",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if err := validateRegistryOutputFormat(flags.OutputFormat); err != nil {
+				return err
+			}
+
+			registry, err := config.GetRegistry(cfg, flags.BuildpackRegistry)
+			if err != nil {
+				return err
+			}
+
+			buildpacks, err := pack.SearchBuildpacks(client.SearchBuildpacksOptions{
+				Term:         args[0],
+				RegistryName: registry.Name,
+			})
+			if err != nil {
+				return err
+			}
+
+			if flags.OutputFormat == "json" {
+				return writeRegistryBuildpacksJSON(logger, buildpacks)
+			}
+			if len(buildpacks) == 0 {
+				logger.Infof("No buildpacks found matching %s", style.Symbol(args[0]))
+				return nil
+			}
+
+			tw := tabwriter.NewWriter(logger.Writer(), 0, 0, 3, ' ', 0)
+			fmt.Fprintln(tw, "ID\tLATEST\tYANKED\tADDRESS")
+			for _, bp := range buildpacks {
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", bp.ID, bp.Version, strconv.FormatBool(bp.Yanked), bp.Address)
+			}
+			return tw.Flush()
+		}),
+	}
+	cmd.Flags().StringVarP(&flags.BuildpackRegistry, "buildpack-registry", "r", "", "Buildpack Registry name")
+	cmd.Flags().StringVarP(&flags.OutputFormat, "output", "o", "table", "Output format to display buildpacks (table, json)")
+	AddHelpFlag(cmd, "search")
+	return cmd
+}
+
+func validateRegistryOutputFormat(format string) error {
+	if format != "table" && format != "json" {
+		return errors.Errorf("output format %s is not supported", style.Symbol(format))
+	}
+	return nil
+}
+
+func writeRegistryBuildpacksJSON(logger logging.Logger, buildpacks []client.RegistryBuildpack) error {
+	if buildpacks == nil {
+		buildpacks = []client.RegistryBuildpack{}
+	}
+	out, err := json.MarshalIndent(buildpacks, "", "  ")
+	if err != nil {
+		return err
+	}
+	logger.Info(string(out))
+	return nil
+}
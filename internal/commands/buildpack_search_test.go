@@ -0,0 +1,122 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBuildpackSearchCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "BuildpackSearchCommand", testBuildpackSearchCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBuildpackSearchCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+		cfg            config.Config
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		cfg = config.Config{
+			Registries: []config.Registry{
+				{Name: "internal", Type: "github", URL: "https://github.com/example/registry-index"},
+			},
+		}
+
+		command = commands.BuildpackSearch(logger, cfg, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#BuildpackSearch", func() {
+		it("fails without a term", func() {
+			command.SetArgs([]string{})
+			h.AssertError(t, command.Execute(), "accepts 1 arg")
+		})
+
+		it("lists the latest version of the buildpacks found", func() {
+			mockClient.EXPECT().
+				SearchBuildpacks(client.SearchBuildpacksOptions{Term: "java", RegistryName: "official"}).
+				Return([]client.RegistryBuildpack{
+					{ID: "example/java", Version: "1.2.0", Address: "example.com/java@sha256:abc"},
+					{ID: "example/java-legacy", Version: "0.9.0", Yanked: true, Address: "example.com/java-legacy@sha256:def"},
+				}, nil)
+
+			command.SetArgs([]string{"java"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContainsMatch(t, outBuf.String(), `ID\s+LATEST\s+YANKED\s+ADDRESS`)
+			h.AssertContainsMatch(t, outBuf.String(), `example/java\s+1.2.0\s+false\s+example.com/java@sha256:abc`)
+			h.AssertContainsMatch(t, outBuf.String(), `example/java-legacy\s+0.9.0\s+true\s+example.com/java-legacy@sha256:def`)
+		})
+
+		it("searches the given registry", func() {
+			mockClient.EXPECT().
+				SearchBuildpacks(client.SearchBuildpacksOptions{Term: "java", RegistryName: "internal"}).
+				Return(nil, nil)
+
+			command.SetArgs([]string{"java", "--buildpack-registry", "internal"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "No buildpacks found matching 'java'")
+		})
+
+		when("--output json", func() {
+			it("prints the buildpacks as JSON", func() {
+				mockClient.EXPECT().
+					SearchBuildpacks(gomock.Any()).
+					Return([]client.RegistryBuildpack{
+						{ID: "example/java", Version: "1.2.0", Address: "example.com/java@sha256:abc"},
+					}, nil)
+
+				command.SetArgs([]string{"java", "--output", "json"})
+				h.AssertNil(t, command.Execute())
+				h.AssertContains(t, outBuf.String(), `"id": "example/java",
+    "version": "1.2.0",
+    "yanked": false,
+    "address": "example.com/java@sha256:abc"`)
+			})
+
+			it("prints an empty list when no buildpacks are found", func() {
+				mockClient.EXPECT().
+					SearchBuildpacks(gomock.Any()).
+					Return(nil, nil)
+
+				command.SetArgs([]string{"java", "--output", "json"})
+				h.AssertNil(t, command.Execute())
+				h.AssertEq(t, outBuf.String(), "[]\n")
+			})
+		})
+
+		it("fails for an unsupported output format", func() {
+			command.SetArgs([]string{"java", "--output", "yaml"})
+			h.AssertError(t, command.Execute(), "output format 'yaml' is not supported")
+		})
+
+		it("fails for an unknown registry", func() {
+			command.SetArgs([]string{"java", "--buildpack-registry", "unknown"})
+			h.AssertError(t, command.Execute(), "registry 'unknown' is not defined")
+		})
+	})
+}
@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// BuildpackVersionsFlags consist of flags applicable to the `buildpack versions` command
+type BuildpackVersionsFlags struct {
+	// BuildpackRegistry is the name of the buildpack registry to list versions from
+	BuildpackRegistry string
+	OutputFormat      string
+}
+
+// BuildpackVersions lists the versions of a buildpack in a buildpack registry
+func BuildpackVersions(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var flags BuildpackVersionsFlags
+
+	cmd := &cobra.Command{
+		Use:     "versions <buildpack-id>",
+		Args:    cobra.ExactArgs(1),
+		Short:   "List the versions of a buildpack in a registry",
+		Example: "pack buildpack versions example/my-buildpack",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if err := validateRegistryOutputFormat(flags.OutputFormat); err != nil {
+				return err
+			}
+
+			registry, err := config.GetRegistry(cfg, flags.BuildpackRegistry)
+			if err != nil {
+				return err
+			}
+
+			buildpacks, err := pack.BuildpackVersions(client.BuildpackVersionsOptions{
+				ID:           args[0],
+				RegistryName: registry.Name,
+			})
+			if err != nil {
+				return err
+			}
+
+			if flags.OutputFormat == "json" {
+				return writeRegistryBuildpacksJSON(logger, buildpacks)
+			}
+
+			tw := tabwriter.NewWriter(logger.Writer(), 0, 0, 3, ' ', 0)
+			fmt.Fprintln(tw, "VERSION\tYANKED\tADDRESS")
+			for _, bp := range buildpacks {
+				fmt.Fprintf(tw, "%s\t%s\t%s\n", bp.Version, strconv.FormatBool(bp.Yanked), bp.Address)
+			}
+			return tw.Flush()
+		}),
+	}
+	cmd.Flags().StringVarP(&flags.BuildpackRegistry, "buildpack-registry", "r", "", "Buildpack Registry name")
+	cmd.Flags().StringVarP(&flags.OutputFormat, "output", "o", "table", "Output format to display versions (table, json)")
+	AddHelpFlag(cmd, "versions")
+	return cmd
+}
@@ -0,0 +1,85 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBuildpackVersionsCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "BuildpackVersionsCommand", testBuildpackVersionsCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBuildpackVersionsCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+
+		command = commands.BuildpackVersions(logger, config.Config{}, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#BuildpackVersions", func() {
+		it("lists the versions of the buildpack", func() {
+			mockClient.EXPECT().
+				BuildpackVersions(client.BuildpackVersionsOptions{ID: "example/java", RegistryName: "official"}).
+				Return([]client.RegistryBuildpack{
+					{ID: "example/java", Version: "1.1.0", Yanked: true, Address: "example.com/java@sha256:def"},
+					{ID: "example/java", Version: "1.0.0", Address: "example.com/java@sha256:abc"},
+				}, nil)
+
+			command.SetArgs([]string{"example/java"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContainsMatch(t, outBuf.String(), `VERSION\s+YANKED\s+ADDRESS`)
+			h.AssertContainsMatch(t, outBuf.String(), `1.1.0\s+true\s+example.com/java@sha256:def\n1.0.0\s+false\s+example.com/java@sha256:abc`)
+		})
+
+		it("prints the versions as JSON", func() {
+			mockClient.EXPECT().
+				BuildpackVersions(gomock.Any()).
+				Return([]client.RegistryBuildpack{
+					{ID: "example/java", Version: "1.0.0", Address: "example.com/java@sha256:abc"},
+				}, nil)
+
+			command.SetArgs([]string{"example/java", "-o", "json"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), `"version": "1.0.0"`)
+		})
+
+		it("returns errors from the client", func() {
+			mockClient.EXPECT().
+				BuildpackVersions(gomock.Any()).
+				Return(nil, errors.New("no entries for buildpack: example/java"))
+
+			command.SetArgs([]string{"example/java"})
+			h.AssertError(t, command.Execute(), "no entries for buildpack: example/java")
+		})
+	})
+}
@@ -40,7 +40,7 @@ func BuildpackYank(logger logging.Logger, cfg config.Config, pack PackClient) *c
 			opts := client.YankBuildpackOptions{
 				ID:      id,
 				Version: version,
-				Type:    "github",
+				Type:    registry.Type,
 				URL:     registry.URL,
 				Yank:    !flags.Undo,
 			}
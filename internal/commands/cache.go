@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+func NewCacheCommand(logger logging.Logger, client PackClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Interact with build caches",
+		RunE:  nil,
+	}
+
+	cmd.AddCommand(CacheList(logger, client))
+	cmd.AddCommand(CacheInspect(logger, client))
+	cmd.AddCommand(CachePrune(logger, client))
+	cmd.AddCommand(CacheExport(logger, client))
+	cmd.AddCommand(CacheImport(logger, client))
+
+	AddHelpFlag(cmd, "cache")
+	return cmd
+}
+
+type cacheVolumeFlags struct {
+	Type   string
+	Volume string
+}
+
+func addCacheVolumeFlags(cmd *cobra.Command, flags *cacheVolumeFlags) {
+	cmd.Flags().StringVar(&flags.Type, "type", "build", "Type of cache, one of 'build', 'launch' or 'kaniko'")
+	cmd.Flags().StringVar(&flags.Volume, "volume", "", "Name of the cache volume, for caches created with a custom name.\nWhen set, the image name is not required")
+}
+
+func cacheVolumeOptions(args []string, flags cacheVolumeFlags) client.CacheVolumeOptions {
+	opts := client.CacheVolumeOptions{Type: flags.Type, Name: flags.Volume}
+	if len(args) > 0 {
+		opts.Image = args[0]
+	}
+	return opts
+}
+
+func writeCaches(logger logging.Logger, caches []client.CacheVolume) {
+	tw := tabwriter.NewWriter(logger.Writer(), 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tTYPE\tIMAGE\tSIZE\tCREATED")
+	for _, c := range caches {
+		image := c.Image
+		if image == "" {
+			image = "<none>"
+		}
+
+		size := "-"
+		if c.Size >= 0 {
+			size = humanize.Bytes(uint64(c.Size))
+		}
+
+		created := "-"
+		if !c.CreatedAt.IsZero() {
+			created = humanize.Time(c.CreatedAt)
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", c.Name, c.Type, image, size, created)
+	}
+	tw.Flush()
+}
@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type CacheExportFlags struct {
+	cacheVolumeFlags
+	Output      string
+	HelperImage string
+}
+
+// CacheExport writes a cache volume to a tarball
+func CacheExport(logger logging.Logger, pack PackClient) *cobra.Command {
+	var flags CacheExportFlags
+
+	cmd := &cobra.Command{
+		Use:   "export [<image-name>] --output <tarball>",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "Export a build cache to a tarball",
+		Long: "Export the contents of a cache volume to a tarball, for example to seed the cache of another machine " +
+			"with 'pack cache import'.",
+		Example: "pack cache export my-app --type build --output build-cache.tar",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 && flags.Volume == "" {
+				return errors.New("an image name or --volume must be provided")
+			}
+			if flags.Output == "" {
+				return errors.New("Please provide a tarball path, using --output.")
+			}
+
+			return pack.ExportCache(cmd.Context(), client.ExportCacheOptions{
+				CacheVolumeOptions: cacheVolumeOptions(args, flags.cacheVolumeFlags),
+				Path:               flags.Output,
+				HelperImage:        flags.HelperImage,
+			})
+		}),
+	}
+
+	addCacheVolumeFlags(cmd, &flags.cacheVolumeFlags)
+	cmd.Flags().StringVarP(&flags.Output, "output", "o", "", "Path of the tarball to write")
+	cmd.Flags().StringVar(&flags.HelperImage, "helper-image", "", "Image used to access the cache volume (defaults to the lifecycle image)")
+	AddHelpFlag(cmd, "export")
+	return cmd
+}
@@ -0,0 +1,76 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCacheExportCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "CacheExportCommand", testCacheExportCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testCacheExportCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		command = commands.CacheExport(logger, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	it("exports the build cache of the image by default", func() {
+		mockClient.EXPECT().ExportCache(gomock.Any(), client.ExportCacheOptions{
+			CacheVolumeOptions: client.CacheVolumeOptions{Image: "some/app", Type: "build"},
+			Path:               "cache.tar",
+		}).Return(nil)
+
+		command.SetArgs([]string{"some/app", "-o", "cache.tar"})
+		h.AssertNil(t, command.Execute())
+	})
+
+	it("exports a named cache volume", func() {
+		mockClient.EXPECT().ExportCache(gomock.Any(), client.ExportCacheOptions{
+			CacheVolumeOptions: client.CacheVolumeOptions{Type: "build", Name: "some-volume"},
+			Path:               "cache.tar",
+			HelperImage:        "some/helper",
+		}).Return(nil)
+
+		command.SetArgs([]string{"--volume", "some-volume", "--output", "cache.tar", "--helper-image", "some/helper"})
+		h.AssertNil(t, command.Execute())
+	})
+
+	it("requires an image or volume", func() {
+		command.SetArgs([]string{"--output", "cache.tar"})
+		h.AssertError(t, command.Execute(), "an image name or --volume must be provided")
+	})
+
+	it("requires a tarball path", func() {
+		command.SetArgs([]string{"some/app"})
+		h.AssertError(t, command.Execute(), "Please provide a tarball path, using --output.")
+	})
+}
@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type CacheImportFlags struct {
+	cacheVolumeFlags
+	Input       string
+	HelperImage string
+}
+
+// CacheImport replaces the contents of a cache volume with a tarball
+func CacheImport(logger logging.Logger, pack PackClient) *cobra.Command {
+	var flags CacheImportFlags
+
+	cmd := &cobra.Command{
+		Use:   "import [<image-name>] --input <tarball>",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "Import a build cache from a tarball",
+		Long: "Replace the contents of a cache volume with a tarball created by 'pack cache export'. " +
+			"The cache is used by the next build of the image.",
+		Example: "pack cache import my-app --type build --input build-cache.tar",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 && flags.Volume == "" {
+				return errors.New("an image name or --volume must be provided")
+			}
+			if flags.Input == "" {
+				return errors.New("Please provide a tarball path, using --input.")
+			}
+
+			return pack.ImportCache(cmd.Context(), client.ImportCacheOptions{
+				CacheVolumeOptions: cacheVolumeOptions(args, flags.cacheVolumeFlags),
+				Path:               flags.Input,
+				HelperImage:        flags.HelperImage,
+			})
+		}),
+	}
+
+	addCacheVolumeFlags(cmd, &flags.cacheVolumeFlags)
+	cmd.Flags().StringVarP(&flags.Input, "input", "i", "", "Path of the tarball to import")
+	cmd.Flags().StringVar(&flags.HelperImage, "helper-image", "", "Image used to access the cache volume (defaults to the lifecycle image)")
+	AddHelpFlag(cmd, "import")
+	return cmd
+}
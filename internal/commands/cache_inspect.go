@@ -0,0 +1,36 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// CacheInspect shows the cache volumes of an image
+func CacheInspect(logger logging.Logger, pack PackClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "inspect <image-name>",
+		Args:    cobra.ExactArgs(1),
+		Short:   "Show the build caches of an image",
+		Example: "pack cache inspect my-app",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			caches, err := pack.InspectCache(cmd.Context(), client.InspectCacheOptions{Image: args[0]})
+			if err != nil {
+				return err
+			}
+
+			if len(caches) == 0 {
+				logger.Infof("No caches found for image %s", style.Symbol(args[0]))
+				return nil
+			}
+
+			writeCaches(logger, caches)
+			return nil
+		}),
+	}
+
+	AddHelpFlag(cmd, "inspect")
+	return cmd
+}
@@ -0,0 +1,63 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCacheInspectCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "CacheInspectCommand", testCacheInspectCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testCacheInspectCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		command = commands.CacheInspect(logger, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	it("shows the caches of the image", func() {
+		mockClient.EXPECT().InspectCache(gomock.Any(), client.InspectCacheOptions{Image: "some/app"}).Return([]client.CacheVolume{
+			{Name: "some-build-volume", Type: "build", Image: "some/app", Size: 10},
+		}, nil)
+
+		command.SetArgs([]string{"some/app"})
+		h.AssertNil(t, command.Execute())
+		h.AssertContainsMatch(t, outBuf.String(), `some-build-volume\s+build\s+some/app\s+10 B`)
+	})
+
+	it("reports when the image has no caches", func() {
+		mockClient.EXPECT().InspectCache(gomock.Any(), client.InspectCacheOptions{Image: "some/app"}).Return(nil, nil)
+
+		command.SetArgs([]string{"some/app"})
+		h.AssertNil(t, command.Execute())
+		h.AssertContains(t, outBuf.String(), "No caches found for image 'some/app'")
+	})
+}
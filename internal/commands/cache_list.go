@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// CacheList lists the cache volumes created by pack
+func CacheList(logger logging.Logger, pack PackClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list",
+		Args:    cobra.NoArgs,
+		Short:   "List build caches",
+		Long:    "List the build, launch and kaniko cache volumes created by pack, along with the image they belong to and their size.",
+		Example: "pack cache list",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			caches, err := pack.ListCaches(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			if len(caches) == 0 {
+				logger.Info("No caches found")
+				return nil
+			}
+
+			writeCaches(logger, caches)
+			return nil
+		}),
+	}
+
+	AddHelpFlag(cmd, "list")
+	return cmd
+}
@@ -0,0 +1,77 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCacheListCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "CacheListCommand", testCacheListCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testCacheListCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		command = commands.CacheList(logger, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	it("lists the caches", func() {
+		mockClient.EXPECT().ListCaches(gomock.Any()).Return([]client.CacheVolume{
+			{Name: "pack-cache-some_app_latest-123456789abc.build", Type: "build", Image: "some/app:latest", Size: 2048, CreatedAt: time.Now().Add(-2 * time.Hour)},
+			{Name: "pack-cache-other_app_latest-123456789abc.launch", Type: "launch", Size: -1},
+		}, nil)
+
+		command.SetArgs([]string{})
+		h.AssertNil(t, command.Execute())
+
+		output := outBuf.String()
+		h.AssertContains(t, output, "NAME")
+		h.AssertContainsMatch(t, output, `pack-cache-some_app_latest-123456789abc.build\s+build\s+some/app:latest\s+2.0 kB\s+2 hours ago`)
+		h.AssertContainsMatch(t, output, `pack-cache-other_app_latest-123456789abc.launch\s+launch\s+<none>\s+-\s+-`)
+	})
+
+	it("reports when there are no caches", func() {
+		mockClient.EXPECT().ListCaches(gomock.Any()).Return(nil, nil)
+
+		command.SetArgs([]string{})
+		h.AssertNil(t, command.Execute())
+		h.AssertContains(t, outBuf.String(), "No caches found")
+	})
+
+	it("returns the client error", func() {
+		mockClient.EXPECT().ListCaches(gomock.Any()).Return(nil, errors.New("some-error"))
+
+		command.SetArgs([]string{})
+		h.AssertError(t, command.Execute(), "some-error")
+	})
+}
@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type CachePruneFlags struct {
+	OlderThan time.Duration
+	Unused    bool
+	All       bool
+	DryRun    bool
+}
+
+// CachePrune removes cache volumes created by pack
+func CachePrune(logger logging.Logger, pack PackClient) *cobra.Command {
+	var flags CachePruneFlags
+
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Args:  cobra.NoArgs,
+		Short: "Remove build caches",
+		Long: "Remove the cache volumes created by pack.\n\n" +
+			"When both --older-than and --unused are provided, only caches matching both are removed. " +
+			"Images built with --publish are never in the daemon, so their caches are unused too: " +
+			"--unused requires --older-than to keep the caches of recent builds.",
+		Example: "pack cache prune --older-than 168h --unused",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if flags.OlderThan < 0 {
+				return errors.Errorf("%s must not be negative", style.Symbol("--older-than"))
+			}
+			filtered := flags.OlderThan > 0 || flags.Unused
+			if !flags.All && !filtered {
+				return errors.New("one of --all, --older-than or --unused must be specified")
+			}
+			if flags.All && filtered {
+				return errors.New("--all cannot be combined with --older-than or --unused")
+			}
+			if flags.Unused && flags.OlderThan == 0 {
+				return errors.New("--unused requires --older-than, as the caches of published images are unused as well")
+			}
+
+			pruned, err := pack.PruneCaches(cmd.Context(), client.PruneCachesOptions{
+				OlderThan: flags.OlderThan,
+				Unused:    flags.Unused,
+				DryRun:    flags.DryRun,
+			})
+			for _, c := range pruned {
+				if flags.DryRun {
+					logger.Infof("Would remove cache %s", style.Symbol(c.Name))
+				} else {
+					logger.Infof("Removed cache %s", style.Symbol(c.Name))
+				}
+			}
+			if err != nil {
+				return err
+			}
+
+			if len(pruned) == 0 {
+				logger.Info("No caches to remove")
+			}
+			return nil
+		}),
+	}
+
+	cmd.Flags().DurationVar(&flags.OlderThan, "older-than", 0, "Remove caches created more than the given duration ago, e.g. '72h'")
+	cmd.Flags().BoolVar(&flags.Unused, "unused", false, "Remove caches whose image is not in the daemon, such as those of published images. Requires --older-than")
+	cmd.Flags().BoolVar(&flags.All, "all", false, "Remove all caches created by pack")
+	cmd.Flags().BoolVar(&flags.DryRun, "dry-run", false, "Show the caches that would be removed without removing them")
+	AddHelpFlag(cmd, "prune")
+	return cmd
+}
@@ -0,0 +1,87 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCachePruneCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "CachePruneCommand", testCachePruneCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testCachePruneCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		command = commands.CachePrune(logger, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	it("prunes caches matching the flags", func() {
+		mockClient.EXPECT().PruneCaches(gomock.Any(), client.PruneCachesOptions{OlderThan: 72 * time.Hour, Unused: true}).
+			Return([]client.CacheVolume{{Name: "some-volume"}}, nil)
+
+		command.SetArgs([]string{"--older-than", "72h", "--unused"})
+		h.AssertNil(t, command.Execute())
+		h.AssertContains(t, outBuf.String(), "Removed cache 'some-volume'")
+	})
+
+	it("prunes all caches", func() {
+		mockClient.EXPECT().PruneCaches(gomock.Any(), client.PruneCachesOptions{}).Return(nil, nil)
+
+		command.SetArgs([]string{"--all"})
+		h.AssertNil(t, command.Execute())
+		h.AssertContains(t, outBuf.String(), "No caches to remove")
+	})
+
+	it("reports the caches that would be removed on a dry run", func() {
+		mockClient.EXPECT().PruneCaches(gomock.Any(), client.PruneCachesOptions{OlderThan: 72 * time.Hour, Unused: true, DryRun: true}).
+			Return([]client.CacheVolume{{Name: "some-volume"}}, nil)
+
+		command.SetArgs([]string{"--older-than", "72h", "--unused", "--dry-run"})
+		h.AssertNil(t, command.Execute())
+		h.AssertContains(t, outBuf.String(), "Would remove cache 'some-volume'")
+	})
+
+	it("requires a criteria", func() {
+		command.SetArgs([]string{})
+		h.AssertError(t, command.Execute(), "one of --all, --older-than or --unused must be specified")
+	})
+
+	it("requires --older-than with --unused", func() {
+		command.SetArgs([]string{"--unused"})
+		h.AssertError(t, command.Execute(), "--unused requires --older-than, as the caches of published images are unused as well")
+	})
+
+	it("does not combine --all with other criteria", func() {
+		command.SetArgs([]string{"--all", "--unused"})
+		h.AssertError(t, command.Execute(), "--all cannot be combined with --older-than or --unused")
+	})
+}
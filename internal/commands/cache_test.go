@@ -0,0 +1,52 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCacheCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "CacheCommand", testCacheCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testCacheCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		cmd    *cobra.Command
+		logger logging.Logger
+		outBuf bytes.Buffer
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController := gomock.NewController(t)
+		mockClient := testmocks.NewMockPackClient(mockController)
+		cmd = commands.NewCacheCommand(logger, mockClient)
+		cmd.SetOut(logging.GetWriterForLevel(logger, logging.InfoLevel))
+	})
+
+	when("cache", func() {
+		it("prints help text", func() {
+			cmd.SetArgs([]string{})
+			h.AssertNil(t, cmd.Execute())
+			output := outBuf.String()
+			h.AssertContains(t, output, "Interact with build caches")
+			h.AssertContains(t, output, "Usage:")
+			for _, command := range []string{"list", "inspect", "prune", "export", "import"} {
+				h.AssertContains(t, output, command)
+			}
+		})
+	})
+}
@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	strs "github.com/buildpacks/pack/internal/strings"
+	"github.com/buildpacks/pack/internal/style"
+	cpkg "github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/sbom"
+)
+
+type CheckSBOMFlags struct {
+	Remote       bool
+	Policy       string
+	OutputFormat string
+}
+
+type checkSBOMOutput struct {
+	Image  string `json:"image"`
+	Policy string `json:"policy"`
+	Passed bool   `json:"passed"`
+	*sbom.Report
+}
+
+func CheckSBOM(
+	logger logging.Logger,
+	client PackClient,
+) *cobra.Command {
+	var flags CheckSBOMFlags
+	cmd := &cobra.Command{
+		Use:   "check <image-name> --policy <policy-file>",
+		Args:  cobra.ExactArgs(1),
+		Short: "Check the packages in the SBoM of specified image against a policy",
+		Long: `Check the packages in the SBoM of specified image against a policy, failing if any package is denied, has a denied license or is affected by an advisory.
+
+A policy is a TOML file such as:
+
+  advisories = "advisories.json"
+
+  [deny]
+  licenses = ["AGPL-3.0"]
+
+  [[deny.packages]]
+  name = "log4j-core"
+  versions = "< 2.17.0"
+  reason = "Log4Shell"
+
+where advisories optionally points to a local JSON database of entries with an id, package, versions, severity and summary.`,
+		Example: "pack sbom check buildpacksio/pack --policy policy.toml",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if flags.Policy == "" {
+				return errors.Errorf("%s flag is required", style.Symbol("--policy"))
+			}
+			if flags.OutputFormat != "table" && flags.OutputFormat != "json" {
+				return errors.Errorf("output format %s is not supported", style.Symbol(flags.OutputFormat))
+			}
+
+			report, err := client.CheckSBOM(args[0], cpkg.CheckSBOMOptions{Daemon: !flags.Remote, Policy: flags.Policy})
+			if err != nil {
+				return err
+			}
+
+			if flags.OutputFormat == "json" {
+				out, err := json.MarshalIndent(checkSBOMOutput{Image: args[0], Policy: flags.Policy, Passed: report.Passed(), Report: report}, "", "  ")
+				if err != nil {
+					return err
+				}
+				logger.Info(string(out))
+			} else {
+				writeViolations(logger, report)
+			}
+
+			if !report.Passed() {
+				return errors.Errorf("image %s violates policy %s: %d violation(s) found", style.Symbol(args[0]), style.Symbol(flags.Policy), len(report.Violations))
+			}
+			return nil
+		}),
+	}
+	AddHelpFlag(cmd, "check")
+	cmd.Flags().BoolVar(&flags.Remote, "remote", false, "Check the SBoM of image in remote registry (without pulling image)")
+	cmd.Flags().StringVar(&flags.Policy, "policy", "", "Path to the policy file")
+	cmd.Flags().StringVarP(&flags.OutputFormat, "output", "o", "table", "Output format to display the report (table, json)")
+	return cmd
+}
+
+func writeViolations(logger logging.Logger, report *sbom.Report) {
+	if report.Passed() {
+		logger.Infof("No violations found in %d packages", report.Packages)
+		return
+	}
+
+	tw := tabwriter.NewWriter(logger.Writer(), 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "RULE\tPACKAGE\tVERSION\tBUILDPACK\tDETAILS")
+	for _, violation := range report.Violations {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", violation.Rule, violation.Package.Name, strs.ValueOrDefault(violation.Package.Version, "-"), violation.Package.Buildpack, violation.Details)
+	}
+	tw.Flush()
+}
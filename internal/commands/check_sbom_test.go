@@ -0,0 +1,127 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	cpkg "github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/sbom"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCheckSBOMCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "CheckSBOMCommand", testCheckSBOMCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testCheckSBOMCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+		failed         = &sbom.Report{
+			Packages: 2,
+			Violations: []sbom.Violation{{
+				Rule:    sbom.RuleDeniedPackage,
+				Package: sbom.Package{Name: "log4j-core", Version: "2.14.1", Buildpack: "some-buildpack", Scope: "launch", Format: sbom.CycloneDX},
+				Details: "package is denied: Log4Shell",
+			}},
+		}
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		command = commands.CheckSBOM(logger, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#CheckSBOM", func() {
+		it("reports that the image complies with the policy", func() {
+			mockClient.EXPECT().CheckSBOM("some/image", cpkg.CheckSBOMOptions{Daemon: true, Policy: "policy.toml"}).Return(&sbom.Report{Packages: 3, Violations: []sbom.Violation{}}, nil)
+			command.SetArgs([]string{"some/image", "--policy", "policy.toml"})
+
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "No violations found in 3 packages")
+		})
+
+		it("lists the violations in a table and fails", func() {
+			mockClient.EXPECT().CheckSBOM("some/image", gomock.Any()).Return(failed, nil)
+			command.SetArgs([]string{"some/image", "--policy", "policy.toml"})
+
+			h.AssertError(t, command.Execute(), "image 'some/image' violates policy 'policy.toml': 1 violation(s) found")
+			h.AssertContains(t, outBuf.String(), "RULE             PACKAGE      VERSION   BUILDPACK        DETAILS")
+			h.AssertContains(t, outBuf.String(), "denied-package   log4j-core   2.14.1    some-buildpack   package is denied: Log4Shell")
+		})
+
+		when("the remote flag is specified", func() {
+			it("respects the remote flag", func() {
+				mockClient.EXPECT().CheckSBOM("some/image", cpkg.CheckSBOMOptions{Daemon: false, Policy: "policy.toml"}).Return(&sbom.Report{}, nil)
+				command.SetArgs([]string{"some/image", "--policy", "policy.toml", "--remote"})
+
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
+		when("the output format is json", func() {
+			it("prints the report as JSON", func() {
+				mockClient.EXPECT().CheckSBOM("some/image", gomock.Any()).Return(failed, nil)
+				command.SetArgs([]string{"some/image", "--policy", "policy.toml", "--output", "json"})
+
+				h.AssertNotNil(t, command.Execute())
+				h.AssertContains(t, outBuf.String(), `{
+  "image": "some/image",
+  "policy": "policy.toml",
+  "passed": false,
+  "packages": 2,
+  "violations": [
+    {
+      "rule": "denied-package",
+      "package": {
+        "name": "log4j-core",`)
+			})
+		})
+
+		when("the policy flag is missing", func() {
+			it("returns an error", func() {
+				command.SetArgs([]string{"some/image"})
+
+				h.AssertError(t, command.Execute(), "'--policy' flag is required")
+			})
+		})
+
+		when("the output format is not supported", func() {
+			it("returns an error", func() {
+				command.SetArgs([]string{"some/image", "--policy", "policy.toml", "--output", "yaml"})
+
+				h.AssertError(t, command.Execute(), "output format 'yaml' is not supported")
+			})
+		})
+
+		when("the check fails", func() {
+			it("returns the error", func() {
+				mockClient.EXPECT().CheckSBOM("some/image", gomock.Any()).Return(nil, errors.New("some-error"))
+				command.SetArgs([]string{"some/image", "--policy", "policy.toml"})
+
+				h.AssertError(t, command.Execute(), "some-error")
+			})
+		})
+	})
+}
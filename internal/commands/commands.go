@@ -14,12 +14,14 @@ import (
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/client"
 	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/sbom"
 )
 
 //go:generate mockgen -package testmocks -destination testmocks/mock_pack_client.go github.com/buildpacks/pack/internal/commands PackClient
 type PackClient interface {
 	InspectBuilder(string, bool, ...client.BuilderInspectionModifier) (*client.BuilderInfo, error)
 	InspectImage(string, bool) (*client.ImageInfo, error)
+	DiffImages(ctx context.Context, oldName, newName string) (*client.ImageDiff, error)
 	Rebase(context.Context, client.RebaseOptions) error
 	CreateBuilder(context.Context, client.CreateBuilderOptions) error
 	NewBuildpack(context.Context, client.NewBuildpackOptions) error
@@ -31,7 +33,24 @@ type PackClient interface {
 	InspectBuildpack(client.InspectBuildpackOptions) (*client.BuildpackInfo, error)
 	InspectExtension(client.InspectExtensionOptions) (*client.ExtensionInfo, error)
 	PullBuildpack(context.Context, client.PullBuildpackOptions) error
+	SearchBuildpacks(client.SearchBuildpacksOptions) ([]client.RegistryBuildpack, error)
+	BuildpackVersions(client.BuildpackVersionsOptions) ([]client.RegistryBuildpack, error)
 	DownloadSBOM(name string, options client.DownloadSBOMOptions) error
+	ListSBOM(name string, options client.ListSBOMOptions) ([]sbom.Package, error)
+	DiffSBOM(oldName, newName string, options client.DiffSBOMOptions) (*client.PackageDiff, error)
+	CheckSBOM(name string, options client.CheckSBOMOptions) (*sbom.Report, error)
+	CreateManifest(ctx context.Context, opts client.CreateManifestOptions) error
+	AddManifest(ctx context.Context, opts client.AddManifestOptions) error
+	AnnotateManifest(ctx context.Context, opts client.AnnotateManifestOptions) error
+	RemoveManifest(ctx context.Context, opts client.RemoveManifestOptions) error
+	DeleteManifest(indexRepoNames []string) error
+	InspectManifest(ctx context.Context, indexRepoName string) error
+	PushManifest(ctx context.Context, opts client.PushManifestOptions) error
+	ListCaches(ctx context.Context) ([]client.CacheVolume, error)
+	InspectCache(ctx context.Context, opts client.InspectCacheOptions) ([]client.CacheVolume, error)
+	PruneCaches(ctx context.Context, opts client.PruneCachesOptions) ([]client.CacheVolume, error)
+	ExportCache(ctx context.Context, opts client.ExportCacheOptions) error
+	ImportCache(ctx context.Context, opts client.ImportCacheOptions) error
 }
 
 func AddHelpFlag(cmd *cobra.Command, commandName string) {
@@ -104,6 +123,15 @@ func isTrustedBuilder(cfg config.Config, builder string) bool {
 	return isSuggestedBuilder(builder)
 }
 
+// signOptions returns the options to sign images with the key at keyPath, if any. Like cosign, the password of an
+// encrypted key is read from the COSIGN_PASSWORD environment variable.
+func signOptions(keyPath string) *client.SignOptions {
+	if keyPath == "" {
+		return nil
+	}
+	return &client.SignOptions{KeyPath: keyPath, KeyPassword: []byte(os.Getenv("COSIGN_PASSWORD"))}
+}
+
 func deprecationWarning(logger logging.Logger, oldCmd, replacementCmd string) {
 	logger.Warnf("Command %s has been deprecated, please use %s instead", style.Symbol("pack "+oldCmd), style.Symbol("pack "+replacementCmd))
 }
@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/style"
+	cpkg "github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type DiffSBOMFlags struct {
+	Remote       bool
+	OutputFormat string
+}
+
+func DiffSBOM(
+	logger logging.Logger,
+	client PackClient,
+) *cobra.Command {
+	var flags DiffSBOMFlags
+	cmd := &cobra.Command{
+		Use:     "diff <old-image-name> <new-image-name>",
+		Args:    cobra.ExactArgs(2),
+		Short:   "Show the package differences between the SBoMs of two images",
+		Long:    "Show the packages added, removed or whose version changed between the SBoMs of two images, as listed by 'pack sbom list'",
+		Example: "pack sbom diff my-app:v1 my-app:v2",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if flags.OutputFormat != "human-readable" && flags.OutputFormat != "json" {
+				return errors.Errorf("output format %s is not supported", style.Symbol(flags.OutputFormat))
+			}
+
+			diff, err := client.DiffSBOM(args[0], args[1], cpkg.DiffSBOMOptions{Daemon: !flags.Remote})
+			if err != nil {
+				return err
+			}
+
+			if flags.OutputFormat == "json" {
+				out, err := json.MarshalIndent(diff, "", "  ")
+				if err != nil {
+					return err
+				}
+				logger.Info(string(out))
+				return nil
+			}
+
+			if diff.Empty() {
+				logger.Info("No differences found")
+				return nil
+			}
+			for _, pkg := range diff.Added {
+				logger.Infof("+ %s", packageName(pkg))
+			}
+			for _, pkg := range diff.Removed {
+				logger.Infof("- %s", packageName(pkg))
+			}
+			for _, change := range diff.Changed {
+				logger.Infof("~ %s: %s -> %s", change.Name, change.OldVersion, change.NewVersion)
+			}
+			return nil
+		}),
+	}
+	AddHelpFlag(cmd, "diff")
+	cmd.Flags().BoolVar(&flags.Remote, "remote", false, "Compare the SBoMs of images in remote registry (without pulling images)")
+	cmd.Flags().StringVarP(&flags.OutputFormat, "output", "o", "human-readable", "Output format to display the differences (human-readable, json)")
+	return cmd
+}
+
+func packageName(pkg cpkg.Package) string {
+	if pkg.Version == "" {
+		return pkg.Name
+	}
+	return pkg.Name + "@" + pkg.Version
+}
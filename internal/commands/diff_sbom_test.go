@@ -0,0 +1,105 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	cpkg "github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestDiffSBOMCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "DiffSBOMCommand", testDiffSBOMCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testDiffSBOMCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+		diff           = &cpkg.PackageDiff{
+			Added:   []cpkg.Package{{Name: "curl", Version: "8.0.0"}},
+			Removed: []cpkg.Package{{Name: "zlib"}},
+			Changed: []cpkg.PackageChange{{Name: "openssl", OldVersion: "3.0.1", NewVersion: "3.0.2"}},
+		}
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		command = commands.DiffSBOM(logger, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#DiffSBOM", func() {
+		it("prints the package differences", func() {
+			mockClient.EXPECT().DiffSBOM("some/app:v1", "some/app:v2", cpkg.DiffSBOMOptions{Daemon: true}).Return(diff, nil)
+			command.SetArgs([]string{"some/app:v1", "some/app:v2"})
+
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "+ curl@8.0.0\n- zlib\n~ openssl: 3.0.1 -> 3.0.2\n")
+		})
+
+		when("the remote flag is specified", func() {
+			it("respects the remote flag", func() {
+				mockClient.EXPECT().DiffSBOM("some/app:v1", "some/app:v2", cpkg.DiffSBOMOptions{Daemon: false}).Return(diff, nil)
+				command.SetArgs([]string{"some/app:v1", "some/app:v2", "--remote"})
+
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
+		when("there are no differences", func() {
+			it("says so", func() {
+				mockClient.EXPECT().DiffSBOM("some/app:v1", "some/app:v2", gomock.Any()).Return(&cpkg.PackageDiff{}, nil)
+				command.SetArgs([]string{"some/app:v1", "some/app:v2"})
+
+				h.AssertNil(t, command.Execute())
+				h.AssertContains(t, outBuf.String(), "No differences found")
+			})
+		})
+
+		when("the output format is json", func() {
+			it("prints the differences as JSON", func() {
+				mockClient.EXPECT().DiffSBOM("some/app:v1", "some/app:v2", gomock.Any()).Return(diff, nil)
+				command.SetArgs([]string{"some/app:v1", "some/app:v2", "-o", "json"})
+
+				h.AssertNil(t, command.Execute())
+				h.AssertContains(t, outBuf.String(), `"changed": [
+    {
+      "name": "openssl",
+      "old_version": "3.0.1",
+      "new_version": "3.0.2"
+    }
+  ]`)
+			})
+		})
+
+		when("the client returns an error", func() {
+			it("returns the error", func() {
+				mockClient.EXPECT().DiffSBOM("some/app:v1", "some/app:v2", gomock.Any()).Return(nil, errors.New("some-error"))
+				command.SetArgs([]string{"some/app:v1", "some/app:v2"})
+
+				h.AssertError(t, command.Execute(), "some-error")
+			})
+		})
+	})
+}
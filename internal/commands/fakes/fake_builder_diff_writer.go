@@ -0,0 +1,39 @@
+package fakes
+
+import (
+	"github.com/buildpacks/pack/internal/builder/writer"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type FakeBuilderDiffWriter struct {
+	PrintForDiff  string
+	ErrorForPrint error
+
+	ReceivedOldName string
+	ReceivedNewName string
+	ReceivedDiff    *client.BuilderDiff
+}
+
+func (w *FakeBuilderDiffWriter) PrintDiff(logger logging.Logger, oldName, newName string, diff *client.BuilderDiff) error {
+	w.ReceivedOldName = oldName
+	w.ReceivedNewName = newName
+	w.ReceivedDiff = diff
+
+	logger.Infof("\nDIFF:\n%s\n", w.PrintForDiff)
+
+	return w.ErrorForPrint
+}
+
+type FakeBuilderDiffWriterFactory struct {
+	ReturnForWriter writer.BuilderDiffWriter
+	ErrorForWriter  error
+
+	ReceivedForKind string
+}
+
+func (f *FakeBuilderDiffWriterFactory) DiffWriter(kind string) (writer.BuilderDiffWriter, error) {
+	f.ReceivedForKind = kind
+
+	return f.ReturnForWriter, f.ErrorForWriter
+}
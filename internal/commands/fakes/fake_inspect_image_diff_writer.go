@@ -0,0 +1,25 @@
+package fakes
+
+import (
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type FakeInspectImageDiffWriter struct {
+	PrintForDiff  string
+	ErrorForPrint error
+
+	ReceivedOldName string
+	ReceivedNewName string
+	ReceivedDiff    *client.ImageDiff
+}
+
+func (w *FakeInspectImageDiffWriter) PrintDiff(logger logging.Logger, oldName, newName string, diff *client.ImageDiff) error {
+	w.ReceivedOldName = oldName
+	w.ReceivedNewName = newName
+	w.ReceivedDiff = diff
+
+	logger.Infof("\nDIFF:\n%s\n", w.PrintForDiff)
+
+	return w.ErrorForPrint
+}
@@ -5,8 +5,9 @@ import (
 )
 
 type FakeInspectImageWriterFactory struct {
-	ReturnForWriter writer.InspectImageWriter
-	ErrorForWriter  error
+	ReturnForWriter     writer.InspectImageWriter
+	ReturnForDiffWriter writer.InspectImageDiffWriter
+	ErrorForWriter      error
 
 	ReceivedForKind string
 	ReceivedForBOM  bool
@@ -18,3 +19,9 @@ func (f *FakeInspectImageWriterFactory) Writer(kind string, bom bool) (writer.In
 
 	return f.ReturnForWriter, f.ErrorForWriter
 }
+
+func (f *FakeInspectImageWriterFactory) DiffWriter(kind string) (writer.InspectImageDiffWriter, error) {
+	f.ReceivedForKind = kind
+
+	return f.ReturnForDiffWriter, f.ErrorForWriter
+}
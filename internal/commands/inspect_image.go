@@ -1,6 +1,9 @@
 package commands
 
 import (
+	"context"
+
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"github.com/buildpacks/pack/internal/inspectimage"
@@ -14,11 +17,13 @@ import (
 //go:generate mockgen -package testmocks -destination testmocks/mock_inspect_image_writer_factory.go github.com/buildpacks/pack/internal/commands InspectImageWriterFactory
 type InspectImageWriterFactory interface {
 	Writer(kind string, BOM bool) (writer.InspectImageWriter, error)
+	DiffWriter(kind string) (writer.InspectImageDiffWriter, error)
 }
 
 type InspectImageFlags struct {
 	BOM          bool
 	OutputFormat string
+	Diff         string
 }
 
 func InspectImage(
@@ -37,6 +42,13 @@ func InspectImage(
 		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
 			img := args[0]
 
+			if flags.Diff != "" {
+				if flags.BOM {
+					return errors.New("bom and diff flags cannot be used together")
+				}
+				return diffImages(cmd.Context(), logger, img, flags, writerFactory, client)
+			}
+
 			sharedImageInfo := inspectimage.GeneralInfo{
 				Name:            img,
 				RunImageMirrors: cfg.RunImages,
@@ -62,6 +74,21 @@ func InspectImage(
 	}
 	AddHelpFlag(cmd, "inspect")
 	cmd.Flags().BoolVar(&flags.BOM, "bom", false, "print bill of materials")
+	cmd.Flags().StringVar(&flags.Diff, "diff", "", "Show the differences between the image and the provided image, instead of the image details.\nImages are looked up in the daemon, then in the registry. Prefix a name with 'oci:' to use an image in an OCI layout.")
 	cmd.Flags().StringVarP(&flags.OutputFormat, "output", "o", "human-readable", "Output format to display builder detail (json, yaml, toml, human-readable).\nOmission of this flag will display as human-readable.")
 	return cmd
 }
+
+func diffImages(ctx context.Context, logger logging.Logger, img string, flags InspectImageFlags, writerFactory InspectImageWriterFactory, client PackClient) error {
+	w, err := writerFactory.DiffWriter(flags.OutputFormat)
+	if err != nil {
+		return err
+	}
+
+	diff, err := client.DiffImages(ctx, img, flags.Diff)
+	if err != nil {
+		return err
+	}
+
+	return w.PrintDiff(logger, img, flags.Diff, diff)
+}
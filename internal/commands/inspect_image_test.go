@@ -272,6 +272,53 @@ func testInspectImageCommand(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 		})
+
+		when("--diff", func() {
+			var (
+				diffWriter    *fakes.FakeInspectImageDiffWriter
+				writerFactory *fakes.FakeInspectImageWriterFactory
+				expectedDiff  = &client.ImageDiff{
+					Buildpacks: client.ModuleDiff{Changed: []client.ModuleChange{{ID: "some/buildpack", OldVersion: "1.0.0", NewVersion: "1.1.0"}}},
+				}
+			)
+
+			it.Before(func() {
+				diffWriter = &fakes.FakeInspectImageDiffWriter{PrintForDiff: "Sample diff"}
+				writerFactory = &fakes.FakeInspectImageWriterFactory{ReturnForDiffWriter: diffWriter}
+			})
+
+			it("prints the differences between the images", func() {
+				mockClient.EXPECT().DiffImages(gomock.Any(), "some/image:v1", "some/image:v2").Return(expectedDiff, nil)
+
+				command := commands.InspectImage(logger, writerFactory, cfg, mockClient)
+				command.SetArgs([]string{"some/image:v1", "--diff", "some/image:v2", "--output", "json"})
+				assert.Nil(command.Execute())
+
+				assert.Equal(writerFactory.ReceivedForKind, "json")
+				assert.Equal(diffWriter.ReceivedOldName, "some/image:v1")
+				assert.Equal(diffWriter.ReceivedNewName, "some/image:v2")
+				assert.Equal(diffWriter.ReceivedDiff, expectedDiff)
+				assert.Contains(outBuf.String(), "DIFF:\nSample diff")
+			})
+
+			when("the images cannot be compared", func() {
+				it("returns the error", func() {
+					mockClient.EXPECT().DiffImages(gomock.Any(), "some/image:v1", "some/image:v2").Return(nil, errors.New("some diff error"))
+
+					command := commands.InspectImage(logger, writerFactory, cfg, mockClient)
+					command.SetArgs([]string{"some/image:v1", "--diff", "some/image:v2"})
+					assert.ErrorWithMessage(command.Execute(), "some diff error")
+				})
+			})
+
+			when("--bom is provided", func() {
+				it("returns an error", func() {
+					command := commands.InspectImage(logger, writerFactory, cfg, mockClient)
+					command.SetArgs([]string{"some/image:v1", "--diff", "some/image:v2", "--bom"})
+					assert.ErrorWithMessage(command.Execute(), "bom and diff flags cannot be used together")
+				})
+			})
+		})
 	})
 }
 
@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	strs "github.com/buildpacks/pack/internal/strings"
+	"github.com/buildpacks/pack/internal/style"
+	cpkg "github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/sbom"
+)
+
+type ListSBOMFlags struct {
+	Remote       bool
+	OutputFormat string
+}
+
+func ListSBOM(
+	logger logging.Logger,
+	client PackClient,
+) *cobra.Command {
+	var flags ListSBOMFlags
+	cmd := &cobra.Command{
+		Use:     "list <image-name>",
+		Args:    cobra.ExactArgs(1),
+		Short:   "List the packages in the SBoM of specified image",
+		Long:    "List the packages, with their versions and licenses, found in the CycloneDX, SPDX and Syft SBoM files contributed by buildpacks to specified image",
+		Example: "pack sbom list buildpacksio/pack",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if flags.OutputFormat != "table" && flags.OutputFormat != "json" {
+				return errors.Errorf("output format %s is not supported", style.Symbol(flags.OutputFormat))
+			}
+
+			packages, err := client.ListSBOM(args[0], cpkg.ListSBOMOptions{Daemon: !flags.Remote})
+			if err != nil {
+				return err
+			}
+
+			if flags.OutputFormat == "json" {
+				if packages == nil {
+					packages = []sbom.Package{}
+				}
+				out, err := json.MarshalIndent(packages, "", "  ")
+				if err != nil {
+					return err
+				}
+				logger.Info(string(out))
+				return nil
+			}
+
+			writePackages(logger, packages)
+			return nil
+		}),
+	}
+	AddHelpFlag(cmd, "list")
+	cmd.Flags().BoolVar(&flags.Remote, "remote", false, "List the SBoM of image in remote registry (without pulling image)")
+	cmd.Flags().StringVarP(&flags.OutputFormat, "output", "o", "table", "Output format to display packages (table, json)")
+	return cmd
+}
+
+func writePackages(logger logging.Logger, packages []sbom.Package) {
+	tw := tabwriter.NewWriter(logger.Writer(), 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tVERSION\tLICENSES\tBUILDPACK\tSCOPE")
+	for _, pkg := range packages {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", pkg.Name, strs.ValueOrDefault(pkg.Version, "-"), strs.ValueOrDefault(strings.Join(pkg.Licenses, ", "), "-"), pkg.Buildpack, pkg.Scope)
+	}
+	tw.Flush()
+}
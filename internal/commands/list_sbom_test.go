@@ -0,0 +1,120 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	cpkg "github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/sbom"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestListSBOMCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ListSBOMCommand", testListSBOMCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testListSBOMCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+		packages       = []sbom.Package{
+			{Name: "openssl", Version: "3.0.2", Licenses: []string{"Apache-2.0"}, Buildpack: "some-buildpack", Scope: "launch", Format: sbom.SPDX},
+			{Name: "some-tool", Buildpack: "other-buildpack", Scope: "build", Format: sbom.Syft},
+		}
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		command = commands.ListSBOM(logger, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#ListSBOM", func() {
+		it("lists the packages in a table", func() {
+			mockClient.EXPECT().ListSBOM("some/image", cpkg.ListSBOMOptions{Daemon: true}).Return(packages, nil)
+			command.SetArgs([]string{"some/image"})
+
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "NAME        VERSION   LICENSES     BUILDPACK         SCOPE")
+			h.AssertContains(t, outBuf.String(), "openssl     3.0.2     Apache-2.0   some-buildpack    launch")
+			h.AssertContains(t, outBuf.String(), "some-tool   -         -            other-buildpack   build")
+		})
+
+		when("the remote flag is specified", func() {
+			it("respects the remote flag", func() {
+				mockClient.EXPECT().ListSBOM("some/image", cpkg.ListSBOMOptions{Daemon: false}).Return(packages, nil)
+				command.SetArgs([]string{"some/image", "--remote"})
+
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
+		when("the output format is json", func() {
+			it("lists the packages as JSON", func() {
+				mockClient.EXPECT().ListSBOM("some/image", gomock.Any()).Return(packages[:1], nil)
+				command.SetArgs([]string{"some/image", "--output", "json"})
+
+				h.AssertNil(t, command.Execute())
+				h.AssertContains(t, outBuf.String(), `[
+  {
+    "name": "openssl",
+    "version": "3.0.2",
+    "licenses": [
+      "Apache-2.0"
+    ],
+    "buildpack": "some-buildpack",
+    "scope": "launch",
+    "format": "spdx"
+  }
+]`)
+			})
+
+			when("there are no packages", func() {
+				it("prints an empty list", func() {
+					mockClient.EXPECT().ListSBOM("some/image", gomock.Any()).Return(nil, nil)
+					command.SetArgs([]string{"some/image", "-o", "json"})
+
+					h.AssertNil(t, command.Execute())
+					h.AssertContains(t, outBuf.String(), "[]")
+				})
+			})
+		})
+
+		when("the output format is not supported", func() {
+			it("returns an error", func() {
+				command.SetArgs([]string{"some/image", "--output", "yaml"})
+
+				h.AssertError(t, command.Execute(), "output format 'yaml' is not supported")
+			})
+		})
+
+		when("the client returns an error", func() {
+			it("returns the error", func() {
+				mockClient.EXPECT().ListSBOM("some/image", gomock.Any()).Return(nil, errors.New("some-error"))
+				command.SetArgs([]string{"some/image"})
+
+				h.AssertError(t, command.Execute(), "some-error")
+			})
+		})
+	})
+}
@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// ManifestAddFlags define flags provided to the ManifestAdd
+type ManifestAddFlags struct {
+	OS, Arch, Variant string
+}
+
+// ManifestAdd adds the manifest(s) of an image or image index to a local image index
+func ManifestAdd(logger logging.Logger, pack PackClient) *cobra.Command {
+	var flags ManifestAddFlags
+
+	cmd := &cobra.Command{
+		Use:     "add [OPTIONS] <manifest-list> <manifest> [flags]",
+		Args:    cobra.MatchAll(cobra.ExactArgs(2), cobra.OnlyValidArgs),
+		Short:   "Add an image to a manifest list.",
+		Example: `pack manifest add my-image-index my-image:some-arch`,
+		Long: `Add the manifest of an image to a local manifest list (or image index).
+When the given image is itself an image index, all of its manifests, or only those matching '--os', '--arch' and '--variant', are added.`,
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			return pack.AddManifest(cmd.Context(), client.AddManifestOptions{
+				IndexRepoName: args[0],
+				RepoName:      args[1],
+				OS:            flags.OS,
+				Arch:          flags.Arch,
+				Variant:       flags.Variant,
+			})
+		}),
+	}
+
+	cmd.Flags().StringVar(&flags.OS, "os", "", "Only add manifests of an image index with this operating system")
+	cmd.Flags().StringVar(&flags.Arch, "arch", "", "Only add manifests of an image index with this architecture")
+	cmd.Flags().StringVar(&flags.Variant, "variant", "", "Only add manifests of an image index with this architecture variant")
+
+	AddHelpFlag(cmd, "add")
+	return cmd
+}
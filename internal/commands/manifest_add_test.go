@@ -0,0 +1,73 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestManifestAddCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ManifestAddCommand", testManifestAddCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testManifestAddCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		command = commands.ManifestAdd(logger, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	it("adds the manifest", func() {
+		mockClient.EXPECT().AddManifest(gomock.Any(), client.AddManifestOptions{
+			IndexRepoName: "some-index",
+			RepoName:      "some-image:arm64",
+		}).Return(nil)
+
+		command.SetArgs([]string{"some-index", "some-image:arm64"})
+		h.AssertNil(t, command.Execute())
+	})
+
+	it("passes the platform filter", func() {
+		mockClient.EXPECT().AddManifest(gomock.Any(), client.AddManifestOptions{
+			IndexRepoName: "some-index",
+			RepoName:      "some-other-index",
+			OS:            "linux",
+			Arch:          "arm",
+			Variant:       "v7",
+		}).Return(nil)
+
+		command.SetArgs([]string{"some-index", "some-other-index", "--os", "linux", "--arch", "arm", "--variant", "v7"})
+		h.AssertNil(t, command.Execute())
+	})
+
+	it("requires exactly two args", func() {
+		command.SetArgs([]string{"some-index"})
+		h.AssertError(t, command.Execute(), "accepts 2 arg(s)")
+	})
+}
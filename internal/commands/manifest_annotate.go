@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// ManifestAnnotateFlags define flags provided to the ManifestAnnotate
+type ManifestAnnotateFlags struct {
+	OS, OSVersion, Arch, Variant string
+	Features                     []string
+	Annotations                  map[string]string
+}
+
+// ManifestAnnotate modifies a manifest descriptor of a local image index
+func ManifestAnnotate(logger logging.Logger, pack PackClient) *cobra.Command {
+	var flags ManifestAnnotateFlags
+
+	cmd := &cobra.Command{
+		Use:     "annotate [OPTIONS] <manifest-list> <manifest> [flags]",
+		Args:    cobra.MatchAll(cobra.ExactArgs(2), cobra.OnlyValidArgs),
+		Short:   "Add or update information about an entry in a manifest list.",
+		Example: `pack manifest annotate my-image-index my-image:some-arch --arch some-other-arch`,
+		Long:    `Set the platform and annotations of a manifest in a local manifest list (or image index).`,
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if err := validateManifestAnnotateFlags(flags); err != nil {
+				return err
+			}
+
+			return pack.AnnotateManifest(cmd.Context(), client.AnnotateManifestOptions{
+				IndexRepoName: args[0],
+				RepoName:      args[1],
+				OS:            flags.OS,
+				OSVersion:     flags.OSVersion,
+				Arch:          flags.Arch,
+				Variant:       flags.Variant,
+				Features:      flags.Features,
+				Annotations:   flags.Annotations,
+			})
+		}),
+	}
+
+	cmd.Flags().StringVar(&flags.OS, "os", "", "Set the operating system")
+	cmd.Flags().StringVar(&flags.OSVersion, "os-version", "", "Set the operating system version")
+	cmd.Flags().StringVar(&flags.Arch, "arch", "", "Set the architecture")
+	cmd.Flags().StringVar(&flags.Variant, "variant", "", "Set the architecture variant")
+	cmd.Flags().StringSliceVar(&flags.Features, "features", nil, "Set the platform features"+stringSliceHelp("feature"))
+	cmd.Flags().StringToStringVar(&flags.Annotations, "annotations", nil, "Set an annotation, in the form of '<key>=<value>'")
+
+	AddHelpFlag(cmd, "annotate")
+	return cmd
+}
+
+func validateManifestAnnotateFlags(flags ManifestAnnotateFlags) error {
+	if flags.OS == "" && flags.OSVersion == "" && flags.Arch == "" && flags.Variant == "" &&
+		len(flags.Features) == 0 && len(flags.Annotations) == 0 {
+		return errors.New("one of --os, --os-version, --arch, --variant, --features or --annotations must be specified")
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestManifestAnnotateCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ManifestAnnotateCommand", testManifestAnnotateCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testManifestAnnotateCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		command = commands.ManifestAnnotate(logger, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	it("annotates the manifest", func() {
+		mockClient.EXPECT().AnnotateManifest(gomock.Any(), client.AnnotateManifestOptions{
+			IndexRepoName: "some-index",
+			RepoName:      "some-image@sha256:abc",
+			OS:            "linux",
+			Arch:          "arm64",
+			Variant:       "v8",
+			Features:      []string{"some-feature"},
+			Annotations:   map[string]string{"some-key": "some-value"},
+		}).Return(nil)
+
+		command.SetArgs([]string{
+			"some-index", "some-image@sha256:abc",
+			"--os", "linux", "--arch", "arm64", "--variant", "v8",
+			"--features", "some-feature", "--annotations", "some-key=some-value",
+		})
+		h.AssertNil(t, command.Execute())
+	})
+
+	it("errors when nothing is annotated", func() {
+		command.SetArgs([]string{"some-index", "some-image@sha256:abc"})
+		h.AssertError(t, command.Execute(), "one of --os, --os-version, --arch, --variant, --features or --annotations must be specified")
+	})
+}
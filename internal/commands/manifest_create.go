@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// ManifestCreateFlags define flags provided to the ManifestCreate
+type ManifestCreateFlags struct {
+	Format            string
+	Insecure, Publish bool
+}
+
+// ManifestCreate creates an image index in local storage
+func ManifestCreate(logger logging.Logger, pack PackClient) *cobra.Command {
+	var flags ManifestCreateFlags
+
+	cmd := &cobra.Command{
+		Use:     "create <manifest-list> <manifest> [<manifest> ... ] [flags]",
+		Args:    cobra.MatchAll(cobra.MinimumNArgs(2), cobra.OnlyValidArgs),
+		Short:   "Create a manifest list.",
+		Example: `pack manifest create my-image-index my-image:some-arch my-image:some-other-arch`,
+		Long:    `Create a manifest list (or image index) in local storage from the manifests of the given images or image indexes.`,
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			return pack.CreateManifest(cmd.Context(), client.CreateManifestOptions{
+				IndexRepoName: args[0],
+				RepoNames:     args[1:],
+				Format:        flags.Format,
+				Insecure:      flags.Insecure,
+				Publish:       flags.Publish,
+			})
+		}),
+	}
+
+	cmd.Flags().StringVarP(&flags.Format, "format", "f", "oci", "Media type to use when saving the image index. Accepted values are: oci, docker")
+	cmd.Flags().BoolVar(&flags.Insecure, "insecure", false, "Allow publishing to, and fetching manifests from, insecure registries")
+	cmd.Flags().BoolVar(&flags.Publish, "publish", false, "Publish the image index to its registry once created")
+
+	AddHelpFlag(cmd, "create")
+	return cmd
+}
@@ -0,0 +1,86 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestManifestCreateCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ManifestCreateCommand", testManifestCreateCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testManifestCreateCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		command = commands.ManifestCreate(logger, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("args are valid", func() {
+		it("creates an oci index by default", func() {
+			mockClient.EXPECT().CreateManifest(gomock.Any(), client.CreateManifestOptions{
+				IndexRepoName: "some-index",
+				RepoNames:     []string{"some-image:amd64", "some-image:arm64"},
+				Format:        "oci",
+			}).Return(nil)
+
+			command.SetArgs([]string{"some-index", "some-image:amd64", "some-image:arm64"})
+			h.AssertNil(t, command.Execute())
+		})
+
+		it("passes format, insecure and publish flags", func() {
+			mockClient.EXPECT().CreateManifest(gomock.Any(), client.CreateManifestOptions{
+				IndexRepoName: "some-index",
+				RepoNames:     []string{"some-image:amd64"},
+				Format:        "docker",
+				Insecure:      true,
+				Publish:       true,
+			}).Return(nil)
+
+			command.SetArgs([]string{"some-index", "some-image:amd64", "--format", "docker", "--insecure", "--publish"})
+			h.AssertNil(t, command.Execute())
+		})
+
+		it("returns the client error", func() {
+			mockClient.EXPECT().CreateManifest(gomock.Any(), gomock.Any()).Return(errors.New("some-error"))
+
+			command.SetArgs([]string{"some-index", "some-image:amd64"})
+			h.AssertError(t, command.Execute(), "some-error")
+		})
+	})
+
+	when("no manifests are provided", func() {
+		it("errors", func() {
+			command.SetArgs([]string{"some-index"})
+			h.AssertError(t, command.Execute(), "requires at least 2 arg(s)")
+		})
+	})
+}
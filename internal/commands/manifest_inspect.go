@@ -0,0 +1,24 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// ManifestInspect shows the manifest of an image index
+func ManifestInspect(logger logging.Logger, pack PackClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "inspect <manifest-list>",
+		Args:    cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		Short:   "Display a manifest list or image index.",
+		Example: `pack manifest inspect my-image-index`,
+		Long:    `Display the manifest of a manifest list (or image index) from local storage or, if it is not found locally, from its registry.`,
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			return pack.InspectManifest(cmd.Context(), args[0])
+		}),
+	}
+
+	AddHelpFlag(cmd, "inspect")
+	return cmd
+}
@@ -0,0 +1,59 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestManifestInspectCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ManifestInspectCommand", testManifestInspectCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testManifestInspectCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		command = commands.ManifestInspect(logger, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	it("inspects the index", func() {
+		mockClient.EXPECT().InspectManifest(gomock.Any(), "some-index").Return(nil)
+
+		command.SetArgs([]string{"some-index"})
+		h.AssertNil(t, command.Execute())
+	})
+
+	it("returns the client error", func() {
+		mockClient.EXPECT().InspectManifest(gomock.Any(), "some-index").Return(errors.New("some-error"))
+
+		command.SetArgs([]string{"some-index"})
+		h.AssertError(t, command.Execute(), "some-error")
+	})
+}
@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// ManifestPushFlags define flags provided to the ManifestPush
+type ManifestPushFlags struct {
+	Format          string
+	Insecure, Purge bool
+}
+
+// ManifestPush pushes a local image index to its registry
+func ManifestPush(logger logging.Logger, pack PackClient) *cobra.Command {
+	var flags ManifestPushFlags
+
+	cmd := &cobra.Command{
+		Use:     "push [OPTIONS] <manifest-list> [flags]",
+		Args:    cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		Short:   "Push a manifest list to a registry.",
+		Example: `pack manifest push my-image-index`,
+		Long: `Push a local manifest list (or image index) to its registry.
+Manifests that are not yet present in the target repository are copied along with it.`,
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			return pack.PushManifest(cmd.Context(), client.PushManifestOptions{
+				IndexRepoName: args[0],
+				Format:        flags.Format,
+				Insecure:      flags.Insecure,
+				Purge:         flags.Purge,
+			})
+		}),
+	}
+
+	cmd.Flags().StringVarP(&flags.Format, "format", "f", "", "Media type to push the image index as. Accepted values are: oci, docker (defaults to the format used at creation)")
+	cmd.Flags().BoolVar(&flags.Insecure, "insecure", false, "Allow publishing to an insecure registry")
+	cmd.Flags().BoolVar(&flags.Purge, "purge", false, "Delete the manifest list from local storage if pushing succeeds")
+
+	AddHelpFlag(cmd, "push")
+	return cmd
+}
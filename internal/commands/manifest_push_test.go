@@ -0,0 +1,66 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestManifestPushCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ManifestPushCommand", testManifestPushCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testManifestPushCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		command = commands.ManifestPush(logger, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	it("pushes the index", func() {
+		mockClient.EXPECT().PushManifest(gomock.Any(), client.PushManifestOptions{
+			IndexRepoName: "some-index",
+		}).Return(nil)
+
+		command.SetArgs([]string{"some-index"})
+		h.AssertNil(t, command.Execute())
+	})
+
+	it("passes format, insecure and purge flags", func() {
+		mockClient.EXPECT().PushManifest(gomock.Any(), client.PushManifestOptions{
+			IndexRepoName: "some-index",
+			Format:        "docker",
+			Insecure:      true,
+			Purge:         true,
+		}).Return(nil)
+
+		command.SetArgs([]string{"some-index", "--format", "docker", "--insecure", "--purge"})
+		h.AssertNil(t, command.Execute())
+	})
+}
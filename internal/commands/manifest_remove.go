@@ -0,0 +1,24 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// ManifestDelete deletes one or more image indexes from local storage
+func ManifestDelete(logger logging.Logger, pack PackClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "remove [manifest-list] [manifest-list...]",
+		Args:    cobra.MatchAll(cobra.MinimumNArgs(1), cobra.OnlyValidArgs),
+		Short:   "Remove one or more manifest lists from local storage",
+		Example: `pack manifest remove my-image-index`,
+		Long:    `Delete one or more manifest lists (or image indexes) from local storage. The images they reference are not affected.`,
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			return pack.DeleteManifest(args)
+		}),
+	}
+
+	AddHelpFlag(cmd, "remove")
+	return cmd
+}
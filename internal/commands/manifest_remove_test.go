@@ -0,0 +1,64 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestManifestDeleteCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ManifestDeleteCommand", testManifestDeleteCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testManifestDeleteCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		command = commands.ManifestDelete(logger, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	it("deletes every given index", func() {
+		mockClient.EXPECT().DeleteManifest([]string{"some-index", "some-other-index"}).Return(nil)
+
+		command.SetArgs([]string{"some-index", "some-other-index"})
+		h.AssertNil(t, command.Execute())
+	})
+
+	it("returns the client error", func() {
+		mockClient.EXPECT().DeleteManifest(gomock.Any()).Return(errors.New("some-error"))
+
+		command.SetArgs([]string{"some-index"})
+		h.AssertError(t, command.Execute(), "some-error")
+	})
+
+	it("requires an index", func() {
+		command.SetArgs([]string{})
+		h.AssertError(t, command.Execute(), "requires at least 1 arg(s)")
+	})
+}
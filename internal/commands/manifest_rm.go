@@ -0,0 +1,28 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// ManifestRemove removes a manifest from a local image index
+func ManifestRemove(logger logging.Logger, pack PackClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "rm [manifest-list] [manifest]",
+		Args:    cobra.MatchAll(cobra.ExactArgs(2), cobra.OnlyValidArgs),
+		Short:   "Remove an image manifest from a manifest list.",
+		Example: `pack manifest rm my-image-index my-image@sha256:<some-sha>`,
+		Long:    `Remove the manifest of an image, given by tag or digest, from a local manifest list (or image index).`,
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			return pack.RemoveManifest(cmd.Context(), client.RemoveManifestOptions{
+				IndexRepoName: args[0],
+				RepoName:      args[1],
+			})
+		}),
+	}
+
+	AddHelpFlag(cmd, "rm")
+	return cmd
+}
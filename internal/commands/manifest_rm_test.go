@@ -0,0 +1,60 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestManifestRemoveCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ManifestRemoveCommand", testManifestRemoveCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testManifestRemoveCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		command = commands.ManifestRemove(logger, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	it("removes the manifest from the index", func() {
+		mockClient.EXPECT().RemoveManifest(gomock.Any(), client.RemoveManifestOptions{
+			IndexRepoName: "some-index",
+			RepoName:      "some-image@sha256:abc",
+		}).Return(nil)
+
+		command.SetArgs([]string{"some-index", "some-image@sha256:abc"})
+		h.AssertNil(t, command.Execute())
+	})
+
+	it("requires exactly two args", func() {
+		command.SetArgs([]string{"some-index"})
+		h.AssertError(t, command.Execute(), "accepts 2 arg(s)")
+	})
+}
@@ -15,7 +15,10 @@ import (
 
 func Rebase(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
 	var opts client.RebaseOptions
-	var policy string
+	var (
+		policy  string
+		signKey string
+	)
 
 	cmd := &cobra.Command{
 		Use:     "rebase <image-name>",
@@ -27,6 +30,7 @@ func Rebase(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Co
 		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
 			opts.RepoName = args[0]
 			opts.AdditionalMirrors = getMirrors(cfg)
+			opts.Sign = signOptions(signKey)
 
 			var err error
 			stringPolicy := policy
@@ -51,6 +55,7 @@ func Rebase(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Co
 	cmd.Flags().StringVar(&policy, "pull-policy", "", "Pull policy to use. Accepted values are always, never, and if-not-present. The default is always")
 	cmd.Flags().StringVar(&opts.PreviousImage, "previous-image", "", "Image to rebase. Set to a particular tag reference, digest reference, or (when performing a daemon build) image ID. Use this flag in combination with <image-name> to avoid replacing the original image.")
 	cmd.Flags().StringVar(&opts.ReportDestinationDir, "report-output-dir", "", "Path to export build report.toml.\nOmitting the flag yield no report file.")
+	cmd.Flags().StringVar(&signKey, "sign-key", "", "Path to a cosign private key to sign the rebased image with. Requires --publish.\nThe password of an encrypted key is read from COSIGN_PASSWORD.")
 	cmd.Flags().BoolVar(&opts.Force, "force", false, "Perform rebase operation without target validation (only available for API >= 0.12)")
 
 	AddHelpFlag(cmd, "rebase")
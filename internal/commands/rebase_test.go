@@ -2,6 +2,7 @@ package commands_test
 
 import (
 	"bytes"
+	"os"
 	"testing"
 
 	"github.com/heroku/color"
@@ -158,6 +159,16 @@ func testRebaseCommand(t *testing.T, when spec.G, it spec.S) {
 					})
 				})
 			})
+			when("sign-key is provided", func() {
+				it("passes the signing key through", func() {
+					opts.Publish = true
+					opts.Sign = &client.SignOptions{KeyPath: "cosign.key", KeyPassword: []byte(os.Getenv("COSIGN_PASSWORD"))}
+					mockClient.EXPECT().Rebase(gomock.Any(), opts).Return(nil)
+					command = commands.Rebase(logger, cfg, mockClient)
+					command.SetArgs([]string{repoName, "--publish", "--sign-key", "cosign.key"})
+					h.AssertNil(t, command.Execute())
+				})
+			})
 			when("image name and previous image are provided", func() {
 				var expectedOpts client.RebaseOptions
 
@@ -15,6 +15,9 @@ func NewSBOMCommand(logger logging.Logger, cfg config.Config, client PackClient)
 	}
 
 	cmd.AddCommand(DownloadSBOM(logger, client))
+	cmd.AddCommand(ListSBOM(logger, client))
+	cmd.AddCommand(DiffSBOM(logger, client))
+	cmd.AddCommand(CheckSBOM(logger, client))
 	AddHelpFlag(cmd, "sbom")
 	return cmd
 }
@@ -11,6 +11,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 
 	client "github.com/buildpacks/pack/pkg/client"
+	sbom "github.com/buildpacks/pack/pkg/sbom"
 )
 
 // MockPackClient is a mock of PackClient interface.
@@ -36,6 +37,34 @@ func (m *MockPackClient) EXPECT() *MockPackClientMockRecorder {
 	return m.recorder
 }
 
+// AddManifest mocks base method.
+func (m *MockPackClient) AddManifest(arg0 context.Context, arg1 client.AddManifestOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddManifest", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddManifest indicates an expected call of AddManifest.
+func (mr *MockPackClientMockRecorder) AddManifest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddManifest", reflect.TypeOf((*MockPackClient)(nil).AddManifest), arg0, arg1)
+}
+
+// AnnotateManifest mocks base method.
+func (m *MockPackClient) AnnotateManifest(arg0 context.Context, arg1 client.AnnotateManifestOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnnotateManifest", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AnnotateManifest indicates an expected call of AnnotateManifest.
+func (mr *MockPackClientMockRecorder) AnnotateManifest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnnotateManifest", reflect.TypeOf((*MockPackClient)(nil).AnnotateManifest), arg0, arg1)
+}
+
 // Build mocks base method.
 func (m *MockPackClient) Build(arg0 context.Context, arg1 client.BuildOptions) error {
 	m.ctrl.T.Helper()
@@ -50,6 +79,36 @@ func (mr *MockPackClientMockRecorder) Build(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Build", reflect.TypeOf((*MockPackClient)(nil).Build), arg0, arg1)
 }
 
+// BuildpackVersions mocks base method.
+func (m *MockPackClient) BuildpackVersions(arg0 client.BuildpackVersionsOptions) ([]client.RegistryBuildpack, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BuildpackVersions", arg0)
+	ret0, _ := ret[0].([]client.RegistryBuildpack)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BuildpackVersions indicates an expected call of BuildpackVersions.
+func (mr *MockPackClientMockRecorder) BuildpackVersions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuildpackVersions", reflect.TypeOf((*MockPackClient)(nil).BuildpackVersions), arg0)
+}
+
+// CheckSBOM mocks base method.
+func (m *MockPackClient) CheckSBOM(arg0 string, arg1 client.CheckSBOMOptions) (*sbom.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckSBOM", arg0, arg1)
+	ret0, _ := ret[0].(*sbom.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckSBOM indicates an expected call of CheckSBOM.
+func (mr *MockPackClientMockRecorder) CheckSBOM(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckSBOM", reflect.TypeOf((*MockPackClient)(nil).CheckSBOM), arg0, arg1)
+}
+
 // CreateBuilder mocks base method.
 func (m *MockPackClient) CreateBuilder(arg0 context.Context, arg1 client.CreateBuilderOptions) error {
 	m.ctrl.T.Helper()
@@ -64,6 +123,64 @@ func (mr *MockPackClientMockRecorder) CreateBuilder(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBuilder", reflect.TypeOf((*MockPackClient)(nil).CreateBuilder), arg0, arg1)
 }
 
+// CreateManifest mocks base method.
+func (m *MockPackClient) CreateManifest(arg0 context.Context, arg1 client.CreateManifestOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateManifest", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateManifest indicates an expected call of CreateManifest.
+func (mr *MockPackClientMockRecorder) CreateManifest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateManifest", reflect.TypeOf((*MockPackClient)(nil).CreateManifest), arg0, arg1)
+}
+
+// DeleteManifest mocks base method.
+func (m *MockPackClient) DeleteManifest(arg0 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteManifest", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteManifest indicates an expected call of DeleteManifest.
+func (mr *MockPackClientMockRecorder) DeleteManifest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteManifest", reflect.TypeOf((*MockPackClient)(nil).DeleteManifest), arg0)
+}
+
+// DiffImages mocks base method.
+func (m *MockPackClient) DiffImages(arg0 context.Context, arg1, arg2 string) (*client.ImageDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffImages", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*client.ImageDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffImages indicates an expected call of DiffImages.
+func (mr *MockPackClientMockRecorder) DiffImages(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffImages", reflect.TypeOf((*MockPackClient)(nil).DiffImages), arg0, arg1, arg2)
+}
+
+// DiffSBOM mocks base method.
+func (m *MockPackClient) DiffSBOM(arg0, arg1 string, arg2 client.DiffSBOMOptions) (*client.PackageDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffSBOM", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*client.PackageDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffSBOM indicates an expected call of DiffSBOM.
+func (mr *MockPackClientMockRecorder) DiffSBOM(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffSBOM", reflect.TypeOf((*MockPackClient)(nil).DiffSBOM), arg0, arg1, arg2)
+}
+
 // DownloadSBOM mocks base method.
 func (m *MockPackClient) DownloadSBOM(arg0 string, arg1 client.DownloadSBOMOptions) error {
 	m.ctrl.T.Helper()
@@ -78,6 +195,34 @@ func (mr *MockPackClientMockRecorder) DownloadSBOM(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadSBOM", reflect.TypeOf((*MockPackClient)(nil).DownloadSBOM), arg0, arg1)
 }
 
+// ExportCache mocks base method.
+func (m *MockPackClient) ExportCache(arg0 context.Context, arg1 client.ExportCacheOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportCache", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportCache indicates an expected call of ExportCache.
+func (mr *MockPackClientMockRecorder) ExportCache(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportCache", reflect.TypeOf((*MockPackClient)(nil).ExportCache), arg0, arg1)
+}
+
+// ImportCache mocks base method.
+func (m *MockPackClient) ImportCache(arg0 context.Context, arg1 client.ImportCacheOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportCache", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportCache indicates an expected call of ImportCache.
+func (mr *MockPackClientMockRecorder) ImportCache(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportCache", reflect.TypeOf((*MockPackClient)(nil).ImportCache), arg0, arg1)
+}
+
 // InspectBuilder mocks base method.
 func (m *MockPackClient) InspectBuilder(arg0 string, arg1 bool, arg2 ...client.BuilderInspectionModifier) (*client.BuilderInfo, error) {
 	m.ctrl.T.Helper()
@@ -113,6 +258,21 @@ func (mr *MockPackClientMockRecorder) InspectBuildpack(arg0 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InspectBuildpack", reflect.TypeOf((*MockPackClient)(nil).InspectBuildpack), arg0)
 }
 
+// InspectCache mocks base method.
+func (m *MockPackClient) InspectCache(arg0 context.Context, arg1 client.InspectCacheOptions) ([]client.CacheVolume, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InspectCache", arg0, arg1)
+	ret0, _ := ret[0].([]client.CacheVolume)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InspectCache indicates an expected call of InspectCache.
+func (mr *MockPackClientMockRecorder) InspectCache(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InspectCache", reflect.TypeOf((*MockPackClient)(nil).InspectCache), arg0, arg1)
+}
+
 // InspectExtension mocks base method.
 func (m *MockPackClient) InspectExtension(arg0 client.InspectExtensionOptions) (*client.ExtensionInfo, error) {
 	m.ctrl.T.Helper()
@@ -143,6 +303,50 @@ func (mr *MockPackClientMockRecorder) InspectImage(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InspectImage", reflect.TypeOf((*MockPackClient)(nil).InspectImage), arg0, arg1)
 }
 
+// InspectManifest mocks base method.
+func (m *MockPackClient) InspectManifest(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InspectManifest", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InspectManifest indicates an expected call of InspectManifest.
+func (mr *MockPackClientMockRecorder) InspectManifest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InspectManifest", reflect.TypeOf((*MockPackClient)(nil).InspectManifest), arg0, arg1)
+}
+
+// ListCaches mocks base method.
+func (m *MockPackClient) ListCaches(arg0 context.Context) ([]client.CacheVolume, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCaches", arg0)
+	ret0, _ := ret[0].([]client.CacheVolume)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCaches indicates an expected call of ListCaches.
+func (mr *MockPackClientMockRecorder) ListCaches(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCaches", reflect.TypeOf((*MockPackClient)(nil).ListCaches), arg0)
+}
+
+// ListSBOM mocks base method.
+func (m *MockPackClient) ListSBOM(arg0 string, arg1 client.ListSBOMOptions) ([]sbom.Package, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSBOM", arg0, arg1)
+	ret0, _ := ret[0].([]sbom.Package)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSBOM indicates an expected call of ListSBOM.
+func (mr *MockPackClientMockRecorder) ListSBOM(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSBOM", reflect.TypeOf((*MockPackClient)(nil).ListSBOM), arg0, arg1)
+}
+
 // NewBuildpack mocks base method.
 func (m *MockPackClient) NewBuildpack(arg0 context.Context, arg1 client.NewBuildpackOptions) error {
 	m.ctrl.T.Helper()
@@ -185,6 +389,21 @@ func (mr *MockPackClientMockRecorder) PackageExtension(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PackageExtension", reflect.TypeOf((*MockPackClient)(nil).PackageExtension), arg0, arg1)
 }
 
+// PruneCaches mocks base method.
+func (m *MockPackClient) PruneCaches(arg0 context.Context, arg1 client.PruneCachesOptions) ([]client.CacheVolume, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneCaches", arg0, arg1)
+	ret0, _ := ret[0].([]client.CacheVolume)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneCaches indicates an expected call of PruneCaches.
+func (mr *MockPackClientMockRecorder) PruneCaches(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneCaches", reflect.TypeOf((*MockPackClient)(nil).PruneCaches), arg0, arg1)
+}
+
 // PullBuildpack mocks base method.
 func (m *MockPackClient) PullBuildpack(arg0 context.Context, arg1 client.PullBuildpackOptions) error {
 	m.ctrl.T.Helper()
@@ -199,6 +418,20 @@ func (mr *MockPackClientMockRecorder) PullBuildpack(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PullBuildpack", reflect.TypeOf((*MockPackClient)(nil).PullBuildpack), arg0, arg1)
 }
 
+// PushManifest mocks base method.
+func (m *MockPackClient) PushManifest(arg0 context.Context, arg1 client.PushManifestOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PushManifest", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PushManifest indicates an expected call of PushManifest.
+func (mr *MockPackClientMockRecorder) PushManifest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PushManifest", reflect.TypeOf((*MockPackClient)(nil).PushManifest), arg0, arg1)
+}
+
 // Rebase mocks base method.
 func (m *MockPackClient) Rebase(arg0 context.Context, arg1 client.RebaseOptions) error {
 	m.ctrl.T.Helper()
@@ -227,6 +460,35 @@ func (mr *MockPackClientMockRecorder) RegisterBuildpack(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterBuildpack", reflect.TypeOf((*MockPackClient)(nil).RegisterBuildpack), arg0, arg1)
 }
 
+// RemoveManifest mocks base method.
+func (m *MockPackClient) RemoveManifest(arg0 context.Context, arg1 client.RemoveManifestOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveManifest", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveManifest indicates an expected call of RemoveManifest.
+func (mr *MockPackClientMockRecorder) RemoveManifest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveManifest", reflect.TypeOf((*MockPackClient)(nil).RemoveManifest), arg0, arg1)
+}
+
+// SearchBuildpacks mocks base method.
+func (m *MockPackClient) SearchBuildpacks(arg0 client.SearchBuildpacksOptions) ([]client.RegistryBuildpack, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchBuildpacks", arg0)
+	ret0, _ := ret[0].([]client.RegistryBuildpack)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchBuildpacks indicates an expected call of SearchBuildpacks.
+func (mr *MockPackClientMockRecorder) SearchBuildpacks(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchBuildpacks", reflect.TypeOf((*MockPackClient)(nil).SearchBuildpacks), arg0)
+}
+
 // YankBuildpack mocks base method.
 func (m *MockPackClient) YankBuildpack(arg0 client.YankBuildpackOptions) error {
 	m.ctrl.T.Helper()
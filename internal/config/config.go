@@ -12,17 +12,18 @@ import (
 
 type Config struct {
 	// Deprecated: Use DefaultRegistryName instead. See https://github.com/buildpacks/pack/issues/747.
-	DefaultRegistry     string            `toml:"default-registry-url,omitempty"`
-	DefaultRegistryName string            `toml:"default-registry,omitempty"`
-	DefaultBuilder      string            `toml:"default-builder-image,omitempty"`
-	PullPolicy          string            `toml:"pull-policy,omitempty"`
-	Experimental        bool              `toml:"experimental,omitempty"`
-	RunImages           []RunImage        `toml:"run-images"`
-	TrustedBuilders     []TrustedBuilder  `toml:"trusted-builders,omitempty"`
-	Registries          []Registry        `toml:"registries,omitempty"`
-	LifecycleImage      string            `toml:"lifecycle-image,omitempty"`
-	RegistryMirrors     map[string]string `toml:"registry-mirrors,omitempty"`
-	LayoutRepositoryDir string            `toml:"layout-repo-dir,omitempty"`
+	DefaultRegistry      string               `toml:"default-registry-url,omitempty"`
+	DefaultRegistryName  string               `toml:"default-registry,omitempty"`
+	DefaultBuilder       string               `toml:"default-builder-image,omitempty"`
+	PullPolicy           string               `toml:"pull-policy,omitempty"`
+	Experimental         bool                 `toml:"experimental,omitempty"`
+	RunImages            []RunImage           `toml:"run-images"`
+	TrustedBuilders      []TrustedBuilder     `toml:"trusted-builders,omitempty"`
+	Registries           []Registry           `toml:"registries,omitempty"`
+	LifecycleImage       string               `toml:"lifecycle-image,omitempty"`
+	RegistryMirrors      map[string]string    `toml:"registry-mirrors,omitempty"`
+	LayoutRepositoryDir  string               `toml:"layout-repo-dir,omitempty"`
+	VerificationPolicies []VerificationPolicy `toml:"verification-policies,omitempty"`
 }
 
 type Registry struct {
@@ -40,6 +41,16 @@ type TrustedBuilder struct {
 	Name string `toml:"name"`
 }
 
+// VerificationPolicy lists the public keys that must have signed the images of a builder or buildpack repository,
+// or the digests they are pinned to. Buildpack archives, identified by URI instead of image, can only be pinned to
+// digests.
+type VerificationPolicy struct {
+	Image      string   `toml:"image,omitempty"`
+	URI        string   `toml:"uri,omitempty"`
+	PublicKeys []string `toml:"public-keys,omitempty"`
+	Digests    []string `toml:"digests,omitempty"`
+}
+
 const OfficialRegistryName = "official"
 
 func DefaultRegistry() Registry {
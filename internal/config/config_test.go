@@ -72,6 +72,9 @@ func testConfig(t *testing.T, when spec.G, it spec.S) {
 					RegistryMirrors: map[string]string{
 						"index.docker.io": "10.0.0.1",
 					},
+					VerificationPolicies: []config.VerificationPolicy{
+						{Image: "some/builder", PublicKeys: []string{"cosign.pub"}},
+					},
 				}, configPath))
 
 				b, err := os.ReadFile(configPath)
@@ -90,6 +93,10 @@ func testConfig(t *testing.T, when spec.G, it spec.S) {
 
 				h.AssertContains(t, string(b), `[registry-mirrors]
   "index.docker.io" = "10.0.0.1"`)
+
+				h.AssertContains(t, string(b), `[[verification-policies]]
+  image = "some/builder"
+  public-keys = ["cosign.pub"]`)
 			})
 		})
 
@@ -11,6 +11,15 @@ import (
 	"github.com/pkg/errors"
 )
 
+// ExitError is returned when a container exits with a non-zero status code.
+type ExitError struct {
+	StatusCode int64
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("failed with status code: %d", e.StatusCode)
+}
+
 type Handler func(bodyChan <-chan dcontainer.WaitResponse, errChan <-chan error, reader io.Reader) error
 
 type DockerClient interface {
@@ -77,7 +86,7 @@ func DefaultHandler(out, errOut io.Writer) Handler {
 		select {
 		case body := <-bodyChan:
 			if body.StatusCode != 0 {
-				return fmt.Errorf("failed with status code: %d", body.StatusCode)
+				return &ExitError{StatusCode: body.StatusCode}
 			}
 		case err := <-errChan:
 			return err
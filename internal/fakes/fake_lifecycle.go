@@ -7,10 +7,18 @@ import (
 )
 
 type FakeLifecycle struct {
-	Opts build.LifecycleOptions
+	Opts             build.LifecycleOptions
+	ExecuteCallCount int
+
+	// ExecuteFunc, when set, is called by Execute and its result returned
+	ExecuteFunc func(opts build.LifecycleOptions) error
 }
 
 func (f *FakeLifecycle) Execute(ctx context.Context, opts build.LifecycleOptions) error {
 	f.Opts = opts
+	f.ExecuteCallCount++
+	if f.ExecuteFunc != nil {
+		return f.ExecuteFunc(opts)
+	}
 	return nil
 }
@@ -0,0 +1,159 @@
+package writer
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// InspectImageDiffWriter prints the differences between two app images, as calculated by client.DiffImages.
+type InspectImageDiffWriter interface {
+	PrintDiff(logger logging.Logger, oldName, newName string, diff *client.ImageDiff) error
+}
+
+type DiffOutput struct {
+	OldImage string            `json:"old_image" yaml:"old_image" toml:"old_image"`
+	NewImage string            `json:"new_image" yaml:"new_image" toml:"new_image"`
+	Diff     *client.ImageDiff `json:"diff" yaml:"diff" toml:"diff"`
+}
+
+// DiffWriter returns a writer for the given output format. The formats are the same as those supported by Writer.
+func (f *Factory) DiffWriter(kind string) (InspectImageDiffWriter, error) {
+	switch kind {
+	case "human-readable":
+		return NewHumanReadable(), nil
+	case "json":
+		return NewJSON(), nil
+	case "yaml":
+		return NewYAML(), nil
+	case "toml":
+		return NewTOML(), nil
+	}
+
+	return nil, fmt.Errorf("output format %s is not supported", style.Symbol(kind))
+}
+
+func (w *StructuredFormat) PrintDiff(logger logging.Logger, oldName, newName string, diff *client.ImageDiff) error {
+	out, err := w.MarshalFunc(DiffOutput{OldImage: oldName, NewImage: newName, Diff: diff})
+	if err != nil {
+		return fmt.Errorf("untested, unexpected failure while marshaling: %w", err)
+	}
+
+	_, err = logger.Writer().Write(out)
+	return err
+}
+
+func (h *HumanReadable) PrintDiff(logger logging.Logger, oldName, newName string, diff *client.ImageDiff) error {
+	logger.Infof("Comparing image %s with %s\n", style.Symbol(oldName), style.Symbol(newName))
+
+	if diff.Empty() {
+		logger.Info("\nNo differences found\n")
+		return nil
+	}
+
+	buf := &bytes.Buffer{}
+	if diff.RunImage != nil {
+		kind := "rebuilt"
+		if diff.RunImage.Rebased {
+			kind = "rebased"
+		}
+		fmt.Fprintf(buf, "\nRun Image (%s):\n", kind)
+		writeValueChange(buf, "  ", "Image", diff.RunImage.Image)
+		writeValueChange(buf, "  ", "Reference", diff.RunImage.Reference)
+		writeValueChange(buf, "  ", "Top Layer", diff.RunImage.TopLayer)
+	}
+	writeModuleDiff(buf, "Buildpacks", diff.Buildpacks)
+	writeModuleDiff(buf, "Extensions", diff.Extensions)
+	if !diff.Processes.Empty() {
+		buf.WriteString("\nProcesses:\n")
+		writeValueChange(buf, "  ", "Default", diff.Processes.Default)
+		for _, processType := range diff.Processes.Added {
+			fmt.Fprintf(buf, "  + %s\n", processType)
+		}
+		for _, processType := range diff.Processes.Removed {
+			fmt.Fprintf(buf, "  - %s\n", processType)
+		}
+		for _, change := range diff.Processes.Changed {
+			fmt.Fprintf(buf, "  ~ %s: %s -> %s\n", change.Type, change.OldCommand, change.NewCommand)
+		}
+	}
+	writePackageDiff(buf, "BOM", diff.BOM)
+	writePackageDiff(buf, "SBOM", diff.SBOM)
+	if !diff.Layers.Empty() {
+		buf.WriteString("\nLayers:\n")
+		writeListDiff(buf, "  ", "Run Image", diff.Layers.RunImage)
+		writeListDiff(buf, "  ", "App", diff.Layers.App)
+	}
+
+	logger.Info(buf.String())
+	return nil
+}
+
+func writePackageDiff(buf *bytes.Buffer, title string, diff client.PackageDiff) {
+	if diff.Empty() {
+		return
+	}
+	fmt.Fprintf(buf, "\n%s:\n", title)
+	for _, pkg := range diff.Added {
+		fmt.Fprintf(buf, "  + %s\n", packageName(pkg))
+	}
+	for _, pkg := range diff.Removed {
+		fmt.Fprintf(buf, "  - %s\n", packageName(pkg))
+	}
+	for _, change := range diff.Changed {
+		fmt.Fprintf(buf, "  ~ %s: %s -> %s\n", change.Name, valueOrNone(change.OldVersion), valueOrNone(change.NewVersion))
+	}
+}
+
+func writeValueChange(buf *bytes.Buffer, indent, title string, change *client.ValueChange) {
+	if change == nil {
+		return
+	}
+	fmt.Fprintf(buf, "%s%s: %s -> %s\n", indent, title, valueOrNone(change.Old), valueOrNone(change.New))
+}
+
+func writeListDiff(buf *bytes.Buffer, indent, title string, diff client.ListDiff) {
+	if diff.Empty() {
+		return
+	}
+	fmt.Fprintf(buf, "%s%s:\n", indent, title)
+	for _, entry := range diff.Added {
+		fmt.Fprintf(buf, "%s  + %s\n", indent, entry)
+	}
+	for _, entry := range diff.Removed {
+		fmt.Fprintf(buf, "%s  - %s\n", indent, entry)
+	}
+}
+
+func writeModuleDiff(buf *bytes.Buffer, title string, diff client.ModuleDiff) {
+	if diff.Empty() {
+		return
+	}
+	fmt.Fprintf(buf, "\n%s:\n", title)
+	for _, module := range diff.Added {
+		fmt.Fprintf(buf, "  + %s\n", module.FullName())
+	}
+	for _, module := range diff.Removed {
+		fmt.Fprintf(buf, "  - %s\n", module.FullName())
+	}
+	for _, change := range diff.Changed {
+		fmt.Fprintf(buf, "  ~ %s: %s -> %s\n", change.ID, valueOrNone(change.OldVersion), valueOrNone(change.NewVersion))
+	}
+}
+
+func packageName(pkg client.Package) string {
+	if pkg.Version == "" {
+		return pkg.Name
+	}
+	return pkg.Name + "@" + pkg.Version
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "(none)"
+	}
+	return value
+}
@@ -0,0 +1,150 @@
+package writer_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/inspectimage/writer"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestDiffWriter(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Inspect Image Diff Writer", testDiffWriter, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testDiffWriter(t *testing.T, when spec.G, it spec.S) {
+	var (
+		assert = h.NewAssertionManager(t)
+		outBuf bytes.Buffer
+		logger logging.Logger
+
+		diff = &client.ImageDiff{
+			Buildpacks: client.ModuleDiff{
+				Added:   []dist.ModuleInfo{{ID: "new-buildpack", Version: "0.1.0"}},
+				Changed: []client.ModuleChange{{ID: "some-buildpack", OldVersion: "1.0.0", NewVersion: "1.1.0"}},
+			},
+			RunImage: &client.RunImageDiff{
+				Reference: &client.ValueChange{Old: "some/run@sha256:aaa", New: "some/run@sha256:bbb"},
+				Rebased:   true,
+			},
+			Processes: client.ProcessDiff{
+				Removed: []string{"worker"},
+				Changed: []client.ProcessChange{{Type: "web", OldCommand: "/start -p 8080", NewCommand: "/start -p 9090"}},
+			},
+			BOM: client.PackageDiff{
+				Added:   []client.Package{{Name: "curl", Version: "8.0.0"}},
+				Changed: []client.PackageChange{{Name: "openssl", OldVersion: "3.0.1", NewVersion: "3.0.2"}},
+			},
+			SBOM: client.PackageDiff{
+				Removed: []client.Package{{Name: "zlib", Version: "1.2.11"}},
+			},
+			Layers: client.LayerDiff{
+				RunImage: client.ListDiff{Added: []string{"sha256:run-3"}, Removed: []string{"sha256:run-2"}},
+			},
+		}
+	)
+
+	it.Before(func() {
+		outBuf.Reset()
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+	})
+
+	when("human-readable", func() {
+		it("prints the sections that changed", func() {
+			err := writer.NewHumanReadable().PrintDiff(logger, "some/app:v1", "some/app:v2", diff)
+			assert.Nil(err)
+
+			assert.Contains(outBuf.String(), "Comparing image 'some/app:v1' with 'some/app:v2'")
+			assert.Contains(outBuf.String(), `
+Run Image (rebased):
+  Reference: some/run@sha256:aaa -> some/run@sha256:bbb
+`)
+			assert.Contains(outBuf.String(), `
+Buildpacks:
+  + new-buildpack@0.1.0
+  ~ some-buildpack: 1.0.0 -> 1.1.0
+`)
+			assert.Contains(outBuf.String(), `
+Processes:
+  - worker
+  ~ web: /start -p 8080 -> /start -p 9090
+`)
+			assert.Contains(outBuf.String(), `
+BOM:
+  + curl@8.0.0
+  ~ openssl: 3.0.1 -> 3.0.2
+`)
+			assert.Contains(outBuf.String(), `
+SBOM:
+  - zlib@1.2.11
+`)
+			assert.Contains(outBuf.String(), `
+Layers:
+  Run Image:
+    + sha256:run-3
+    - sha256:run-2
+`)
+			assert.NotContains(outBuf.String(), "Extensions")
+		})
+
+		when("the run image was rebuilt", func() {
+			it("says so", func() {
+				err := writer.NewHumanReadable().PrintDiff(logger, "some/app:v1", "some/app:v2", &client.ImageDiff{
+					RunImage: &client.RunImageDiff{Image: &client.ValueChange{Old: "some/run", New: "other/run"}},
+				})
+				assert.Nil(err)
+
+				assert.Contains(outBuf.String(), "Run Image (rebuilt):\n  Image: some/run -> other/run\n")
+			})
+		})
+
+		when("there are no differences", func() {
+			it("says so", func() {
+				err := writer.NewHumanReadable().PrintDiff(logger, "some/app:v1", "some/app:v2", &client.ImageDiff{})
+				assert.Nil(err)
+
+				assert.Contains(outBuf.String(), "No differences found")
+			})
+		})
+	})
+
+	when("json", func() {
+		it("prints the image names and differences", func() {
+			err := writer.NewJSON().PrintDiff(logger, "some/app:v1", "some/app:v2", diff)
+			assert.Nil(err)
+
+			assert.Contains(outBuf.String(), `"old_image": "some/app:v1"`)
+			assert.Contains(outBuf.String(), `"new_image": "some/app:v2"`)
+			assert.Contains(outBuf.String(), `"rebased": true`)
+		})
+	})
+
+	when("yaml", func() {
+		it("prints the image names and differences", func() {
+			err := writer.NewYAML().PrintDiff(logger, "some/app:v1", "some/app:v2", diff)
+			assert.Nil(err)
+
+			assert.Contains(outBuf.String(), "old_image: some/app:v1")
+			assert.Contains(outBuf.String(), "new_version: 3.0.2")
+		})
+	})
+
+	when("toml", func() {
+		it("prints the image names and differences", func() {
+			err := writer.NewTOML().PrintDiff(logger, "some/app:v1", "some/app:v2", diff)
+			assert.Nil(err)
+
+			assert.Contains(outBuf.String(), `old_image = "some/app:v1"`)
+			assert.Contains(outBuf.String(), `new_command = "/start -p 9090"`)
+		})
+	})
+}
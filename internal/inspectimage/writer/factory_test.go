@@ -145,4 +145,30 @@ func testFactory(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 	})
+
+	when("DiffWriter", func() {
+		when("output format is toml", func() {
+			it("returns a TOML writer", func() {
+				factory := writer.NewFactory()
+
+				returnedWriter, err := factory.DiffWriter("toml")
+				assert.Nil(err)
+
+				_, ok := returnedWriter.(*writer.TOML)
+				assert.TrueWithMessage(
+					ok,
+					fmt.Sprintf("expected %T to be assignable to type `*writer.TOML`", returnedWriter),
+				)
+			})
+		})
+
+		when("output format is not supported", func() {
+			it("returns an error", func() {
+				factory := writer.NewFactory()
+
+				_, err := factory.DiffWriter("mind-beam")
+				assert.ErrorWithMessage(err, "output format 'mind-beam' is not supported")
+			})
+		})
+	})
 }
@@ -0,0 +1,204 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	ggcrname "github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+const (
+	// OCIIndexTag is the tag of the artifacts holding the entries of an OCI index.
+	OCIIndexTag = "index"
+	// OCIIndexConfigMediaType is the config media type of the artifacts holding the entries of an OCI index.
+	OCIIndexConfigMediaType types.MediaType = "application/vnd.buildpacks.registry.index.config.v1+json"
+	// OCIIndexEntryMediaType is the media type of the layer holding the entries of a buildpack in an OCI index.
+	OCIIndexEntryMediaType types.MediaType = "application/vnd.buildpacks.registry.entry.v1+json"
+)
+
+// OCIIndex is a buildpack registry stored in an image registry, for teams that can't reach a git index. The
+// entries of the buildpack <ns>/<name> are stored as an OCI artifact, tagged OCIIndexTag in the repository
+// <repository>/<ns>/<name>, which may also hold the buildpack images themselves.
+type OCIIndex struct {
+	logger     logging.Logger
+	repository string
+	options    []remote.Option
+}
+
+// NewOCIIndex creates an index stored under repository, e.g. registry.example.com/buildpacks, which may be
+// prefixed with oci://.
+func NewOCIIndex(logger logging.Logger, repository string, keychain authn.Keychain) (*OCIIndex, error) {
+	if keychain == nil {
+		keychain = authn.DefaultKeychain
+	}
+
+	repository = strings.TrimSuffix(strings.TrimPrefix(repository, "oci://"), "/")
+	if _, err := ggcrname.NewRepository(repository, ggcrname.WeakValidation); err != nil {
+		return nil, errors.Wrapf(err, "invalid registry repository %s", style.Symbol(repository))
+	}
+
+	return &OCIIndex{
+		logger:     logger,
+		repository: repository,
+		options:    []remote.Option{remote.WithAuthFromKeychain(keychain)},
+	}, nil
+}
+
+// LocateBuildpack stored in registry
+func (i *OCIIndex) LocateBuildpack(bp string) (Buildpack, error) {
+	ns, name, version, err := buildpack.ParseRegistryID(bp)
+	if err != nil {
+		return Buildpack{}, errors.Wrap(err, "parsing buildpacks registry id")
+	}
+
+	entry, err := i.readEntry(context.Background(), ns, name)
+	if err != nil {
+		return Buildpack{}, errors.Wrap(err, "reading entry")
+	}
+	return locate(entry, bp, version)
+}
+
+// Versions returns the versions of a buildpack stored in registry, highest first
+func (i *OCIIndex) Versions(id string) ([]Buildpack, error) {
+	ns, name, err := ParseNamespaceName(id)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := i.readEntry(context.Background(), ns, name)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading entry")
+	}
+	if len(entry.Buildpacks) == 0 {
+		return nil, fmt.Errorf("no entries for buildpack: %s", id)
+	}
+	sortVersions(entry.Buildpacks)
+	return entry.Buildpacks, nil
+}
+
+// Register adds a version of a buildpack to the index.
+func (i *OCIIndex) Register(ctx context.Context, b Buildpack) error {
+	if err := Validate(b); err != nil {
+		return err
+	}
+
+	entry, err := i.readEntry(ctx, b.Namespace, b.Name)
+	if err != nil {
+		return errors.Wrap(err, "reading entry")
+	}
+	for _, existing := range entry.Buildpacks {
+		if existing.Version == b.Version {
+			return errors.Errorf("buildpack %s is already registered", style.Symbol(fmt.Sprintf("%s/%s@%s", b.Namespace, b.Name, b.Version)))
+		}
+	}
+
+	entry.Buildpacks = append(entry.Buildpacks, b)
+	return i.writeEntry(ctx, b.Namespace, b.Name, entry)
+}
+
+// Yank marks a version of a buildpack in the index as yanked, or as no longer yanked when b.Yanked is false.
+func (i *OCIIndex) Yank(ctx context.Context, b Buildpack) error {
+	entry, err := i.readEntry(ctx, b.Namespace, b.Name)
+	if err != nil {
+		return errors.Wrap(err, "reading entry")
+	}
+
+	for j := range entry.Buildpacks {
+		if entry.Buildpacks[j].Version == b.Version {
+			entry.Buildpacks[j].Yanked = b.Yanked
+			return i.writeEntry(ctx, b.Namespace, b.Name, entry)
+		}
+	}
+	return errors.Errorf("buildpack %s is not registered", style.Symbol(fmt.Sprintf("%s/%s@%s", b.Namespace, b.Name, b.Version)))
+}
+
+// tag returns the tag of the artifact holding the entries of a buildpack
+func (i *OCIIndex) tag(ns, name string) (ggcrname.Tag, error) {
+	if err := validateField("namespace", ns); err != nil {
+		return ggcrname.Tag{}, err
+	}
+	if err := validateField("name", name); err != nil {
+		return ggcrname.Tag{}, err
+	}
+	return ggcrname.NewTag(fmt.Sprintf("%s/%s/%s:%s", i.repository, ns, name, OCIIndexTag), ggcrname.WeakValidation)
+}
+
+func (i *OCIIndex) readEntry(ctx context.Context, ns, name string) (Entry, error) {
+	tag, err := i.tag(ns, name)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	i.logger.Debugf("Reading registry entries from %s", style.Symbol(tag.Name()))
+	img, err := remote.Image(tag, append(i.options, remote.WithContext(ctx))...)
+	if err != nil {
+		var transportErr *transport.Error
+		if errors.As(err, &transportErr) && transportErr.StatusCode == 404 {
+			return Entry{}, nil
+		}
+		return Entry{}, errors.Wrapf(err, "fetching %s", style.Symbol(tag.Name()))
+	}
+
+	layers, err := img.Layers()
+	if err != nil {
+		return Entry{}, errors.Wrapf(err, "reading %s", style.Symbol(tag.Name()))
+	}
+	if len(layers) != 1 {
+		return Entry{}, errors.Errorf("%s is not a registry entry: expected 1 layer, found %d", style.Symbol(tag.Name()), len(layers))
+	}
+	rc, err := layers[0].Uncompressed()
+	if err != nil {
+		return Entry{}, errors.Wrapf(err, "reading %s", style.Symbol(tag.Name()))
+	}
+	defer rc.Close()
+
+	contents, err := io.ReadAll(rc)
+	if err != nil {
+		return Entry{}, errors.Wrapf(err, "reading %s", style.Symbol(tag.Name()))
+	}
+	var entry Entry
+	if err := json.Unmarshal(contents, &entry); err != nil {
+		return Entry{}, errors.Wrapf(err, "parsing index for buildpack: %s/%s", ns, name)
+	}
+	return entry, nil
+}
+
+func (i *OCIIndex) writeEntry(ctx context.Context, ns, name string, entry Entry) error {
+	tag, err := i.tag(ns, name)
+	if err != nil {
+		return err
+	}
+
+	contents, err := json.Marshal(entry)
+	if err != nil {
+		return errors.Wrapf(err, "converting buildpack entry to json: %s/%s", ns, name)
+	}
+	img, err := mutate.Append(
+		mutate.ConfigMediaType(mutate.MediaType(empty.Image, types.OCIManifestSchema1), OCIIndexConfigMediaType),
+		mutate.Addendum{Layer: static.NewLayer(contents, OCIIndexEntryMediaType), MediaType: OCIIndexEntryMediaType},
+	)
+	if err != nil {
+		return err
+	}
+
+	i.logger.Debugf("Writing registry entries to %s", style.Symbol(tag.Name()))
+	if err := remote.Write(tag, img, append(i.options, remote.WithContext(ctx))...); err != nil {
+		return errors.Wrapf(err, "writing %s", style.Symbol(tag.Name()))
+	}
+	return nil
+}
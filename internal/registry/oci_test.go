@@ -0,0 +1,154 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	ggcrregistry "github.com/google/go-containerregistry/pkg/registry"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestOCIIndex(t *testing.T) {
+	color.Disable(true)
+	spec.Run(t, "OCIIndex", testOCIIndex, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testOCIIndex(t *testing.T, when spec.G, it spec.S) {
+	var (
+		server *httptest.Server
+		index  *OCIIndex
+		outBuf bytes.Buffer
+		ctx    = context.Background()
+	)
+
+	buildpackVersion := func(version string) Buildpack {
+		return Buildpack{
+			Namespace: "example",
+			Name:      "java",
+			Version:   version,
+			Address:   "example.com/some/package@sha256:8c27fe111c11b722081701dfed3bd55e039b9ce92865473cf4cdfa918071c566",
+		}
+	}
+
+	it.Before(func() {
+		server = httptest.NewServer(ggcrregistry.New(ggcrregistry.Logger(log.New(io.Discard, "", 0))))
+
+		var err error
+		index, err = NewOCIIndex(logging.NewLogWithWriters(&outBuf, &outBuf), "oci://"+strings.TrimPrefix(server.URL, "http://")+"/buildpacks/", authn.NewMultiKeychain())
+		h.AssertNil(t, err)
+	})
+
+	it.After(func() {
+		server.Close()
+	})
+
+	when("#NewOCIIndex", func() {
+		it("fails for an invalid repository", func() {
+			_, err := NewOCIIndex(logging.NewLogWithWriters(&outBuf, &outBuf), "oci://Not A Repository", nil)
+			h.AssertError(t, err, "invalid registry repository 'Not A Repository'")
+		})
+	})
+
+	when("#Register", func() {
+		it("adds versions to the entry of the buildpack", func() {
+			h.AssertNil(t, index.Register(ctx, buildpackVersion("1.0.0")))
+			h.AssertNil(t, index.Register(ctx, buildpackVersion("1.1.0")))
+
+			bp, err := index.LocateBuildpack("example/java")
+			h.AssertNil(t, err)
+			h.AssertEq(t, bp, buildpackVersion("1.1.0"))
+
+			bp, err = index.LocateBuildpack("example/java@1.0.0")
+			h.AssertNil(t, err)
+			h.AssertEq(t, bp, buildpackVersion("1.0.0"))
+		})
+
+		it("fails when the version is already registered", func() {
+			h.AssertNil(t, index.Register(ctx, buildpackVersion("1.0.0")))
+
+			err := index.Register(ctx, buildpackVersion("1.0.0"))
+			h.AssertError(t, err, "buildpack 'example/java@1.0.0' is already registered")
+		})
+
+		it("fails when the address is not a digest reference", func() {
+			b := buildpackVersion("1.0.0")
+			b.Address = "example.com/some/package:latest"
+
+			err := index.Register(ctx, b)
+			h.AssertError(t, err, "'example.com/some/package:latest' is not a digest reference")
+		})
+
+		it("fails for an invalid namespace", func() {
+			b := buildpackVersion("1.0.0")
+			b.Namespace = "Example"
+
+			err := index.Register(ctx, b)
+			h.AssertError(t, err, "'namespace' contains illegal characters")
+		})
+	})
+
+	when("#Yank", func() {
+		it.Before(func() {
+			h.AssertNil(t, index.Register(ctx, buildpackVersion("1.0.0")))
+		})
+
+		it("marks the version as yanked", func() {
+			b := buildpackVersion("1.0.0")
+			b.Yanked = true
+			h.AssertNil(t, index.Yank(ctx, b))
+
+			bp, err := index.LocateBuildpack("example/java@1.0.0")
+			h.AssertNil(t, err)
+			h.AssertTrue(t, bp.Yanked)
+
+			b.Yanked = false
+			h.AssertNil(t, index.Yank(ctx, b))
+
+			bp, err = index.LocateBuildpack("example/java@1.0.0")
+			h.AssertNil(t, err)
+			h.AssertFalse(t, bp.Yanked)
+		})
+
+		it("fails when the version is not registered", func() {
+			err := index.Yank(ctx, buildpackVersion("2.0.0"))
+			h.AssertError(t, err, "buildpack 'example/java@2.0.0' is not registered")
+		})
+	})
+
+	when("#LocateBuildpack", func() {
+		it("fails when the buildpack is not registered", func() {
+			_, err := index.LocateBuildpack("example/ruby")
+			h.AssertError(t, err, "no entries for buildpack: example/ruby")
+		})
+
+		it("skips yanked versions when resolving a version range", func() {
+			h.AssertNil(t, index.Register(ctx, buildpackVersion("1.0.0")))
+			yanked := buildpackVersion("1.1.0")
+			h.AssertNil(t, index.Register(ctx, yanked))
+			yanked.Yanked = true
+			h.AssertNil(t, index.Yank(ctx, yanked))
+
+			bp, err := index.LocateBuildpack("example/java@^1.0")
+			h.AssertNil(t, err)
+			h.AssertEq(t, bp.Version, "1.0.0")
+		})
+
+		it("fails when the version is not registered", func() {
+			h.AssertNil(t, index.Register(ctx, buildpackVersion("1.0.0")))
+
+			_, err := index.LocateBuildpack("example/java@2.0.0")
+			h.AssertError(t, err, "could not find version for buildpack: example/java@2.0.0")
+		})
+	})
+}
@@ -6,11 +6,14 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io/fs"
 	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/go-git/go-git/v5"
@@ -27,6 +30,12 @@ const DefaultRegistryURL = "https://github.com/buildpacks/registry-index"
 const DefaultRegistryName = "official"
 const defaultRegistryDir = "registry"
 
+// Index is a buildpack registry that buildpacks can be located in
+type Index interface {
+	LocateBuildpack(bp string) (Buildpack, error)
+	Versions(id string) ([]Buildpack, error)
+}
+
 // Cache is a RegistryCache
 type Cache struct {
 	logger      logging.Logger
@@ -48,6 +57,14 @@ type Entry struct {
 	Buildpacks []Buildpack `json:"buildpacks"`
 }
 
+// ID returns the ID of the buildpack the entry is for
+func (e Entry) ID() string {
+	if len(e.Buildpacks) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s", e.Buildpacks[0].Namespace, e.Buildpacks[0].Name)
+}
+
 // NewDefaultRegistryCache creates a new registry cache with default options
 func NewDefaultRegistryCache(logger logging.Logger, home string) (Cache, error) {
 	return NewRegistryCache(logger, home, DefaultRegistryURL)
@@ -91,8 +108,27 @@ func (r *Cache) LocateBuildpack(bp string) (Buildpack, error) {
 	if err != nil {
 		return Buildpack{}, errors.Wrap(err, "reading entry")
 	}
+	return locate(entry, bp, version)
+}
 
+// locate returns the buildpack with the given version among the entries of a buildpack, or its highest version
+// when version is empty. Version ranges resolve to the highest version that satisfies them and isn't yanked.
+func locate(entry Entry, bp, version string) (Buildpack, error) {
 	if len(entry.Buildpacks) > 0 {
+		if buildpack.IsVersionRange(version) {
+			var versions []string
+			for _, b := range entry.Buildpacks {
+				if !b.Yanked {
+					versions = append(versions, b.Version)
+				}
+			}
+			resolved, found := buildpack.ResolveVersionRange(version, versions)
+			if !found {
+				return Buildpack{}, fmt.Errorf("could not find version matching %s for buildpack: %s", version, bp)
+			}
+			version = resolved
+		}
+
 		if version == "" {
 			highestVersion := entry.Buildpacks[0]
 			if len(entry.Buildpacks) > 1 {
@@ -116,6 +152,80 @@ func (r *Cache) LocateBuildpack(bp string) (Buildpack, error) {
 	return Buildpack{}, fmt.Errorf("no entries for buildpack: %s", bp)
 }
 
+// Versions returns the versions of a buildpack stored in registry, highest first
+func (r *Cache) Versions(id string) ([]Buildpack, error) {
+	if err := r.Refresh(); err != nil {
+		return nil, errors.Wrap(err, "refreshing cache")
+	}
+
+	ns, name, err := ParseNamespaceName(id)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := r.readEntry(ns, name)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading entry")
+	}
+	sortVersions(entry.Buildpacks)
+	return entry.Buildpacks, nil
+}
+
+// Search returns the entries of the buildpacks stored in registry whose ID contains term, ignoring case. Entries are
+// ordered by buildpack ID, and their versions highest first.
+func (r *Cache) Search(term string) ([]Entry, error) {
+	if err := r.Refresh(); err != nil {
+		return nil, errors.Wrap(err, "refreshing cache")
+	}
+
+	term = strings.ToLower(term)
+	var entries []Entry
+	err := filepath.WalkDir(r.Root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		ns, name, found := strings.Cut(d.Name(), "_")
+		if !found || !strings.Contains(fmt.Sprintf("%s/%s", ns, name), term) {
+			return nil
+		}
+		if index, err := IndexPath(r.Root, ns, name); err != nil || index != path {
+			return nil
+		}
+
+		entry, err := r.readEntry(ns, name)
+		if err != nil {
+			return err
+		}
+		if len(entry.Buildpacks) > 0 {
+			sortVersions(entry.Buildpacks)
+			entries = append(entries, entry)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "searching %s", style.Symbol(r.Root))
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ID() < entries[j].ID()
+	})
+	return entries, nil
+}
+
+// sortVersions orders the versions of a buildpack highest first
+func sortVersions(buildpacks []Buildpack) {
+	sort.SliceStable(buildpacks, func(i, j int) bool {
+		return semver.Compare(fmt.Sprintf("v%s", buildpacks[i].Version), fmt.Sprintf("v%s", buildpacks[j].Version)) > 0
+	})
+}
+
 // Refresh local Registry Cache
 func (r *Cache) Refresh() error {
 	r.logger.Debugf("Refreshing registry cache for %s/%s", r.url.Host, r.url.Path)
@@ -129,6 +129,25 @@ func testRegistryCache(t *testing.T, when spec.G, it spec.S) {
 			h.AssertEq(t, bp.Version, "1.1.0")
 		})
 
+		it("locates a buildpack with a version range", func() {
+			bp, err := registryCache.LocateBuildpack("example/foo@~1.1")
+			h.AssertNil(t, err)
+			h.AssertEq(t, bp.Version, "1.1.0")
+
+			bp, err = registryCache.LocateBuildpack("example/foo@^1.0")
+			h.AssertNil(t, err)
+			h.AssertEq(t, bp.Version, "1.2.0")
+
+			bp, err = registryCache.LocateBuildpack("example/foo@latest")
+			h.AssertNil(t, err)
+			h.AssertEq(t, bp.Version, "1.2.0")
+		})
+
+		it("returns error if can't find buildpack matching requested version range", func() {
+			_, err := registryCache.LocateBuildpack("example/foo@^2.0")
+			h.AssertError(t, err, "could not find version matching ^2.0 for buildpack: example/foo@^2.0")
+		})
+
 		it("returns error if can't parse buildpack id", func() {
 			_, err := registryCache.LocateBuildpack("quack")
 			h.AssertError(t, err, "parsing buildpacks registry id")
@@ -150,6 +169,73 @@ func testRegistryCache(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 
+	when("#Versions", func() {
+		var (
+			registryCache Cache
+		)
+
+		it.Before(func() {
+			registryCache, err = NewRegistryCache(logger, tmpDir, registryFixture)
+			h.AssertNil(t, err)
+		})
+
+		it("returns the versions of a buildpack, highest first", func() {
+			versions, err := registryCache.Versions("example/foo")
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, len(versions), 3)
+			h.AssertEq(t, versions[0].Version, "1.2.0")
+			h.AssertEq(t, versions[1].Version, "1.1.0")
+			h.AssertEq(t, versions[2].Version, "1.0.0")
+		})
+
+		it("returns error if can't find buildpack with requested id", func() {
+			_, err := registryCache.Versions("example/qu")
+			h.AssertError(t, err, "reading entry")
+		})
+
+		it("returns error if the id doesn't contain a namespace", func() {
+			_, err := registryCache.Versions("quack")
+			h.AssertError(t, err, "does not contain a namespace")
+		})
+	})
+
+	when("#Search", func() {
+		var (
+			registryCache Cache
+		)
+
+		it.Before(func() {
+			registryCache, err = NewRegistryCache(logger, tmpDir, registryFixture)
+			h.AssertNil(t, err)
+		})
+
+		it("returns the entries of all buildpacks when term is empty, ordered by ID", func() {
+			entries, err := registryCache.Search("")
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, len(entries), 2)
+			h.AssertEq(t, entries[0].ID(), "example/foo")
+			h.AssertEq(t, entries[1].ID(), "example/java")
+		})
+
+		it("returns the entries of buildpacks whose ID contains term, ignoring case", func() {
+			entries, err := registryCache.Search("FO")
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, len(entries), 1)
+			h.AssertEq(t, entries[0].ID(), "example/foo")
+			h.AssertEq(t, entries[0].Buildpacks[0].Version, "1.2.0")
+		})
+
+		it("returns no entries when no buildpack matches", func() {
+			entries, err := registryCache.Search("ruby")
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, len(entries), 0)
+		})
+	})
+
 	when("#Refresh", func() {
 		var (
 			registryCache Cache
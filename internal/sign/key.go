@@ -0,0 +1,108 @@
+package sign
+
+import (
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+const (
+	cosignPrivateKeyPEMType   = "ENCRYPTED COSIGN PRIVATE KEY"
+	sigstorePrivateKeyPEMType = "ENCRYPTED SIGSTORE PRIVATE KEY"
+)
+
+// encryptedKey is the contents of an encrypted cosign private key: a PKCS #8 key sealed with nacl/secretbox,
+// using a key derived from the password with scrypt.
+type encryptedKey struct {
+	KDF struct {
+		Name   string `json:"name"`
+		Params struct {
+			N int `json:"N"`
+			R int `json:"r"`
+			P int `json:"p"`
+		} `json:"params"`
+		Salt []byte `json:"salt"`
+	} `json:"kdf"`
+	Cipher struct {
+		Name  string `json:"name"`
+		Nonce []byte `json:"nonce"`
+	} `json:"cipher"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// LoadPrivateKey reads an ECDSA private key from a key file created by `cosign generate-key-pair`, decrypting it
+// with password, or from an unencrypted PEM-encoded PKCS #8 or EC private key.
+func LoadPrivateKey(path string, password []byte) (*ecdsa.PrivateKey, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading signing key")
+	}
+
+	block, _ := pem.Decode(contents)
+	if block == nil {
+		return nil, errors.Errorf("signing key %s is not PEM-encoded", style.Symbol(path))
+	}
+
+	var der []byte
+	switch block.Type {
+	case cosignPrivateKeyPEMType, sigstorePrivateKeyPEMType:
+		if der, err = decrypt(block.Bytes, password); err != nil {
+			return nil, errors.Wrapf(err, "decrypting signing key %s", style.Symbol(path))
+		}
+	case "PRIVATE KEY":
+		der = block.Bytes
+	case "EC PRIVATE KEY":
+		key, err := x509.ParseECPrivateKey(block.Bytes)
+		return key, errors.Wrapf(err, "parsing signing key %s", style.Symbol(path))
+	default:
+		return nil, errors.Errorf("signing key %s has unsupported PEM type %s", style.Symbol(path), style.Symbol(block.Type))
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing signing key %s", style.Symbol(path))
+	}
+	ecdsaKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, errors.Errorf("signing key %s is not an ECDSA key", style.Symbol(path))
+	}
+	return ecdsaKey, nil
+}
+
+func decrypt(contents, password []byte) ([]byte, error) {
+	var key encryptedKey
+	if err := json.Unmarshal(contents, &key); err != nil {
+		return nil, err
+	}
+	if key.KDF.Name != "scrypt" || key.Cipher.Name != "nacl/secretbox" {
+		return nil, errors.Errorf("unsupported encryption %s with %s", style.Symbol(key.KDF.Name), style.Symbol(key.Cipher.Name))
+	}
+	if len(key.Cipher.Nonce) != 24 {
+		return nil, errors.New("invalid nonce")
+	}
+
+	secret, err := scrypt.Key(password, key.KDF.Salt, key.KDF.Params.N, key.KDF.Params.R, key.KDF.Params.P, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		nonce     [24]byte
+		secretKey [32]byte
+	)
+	copy(nonce[:], key.Cipher.Nonce)
+	copy(secretKey[:], secret)
+	der, ok := secretbox.Open(nil, key.Ciphertext, &nonce, &secretKey)
+	if !ok {
+		return nil, errors.New("incorrect password")
+	}
+	return der, nil
+}
@@ -0,0 +1,163 @@
+package sign_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/buildpacks/pack/internal/sign"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestKey(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Key", testKey, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testKey(t *testing.T, when spec.G, it spec.S) {
+	var (
+		assert = h.NewAssertionManager(t)
+		tmpDir string
+		key    *ecdsa.PrivateKey
+	)
+
+	it.Before(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "sign-key")
+		assert.Nil(err)
+		key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		assert.Nil(err)
+	})
+
+	it.After(func() {
+		assert.Nil(os.RemoveAll(tmpDir))
+	})
+
+	writeKey := func(pemType string, contents []byte) string {
+		path := filepath.Join(tmpDir, "cosign.key")
+		assert.Nil(os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: pemType, Bytes: contents}), 0600))
+		return path
+	}
+
+	when("#LoadPrivateKey", func() {
+		when("the key is an encrypted cosign key", func() {
+			var path string
+
+			it.Before(func() {
+				der, err := x509.MarshalPKCS8PrivateKey(key)
+				assert.Nil(err)
+
+				salt, nonce := make([]byte, 32), [24]byte{}
+				_, err = rand.Read(salt)
+				assert.Nil(err)
+				_, err = rand.Read(nonce[:])
+				assert.Nil(err)
+				secret, err := scrypt.Key([]byte("some-password"), salt, 1024, 8, 1, 32)
+				assert.Nil(err)
+				var secretKey [32]byte
+				copy(secretKey[:], secret)
+
+				contents, err := json.Marshal(map[string]interface{}{
+					"kdf":        map[string]interface{}{"name": "scrypt", "params": map[string]int{"N": 1024, "r": 8, "p": 1}, "salt": salt},
+					"cipher":     map[string]interface{}{"name": "nacl/secretbox", "nonce": nonce[:]},
+					"ciphertext": secretbox.Seal(nil, der, &nonce, &secretKey),
+				})
+				assert.Nil(err)
+				path = writeKey("ENCRYPTED SIGSTORE PRIVATE KEY", contents)
+			})
+
+			it("decrypts it with the password", func() {
+				loaded, err := sign.LoadPrivateKey(path, []byte("some-password"))
+				assert.Nil(err)
+				assert.TrueWithMessage(loaded.Equal(key), "expected the loaded key to match")
+			})
+
+			it("fails with the wrong password", func() {
+				_, err := sign.LoadPrivateKey(path, []byte("other-password"))
+				assert.ErrorContains(err, "incorrect password")
+			})
+		})
+
+		it("reads unencrypted PKCS #8 keys", func() {
+			der, err := x509.MarshalPKCS8PrivateKey(key)
+			assert.Nil(err)
+
+			loaded, err := sign.LoadPrivateKey(writeKey("PRIVATE KEY", der), nil)
+			assert.Nil(err)
+			assert.TrueWithMessage(loaded.Equal(key), "expected the loaded key to match")
+		})
+
+		it("reads unencrypted EC keys", func() {
+			der, err := x509.MarshalECPrivateKey(key)
+			assert.Nil(err)
+
+			loaded, err := sign.LoadPrivateKey(writeKey("EC PRIVATE KEY", der), nil)
+			assert.Nil(err)
+			assert.TrueWithMessage(loaded.Equal(key), "expected the loaded key to match")
+		})
+
+		when("the key is not an ECDSA key", func() {
+			it("returns an error", func() {
+				rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+				assert.Nil(err)
+				der, err := x509.MarshalPKCS8PrivateKey(rsaKey)
+				assert.Nil(err)
+
+				path := writeKey("PRIVATE KEY", der)
+				_, err = sign.LoadPrivateKey(path, nil)
+				assert.ErrorContains(err, "is not an ECDSA key")
+			})
+		})
+
+		when("the key is not PEM-encoded", func() {
+			it("returns an error", func() {
+				path := filepath.Join(tmpDir, "cosign.key")
+				assert.Nil(os.WriteFile(path, []byte("not a key"), 0600))
+
+				_, err := sign.LoadPrivateKey(path, nil)
+				assert.ErrorContains(err, "is not PEM-encoded")
+			})
+		})
+	})
+
+	when("#LoadPublicKey", func() {
+		it("reads PEM-encoded ECDSA public keys", func() {
+			der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+			assert.Nil(err)
+			path := filepath.Join(tmpDir, "cosign.pub")
+			assert.Nil(os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600))
+
+			loaded, err := sign.LoadPublicKey(path)
+			assert.Nil(err)
+			assert.TrueWithMessage(loaded.Equal(&key.PublicKey), "expected the loaded key to match")
+		})
+
+		when("the key is not an ECDSA key", func() {
+			it("returns an error", func() {
+				rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+				assert.Nil(err)
+				der, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+				assert.Nil(err)
+				path := filepath.Join(tmpDir, "cosign.pub")
+				assert.Nil(os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600))
+
+				_, err = sign.LoadPublicKey(path)
+				assert.ErrorContains(err, "is not an ECDSA key")
+			})
+		})
+	})
+}
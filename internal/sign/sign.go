@@ -0,0 +1,177 @@
+package sign
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/intoto"
+	"github.com/buildpacks/pack/internal/style"
+)
+
+const (
+	// SimpleSigningMediaType is the media type of the signed payloads in cosign signature images.
+	SimpleSigningMediaType types.MediaType = "application/vnd.dev.cosign.simplesigning.v1+json"
+	// DSSEMediaType is the media type of the DSSE envelopes in cosign attestation images.
+	DSSEMediaType types.MediaType = "application/vnd.dsse.envelope.v1+json"
+
+	// SignatureAnnotation holds the base64-encoded signature of a layer of a cosign signature image.
+	SignatureAnnotation = "dev.cosignproject.cosign/signature"
+	// PredicateTypeAnnotation holds the predicate type of a layer of a cosign attestation image.
+	PredicateTypeAnnotation = "predicateType"
+)
+
+// Signer signs images the way cosign does, storing signatures and attestations in the image repository under the
+// sha256-<digest>.sig and sha256-<digest>.att tags, so they can be verified with `cosign verify` and
+// `cosign verify-attestation`.
+type Signer struct {
+	key      *ecdsa.PrivateKey
+	keychain authn.Keychain
+}
+
+func NewSigner(key *ecdsa.PrivateKey, keychain authn.Keychain) *Signer {
+	return &Signer{key: key, keychain: keychain}
+}
+
+// Sign signs the SHA-256 digest of payload.
+func (s *Signer) Sign(payload []byte) ([]byte, error) {
+	digest := sha256.Sum256(payload)
+	return ecdsa.SignASN1(rand.Reader, s.key, digest[:])
+}
+
+// SignImage signs the image manifest with digest ref.
+func (s *Signer) SignImage(ctx context.Context, ref name.Digest) error {
+	payload, err := json.Marshal(simpleSigningPayload(ref))
+	if err != nil {
+		return err
+	}
+	signature, err := s.Sign(payload)
+	if err != nil {
+		return errors.Wrap(err, "signing image")
+	}
+
+	return s.appendLayer(ctx, tagFor(ref, "sig"), mutate.Addendum{
+		Layer:       static.NewLayer(payload, SimpleSigningMediaType),
+		MediaType:   SimpleSigningMediaType,
+		Annotations: map[string]string{SignatureAnnotation: base64.StdEncoding.EncodeToString(signature)},
+	})
+}
+
+// Attest signs statements about the image manifest with digest ref, as DSSE envelopes.
+func (s *Signer) Attest(ctx context.Context, ref name.Digest, statements ...intoto.Statement) error {
+	for _, statement := range statements {
+		payload, err := json.Marshal(statement)
+		if err != nil {
+			return err
+		}
+		envelope, err := s.envelope(payload)
+		if err != nil {
+			return errors.Wrapf(err, "signing %s attestation", style.Symbol(statement.PredicateType))
+		}
+
+		if err := s.appendLayer(ctx, tagFor(ref, "att"), mutate.Addendum{
+			Layer:     static.NewLayer(envelope, DSSEMediaType),
+			MediaType: DSSEMediaType,
+			Annotations: map[string]string{
+				SignatureAnnotation:     "",
+				PredicateTypeAnnotation: statement.PredicateType,
+			},
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type envelope struct {
+	PayloadType string              `json:"payloadType"`
+	Payload     string              `json:"payload"`
+	Signatures  []envelopeSignature `json:"signatures"`
+}
+
+type envelopeSignature struct {
+	KeyID string `json:"keyid"`
+	Sig   string `json:"sig"`
+}
+
+// envelope wraps an in-toto statement in a signed DSSE envelope
+func (s *Signer) envelope(statement []byte) ([]byte, error) {
+	signature, err := s.Sign(PAE(intoto.PayloadType, statement))
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(envelope{
+		PayloadType: intoto.PayloadType,
+		Payload:     base64.StdEncoding.EncodeToString(statement),
+		Signatures:  []envelopeSignature{{Sig: base64.StdEncoding.EncodeToString(signature)}},
+	})
+}
+
+// PAE is the DSSE pre-authentication encoding of a payload, which is what the signature of an envelope covers.
+func PAE(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload))
+}
+
+// appendLayer adds a layer to the signature or attestation image at tag, creating it when it doesn't exist
+func (s *Signer) appendLayer(ctx context.Context, tag name.Tag, addendum mutate.Addendum) error {
+	options := []remote.Option{remote.WithContext(ctx), remote.WithAuthFromKeychain(s.keychain)}
+
+	base, err := remote.Image(tag, options...)
+	if err != nil {
+		var transportErr *transport.Error
+		if !errors.As(err, &transportErr) || transportErr.StatusCode != 404 {
+			return errors.Wrapf(err, "fetching %s", style.Symbol(tag.Name()))
+		}
+		base = mutate.ConfigMediaType(mutate.MediaType(empty.Image, types.OCIManifestSchema1), types.OCIConfigJSON)
+	}
+
+	img, err := mutate.Append(base, addendum)
+	if err != nil {
+		return err
+	}
+	if err := remote.Write(tag, img, options...); err != nil {
+		return errors.Wrapf(err, "writing %s", style.Symbol(tag.Name()))
+	}
+	return nil
+}
+
+// tagFor returns the tag cosign stores signatures (sig) or attestations (att) of an image manifest under
+func tagFor(ref name.Digest, suffix string) name.Tag {
+	return ref.Context().Tag(strings.Replace(ref.DigestStr(), ":", "-", 1) + "." + suffix)
+}
+
+type simpleSigning struct {
+	Critical struct {
+		Identity struct {
+			DockerReference string `json:"docker-reference"`
+		} `json:"identity"`
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+		Type string `json:"type"`
+	} `json:"critical"`
+	Optional map[string]interface{} `json:"optional"`
+}
+
+func simpleSigningPayload(ref name.Digest) simpleSigning {
+	var payload simpleSigning
+	payload.Critical.Identity.DockerReference = ref.Context().Name()
+	payload.Critical.Image.DockerManifestDigest = ref.DigestStr()
+	payload.Critical.Type = "cosign container image signature"
+	return payload
+}
@@ -0,0 +1,194 @@
+package sign_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/intoto"
+	"github.com/buildpacks/pack/internal/sign"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestSign(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Sign", testSign, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testSign(t *testing.T, when spec.G, it spec.S) {
+	var (
+		assert = h.NewAssertionManager(t)
+		server *httptest.Server
+		key    *ecdsa.PrivateKey
+		signer *sign.Signer
+		ref    name.Digest
+	)
+
+	it.Before(func() {
+		server = httptest.NewServer(registry.New())
+
+		var err error
+		key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		assert.Nil(err)
+		signer = sign.NewSigner(key, authn.DefaultKeychain)
+
+		img, err := random.Image(10, 1)
+		assert.Nil(err)
+		tag, err := name.NewTag(strings.TrimPrefix(server.URL, "http://") + "/some/app:latest")
+		assert.Nil(err)
+		assert.Nil(remote.Write(tag, img))
+		digest, err := img.Digest()
+		assert.Nil(err)
+		ref = tag.Context().Digest(digest.String())
+	})
+
+	it.After(func() {
+		server.Close()
+	})
+
+	verify := func(payload []byte, signature string) {
+		t.Helper()
+		decoded, err := base64.StdEncoding.DecodeString(signature)
+		assert.Nil(err)
+		digest := sha256.Sum256(payload)
+		assert.TrueWithMessage(ecdsa.VerifyASN1(&key.PublicKey, digest[:], decoded), "expected signature to be valid")
+	}
+
+	readLayers := func(tag string) ([][]byte, []map[string]string) {
+		t.Helper()
+		img, err := remote.Image(ref.Context().Tag(tag))
+		assert.Nil(err)
+		manifest, err := img.Manifest()
+		assert.Nil(err)
+		layers, err := img.Layers()
+		assert.Nil(err)
+
+		var (
+			contents    [][]byte
+			annotations []map[string]string
+		)
+		for i, layer := range layers {
+			rc, err := layer.Uncompressed()
+			assert.Nil(err)
+			b, err := io.ReadAll(rc)
+			assert.Nil(err)
+			assert.Nil(rc.Close())
+			contents = append(contents, b)
+			annotations = append(annotations, manifest.Layers[i].Annotations)
+		}
+		return contents, annotations
+	}
+
+	when("#SignImage", func() {
+		it("stores a simple signing payload and its signature under the .sig tag", func() {
+			assert.Nil(signer.SignImage(context.Background(), ref))
+
+			layers, annotations := readLayers(strings.Replace(ref.DigestStr(), ":", "-", 1) + ".sig")
+			assert.Equal(len(layers), 1)
+
+			var payload struct {
+				Critical struct {
+					Identity struct {
+						DockerReference string `json:"docker-reference"`
+					} `json:"identity"`
+					Image struct {
+						DockerManifestDigest string `json:"docker-manifest-digest"`
+					} `json:"image"`
+					Type string `json:"type"`
+				} `json:"critical"`
+			}
+			assert.Nil(json.Unmarshal(layers[0], &payload))
+			assert.Equal(payload.Critical.Identity.DockerReference, ref.Context().Name())
+			assert.Equal(payload.Critical.Image.DockerManifestDigest, ref.DigestStr())
+			assert.Equal(payload.Critical.Type, "cosign container image signature")
+
+			verify(layers[0], annotations[0][sign.SignatureAnnotation])
+		})
+
+		it("appends to existing signatures", func() {
+			assert.Nil(signer.SignImage(context.Background(), ref))
+			assert.Nil(signer.SignImage(context.Background(), ref))
+
+			layers, _ := readLayers(strings.Replace(ref.DigestStr(), ":", "-", 1) + ".sig")
+			assert.Equal(len(layers), 2)
+		})
+	})
+
+	when("#VerifyImage", func() {
+		it("succeeds when the image was signed with one of the keys", func() {
+			assert.Nil(signer.SignImage(context.Background(), ref))
+
+			otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			assert.Nil(err)
+			assert.Nil(sign.VerifyImage(context.Background(), ref, []*ecdsa.PublicKey{&otherKey.PublicKey, &key.PublicKey}, authn.DefaultKeychain))
+		})
+
+		it("fails when the image is not signed", func() {
+			err := sign.VerifyImage(context.Background(), ref, []*ecdsa.PublicKey{&key.PublicKey}, authn.DefaultKeychain)
+			assert.ErrorContains(err, "is not signed")
+		})
+
+		it("fails when the image was signed with another key", func() {
+			assert.Nil(signer.SignImage(context.Background(), ref))
+
+			otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			assert.Nil(err)
+			err = sign.VerifyImage(context.Background(), ref, []*ecdsa.PublicKey{&otherKey.PublicKey}, authn.DefaultKeychain)
+			assert.ErrorContains(err, "has no valid signature for the given public keys")
+		})
+	})
+
+	when("#Attest", func() {
+		it("stores a signed DSSE envelope for each statement under the .att tag", func() {
+			subject := intoto.Subject{Name: ref.Context().Name(), Digest: map[string]string{"sha256": strings.TrimPrefix(ref.DigestStr(), "sha256:")}}
+			assert.Nil(signer.Attest(context.Background(), ref,
+				intoto.NewStatement(subject, intoto.ProvenancePredicateType, map[string]string{"some": "predicate"}),
+				intoto.NewStatement(subject, "https://cyclonedx.org/bom", map[string]string{"bomFormat": "CycloneDX"}),
+			))
+
+			layers, annotations := readLayers(strings.Replace(ref.DigestStr(), ":", "-", 1) + ".att")
+			assert.Equal(len(layers), 2)
+			assert.Equal(annotations[0][sign.PredicateTypeAnnotation], intoto.ProvenancePredicateType)
+			assert.Equal(annotations[1][sign.PredicateTypeAnnotation], "https://cyclonedx.org/bom")
+
+			var envelope struct {
+				PayloadType string `json:"payloadType"`
+				Payload     string `json:"payload"`
+				Signatures  []struct {
+					Sig string `json:"sig"`
+				} `json:"signatures"`
+			}
+			assert.Nil(json.Unmarshal(layers[0], &envelope))
+			assert.Equal(envelope.PayloadType, intoto.PayloadType)
+			assert.Equal(len(envelope.Signatures), 1)
+
+			payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+			assert.Nil(err)
+			verify(sign.PAE(envelope.PayloadType, payload), envelope.Signatures[0].Sig)
+
+			var statement intoto.Statement
+			assert.Nil(json.Unmarshal(payload, &statement))
+			assert.Equal(statement.Type, intoto.StatementType)
+			assert.Equal(statement.PredicateType, intoto.ProvenancePredicateType)
+			assert.Equal(statement.Subject, []intoto.Subject{subject})
+		})
+	})
+}
@@ -0,0 +1,108 @@
+package sign
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"os"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// LoadPublicKey reads a PEM-encoded ECDSA public key, such as the cosign.pub file created by `cosign generate-key-pair`.
+func LoadPublicKey(path string) (*ecdsa.PublicKey, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading public key")
+	}
+
+	block, _ := pem.Decode(contents)
+	if block == nil {
+		return nil, errors.Errorf("public key %s is not PEM-encoded", style.Symbol(path))
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing public key %s", style.Symbol(path))
+	}
+	ecdsaKey, ok := key.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.Errorf("public key %s is not an ECDSA key", style.Symbol(path))
+	}
+	return ecdsaKey, nil
+}
+
+// VerifyImage checks that the image manifest with digest ref has a cosign signature made with one of keys.
+func VerifyImage(ctx context.Context, ref name.Digest, keys []*ecdsa.PublicKey, keychain authn.Keychain) error {
+	tag := tagFor(ref, "sig")
+	sigImage, err := remote.Image(tag, remote.WithContext(ctx), remote.WithAuthFromKeychain(keychain))
+	if err != nil {
+		var transportErr *transport.Error
+		if errors.As(err, &transportErr) && transportErr.StatusCode == 404 {
+			return errors.Errorf("image %s is not signed", style.Symbol(ref.Name()))
+		}
+		return errors.Wrapf(err, "fetching signatures of %s", style.Symbol(ref.Name()))
+	}
+
+	manifest, err := sigImage.Manifest()
+	if err != nil {
+		return errors.Wrapf(err, "reading signatures of %s", style.Symbol(ref.Name()))
+	}
+	for _, descriptor := range manifest.Layers {
+		signature, err := base64.StdEncoding.DecodeString(descriptor.Annotations[SignatureAnnotation])
+		if err != nil || len(signature) == 0 {
+			continue
+		}
+		layer, err := sigImage.LayerByDigest(descriptor.Digest)
+		if err != nil {
+			return errors.Wrapf(err, "reading signatures of %s", style.Symbol(ref.Name()))
+		}
+		payload, err := readLayer(layer.Uncompressed)
+		if err != nil {
+			return errors.Wrapf(err, "reading signatures of %s", style.Symbol(ref.Name()))
+		}
+
+		if signedBy(payload, signature, keys) && signs(payload, ref) {
+			return nil
+		}
+	}
+	return errors.Errorf("image %s has no valid signature for the given public keys", style.Symbol(ref.Name()))
+}
+
+func readLayer(open func() (io.ReadCloser, error)) ([]byte, error) {
+	rc, err := open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+func signedBy(payload, signature []byte, keys []*ecdsa.PublicKey) bool {
+	digest := sha256.Sum256(payload)
+	for _, key := range keys {
+		if ecdsa.VerifyASN1(key, digest[:], signature) {
+			return true
+		}
+	}
+	return false
+}
+
+// signs reports whether a simple signing payload is about the image manifest with digest ref
+func signs(payload []byte, ref name.Digest) bool {
+	var signed simpleSigning
+	if err := json.Unmarshal(payload, &signed); err != nil {
+		return false
+	}
+	return signed.Critical.Image.DockerManifestDigest == ref.DigestStr()
+}
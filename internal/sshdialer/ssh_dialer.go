@@ -37,21 +37,10 @@ type Config struct {
 const defaultSSHPort = "22"
 
 func NewDialContext(url *urlPkg.URL, config Config) (func(ctx context.Context, network, addr string) (net.Conn, error), error) {
-	sshConfig, err := NewSSHClientConfig(url, config)
+	sshClient, err := NewClient(url, config)
 	if err != nil {
 		return nil, err
 	}
-
-	port := url.Port()
-	if port == "" {
-		port = defaultSSHPort
-	}
-	host := url.Hostname()
-
-	sshClient, err := ssh.Dial("tcp", net.JoinHostPort(host, port), sshConfig)
-	if err != nil {
-		return nil, fmt.Errorf("failed to dial ssh: %w", err)
-	}
 	defer func() {
 		if sshClient != nil {
 			sshClient.Close()
@@ -93,6 +82,26 @@ func NewDialContext(url *urlPkg.URL, config Config) (func(ctx context.Context, n
 	return dialContext, nil
 }
 
+// NewClient connects to the SSH server at url, authenticating as described by config.
+func NewClient(url *urlPkg.URL, config Config) (*ssh.Client, error) {
+	sshConfig, err := NewSSHClientConfig(url, config)
+	if err != nil {
+		return nil, err
+	}
+
+	port := url.Port()
+	if port == "" {
+		port = defaultSSHPort
+	}
+	host := url.Hostname()
+
+	sshClient, err := ssh.Dial("tcp", net.JoinHostPort(host, port), sshConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial ssh: %w", err)
+	}
+	return sshClient, nil
+}
+
 type dialer struct {
 	sshClient *ssh.Client
 	network   string
@@ -0,0 +1,127 @@
+package watch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Watcher reports changes to the files in a directory.
+//
+// The directory is polled, rather than relying on filesystem notifications, so that changes are seen the same way
+// on every platform, including for directories shared with a VM or mounted over the network.
+type Watcher struct {
+	dir      string
+	filter   func(string) bool
+	interval time.Duration
+	debounce time.Duration
+	files    map[string]fileState
+}
+
+type fileState struct {
+	modTime time.Time
+	size    int64
+	mode    os.FileMode
+}
+
+// NewWatcher returns a Watcher for dir, taking a snapshot of its current contents. Only paths for which filter
+// returns true are watched; filter receives paths relative to dir, and may be nil. The directory is scanned
+// every interval, and changes are reported once none have been seen for the debounce period.
+func NewWatcher(dir string, filter func(string) bool, interval, debounce time.Duration) (*Watcher, error) {
+	w := &Watcher{
+		dir:      dir,
+		filter:   filter,
+		interval: interval,
+		debounce: debounce,
+	}
+
+	files, err := w.scan()
+	if err != nil {
+		return nil, err
+	}
+	w.files = files
+	return w, nil
+}
+
+// Next blocks until files have been added, modified or removed since the last call, and returns their paths
+// relative to the watched directory. It returns early with the context's error if the context is done.
+func (w *Watcher) Next(ctx context.Context) ([]string, error) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	var (
+		changed    = map[string]bool{}
+		lastChange time.Time
+	)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case now := <-ticker.C:
+			files, err := w.scan()
+			if err != nil {
+				return nil, err
+			}
+
+			if diff := compare(w.files, files); len(diff) > 0 {
+				for _, path := range diff {
+					changed[path] = true
+				}
+				w.files = files
+				lastChange = now
+				continue
+			}
+
+			if len(changed) > 0 && now.Sub(lastChange) >= w.debounce {
+				var paths []string
+				for path := range changed {
+					paths = append(paths, path)
+				}
+				sort.Strings(paths)
+				return paths, nil
+			}
+		}
+	}
+}
+
+func (w *Watcher) scan() (map[string]fileState, error) {
+	files := map[string]fileState{}
+	err := filepath.Walk(w.dir, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			// files may be removed while the directory is being walked
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+
+		relPath, err := filepath.Rel(w.dir, path)
+		if err != nil {
+			return err
+		}
+		if relPath == "." || (w.filter != nil && !w.filter(relPath)) {
+			return nil
+		}
+
+		files[relPath] = fileState{modTime: fi.ModTime(), size: fi.Size(), mode: fi.Mode()}
+		return nil
+	})
+	return files, err
+}
+
+func compare(before, after map[string]fileState) []string {
+	var changed []string
+	for path, state := range after {
+		if prev, ok := before[path]; !ok || prev != state {
+			changed = append(changed, path)
+		}
+	}
+	for path := range before {
+		if _, ok := after[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+	return changed
+}
@@ -0,0 +1,105 @@
+package watch_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/watch"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestWatch(t *testing.T) {
+	spec.Run(t, "Watch", testWatch, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testWatch(t *testing.T, when spec.G, it spec.S) {
+	var (
+		tmpDir string
+		ctx    context.Context
+		cancel context.CancelFunc
+	)
+
+	var writeFile = func(path, contents string) {
+		path = filepath.Join(tmpDir, path)
+		h.AssertNil(t, os.MkdirAll(filepath.Dir(path), 0755))
+		h.AssertNil(t, os.WriteFile(path, []byte(contents), 0600))
+		// ensure the modification time changes on filesystems with coarse timestamps
+		future := time.Now().Add(time.Minute)
+		h.AssertNil(t, os.Chtimes(path, future, future))
+	}
+
+	it.Before(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "pack.watch.test")
+		h.AssertNil(t, err)
+		ctx, cancel = context.WithTimeout(context.Background(), 10*time.Second)
+
+		writeFile("main.go", "package main")
+		writeFile("vendor/lib.go", "package lib")
+	})
+
+	it.After(func() {
+		cancel()
+		h.AssertNil(t, os.RemoveAll(tmpDir))
+	})
+
+	when("#Next", func() {
+		it("returns the files that were added, modified or removed", func() {
+			watcher, err := watch.NewWatcher(tmpDir, nil, 10*time.Millisecond, 50*time.Millisecond)
+			h.AssertNil(t, err)
+
+			writeFile("main.go", "package main // changed")
+			writeFile("README.md", "some-readme")
+			h.AssertNil(t, os.Remove(filepath.Join(tmpDir, "vendor", "lib.go")))
+
+			changed, err := watcher.Next(ctx)
+			h.AssertNil(t, err)
+			h.AssertSliceContains(t, changed, "README.md", "main.go", filepath.Join("vendor", "lib.go"))
+		})
+
+		it("ignores files excluded by the filter", func() {
+			watcher, err := watch.NewWatcher(tmpDir, func(path string) bool {
+				return !strings.HasPrefix(path, "vendor")
+			}, 10*time.Millisecond, 50*time.Millisecond)
+			h.AssertNil(t, err)
+
+			writeFile("vendor/lib.go", "package lib // changed")
+			writeFile("main.go", "package main // changed")
+
+			changed, err := watcher.Next(ctx)
+			h.AssertNil(t, err)
+			h.AssertEq(t, changed, []string{"main.go"})
+		})
+
+		it("waits for changes to settle", func() {
+			watcher, err := watch.NewWatcher(tmpDir, nil, 10*time.Millisecond, 200*time.Millisecond)
+			h.AssertNil(t, err)
+
+			writeFile("main.go", "package main // changed")
+			go func() {
+				time.Sleep(100 * time.Millisecond)
+				writeFile("other.go", "package main")
+			}()
+
+			changed, err := watcher.Next(ctx)
+			h.AssertNil(t, err)
+			h.AssertEq(t, changed, []string{"main.go", "other.go"})
+		})
+
+		it("returns when the context is done", func() {
+			watcher, err := watch.NewWatcher(tmpDir, nil, 10*time.Millisecond, 50*time.Millisecond)
+			h.AssertNil(t, err)
+
+			cancel()
+			_, err = watcher.Next(ctx)
+			h.AssertSameInstance(t, err, context.Canceled)
+		})
+	})
+}
@@ -28,6 +28,11 @@ type Logger interface {
 	Writer() io.Writer
 }
 
+// eventLogger is implemented by loggers that may emit structured events instead of text
+type eventLogger interface {
+	EventsEnabled() bool
+}
+
 type Downloader interface {
 	Download(ctx context.Context, pathOrURI string) (Blob, error)
 }
@@ -153,6 +158,10 @@ func (d *downloader) downloadAsStream(ctx context.Context, uri string, etag stri
 
 	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
 		d.logger.Infof("Downloading from %s", style.Symbol(uri))
+		// a progress bar would break the stream of JSON lines of structured logs
+		if el, ok := d.logger.(eventLogger); ok && el.EventsEnabled() {
+			return resp.Body, resp.Header.Get("Etag"), nil
+		}
 		return withProgress(d.logger.Writer(), resp.Body, resp.ContentLength), resp.Header.Get("Etag"), nil
 	}
 
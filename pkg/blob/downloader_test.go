@@ -1,12 +1,15 @@
 package blob_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/heroku/color"
@@ -17,6 +20,7 @@ import (
 	"github.com/buildpacks/pack/internal/paths"
 	"github.com/buildpacks/pack/pkg/archive"
 	"github.com/buildpacks/pack/pkg/blob"
+	"github.com/buildpacks/pack/pkg/logging"
 	h "github.com/buildpacks/pack/testhelpers"
 )
 
@@ -124,6 +128,21 @@ func testDownloader(t *testing.T, when spec.G, it spec.S) {
 					assertBlob(t, b)
 				})
 
+				it("doesn't draw a progress bar when logging JSON", func() {
+					var out bytes.Buffer
+					jsonLogger := logging.NewLogWithWriters(&out, &out)
+					jsonLogger.WantJSON(true)
+					subject = blob.NewDownloader(jsonLogger, cacheDir)
+
+					b, err := subject.Download(context.TODO(), uri)
+					h.AssertNil(t, err)
+					assertBlob(t, b)
+
+					for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+						h.AssertTrue(t, json.Valid([]byte(line)))
+					}
+				})
+
 				it("uses cache from a 'http(s)://' URI tgz", func() {
 					b, err := subject.Download(context.TODO(), uri)
 					h.AssertNil(t, err)
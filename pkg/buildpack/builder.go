@@ -353,44 +353,33 @@ func (b *PackageBuilder) resolvedStacks() []dist.Stack {
 }
 
 func (b *PackageBuilder) SaveAsFile(path, imageOS string, labels map[string]string) error {
-	if err := b.validate(); err != nil {
-		return err
-	}
+	return saveAsLayoutFile(path, []TargetPackage{{Target: dist.Target{OS: imageOS}, Builder: b}}, labels)
+}
 
-	layoutImage, err := newLayoutImage(imageOS)
-	if err != nil {
-		return errors.Wrap(err, "creating layout image")
-	}
+// TargetPackage is a PackageBuilder for the buildpackage of a single target.
+type TargetPackage struct {
+	Target  dist.Target
+	Builder *PackageBuilder
+}
 
-	for labelKey, labelValue := range labels {
-		err = layoutImage.SetLabel(labelKey, labelValue)
-		if err != nil {
-			return errors.Wrapf(err, "adding label %s=%s", labelKey, labelValue)
+// SaveAsMultiPlatformFile writes the buildpackages of several targets to a single file. The file
+// is an OCI layout whose index references the image of every target along with its platform.
+func SaveAsMultiPlatformFile(path string, packages []TargetPackage, labels map[string]string) error {
+	for _, pkg := range packages {
+		if pkg.Target.Arch == "" {
+			return errors.Errorf("missing architecture for target %s", style.Symbol(pkg.Target.OS))
 		}
 	}
+	return saveAsLayoutFile(path, packages, labels)
+}
 
-	tempDirName := ""
-	if b.buildpack != nil {
-		tempDirName = "package-buildpack"
-	} else if b.extension != nil {
-		tempDirName = "extension-buildpack"
-	}
-
-	tmpDir, err := os.MkdirTemp("", tempDirName)
+func saveAsLayoutFile(path string, packages []TargetPackage, labels map[string]string) error {
+	tmpDir, err := os.MkdirTemp("", "package-layout")
 	if err != nil {
 		return err
 	}
 	defer os.RemoveAll(tmpDir)
 
-	if b.buildpack != nil {
-		if err := b.finalizeImage(layoutImage, tmpDir); err != nil {
-			return err
-		}
-	} else if b.extension != nil {
-		if err := b.finalizeExtensionImage(layoutImage, tmpDir); err != nil {
-			return err
-		}
-	}
 	layoutDir, err := os.MkdirTemp(tmpDir, "oci-layout")
 	if err != nil {
 		return errors.Wrap(err, "creating oci-layout temp dir")
@@ -401,8 +390,29 @@ func (b *PackageBuilder) SaveAsFile(path, imageOS string, labels map[string]stri
 		return errors.Wrap(err, "writing index")
 	}
 
-	if err := p.AppendImage(layoutImage); err != nil {
-		return errors.Wrap(err, "writing layout")
+	for _, pkg := range packages {
+		// layers are read from the module dir when appended to the layout
+		moduleDir, err := os.MkdirTemp(tmpDir, pkg.Builder.tempDirName())
+		if err != nil {
+			return err
+		}
+
+		img, err := pkg.Builder.layoutImage(pkg.Target, labels, moduleDir)
+		if err != nil {
+			return err
+		}
+
+		var layoutOpts []layout.Option
+		if pkg.Target.Arch != "" {
+			layoutOpts = append(layoutOpts, layout.WithPlatform(v1.Platform{
+				OS:           pkg.Target.OS,
+				Architecture: pkg.Target.Arch,
+				Variant:      pkg.Target.ArchVariant,
+			}))
+		}
+		if err := p.AppendImage(img, layoutOpts...); err != nil {
+			return errors.Wrap(err, "writing layout")
+		}
 	}
 
 	outputFile, err := os.Create(path)
@@ -417,7 +427,45 @@ func (b *PackageBuilder) SaveAsFile(path, imageOS string, labels map[string]stri
 	return archive.WriteDirToTar(tw, layoutDir, "/", 0, 0, 0755, true, false, nil)
 }
 
-func newLayoutImage(imageOS string) (*layoutImage, error) {
+func (b *PackageBuilder) layoutImage(target dist.Target, labels map[string]string, tmpDir string) (*layoutImage, error) {
+	if err := b.validate(); err != nil {
+		return nil, err
+	}
+
+	layoutImage, err := newLayoutImage(target)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating layout image")
+	}
+
+	for labelKey, labelValue := range labels {
+		err = layoutImage.SetLabel(labelKey, labelValue)
+		if err != nil {
+			return nil, errors.Wrapf(err, "adding label %s=%s", labelKey, labelValue)
+		}
+	}
+
+	if b.buildpack != nil {
+		if err := b.finalizeImage(layoutImage, tmpDir); err != nil {
+			return nil, err
+		}
+	} else if b.extension != nil {
+		if err := b.finalizeExtensionImage(layoutImage, tmpDir); err != nil {
+			return nil, err
+		}
+	}
+	return layoutImage, nil
+}
+
+func (b *PackageBuilder) tempDirName() string {
+	if b.buildpack != nil {
+		return "package-buildpack"
+	} else if b.extension != nil {
+		return "extension-buildpack"
+	}
+	return ""
+}
+
+func newLayoutImage(target dist.Target) (*layoutImage, error) {
 	i := empty.Image
 
 	configFile, err := i.ConfigFile()
@@ -425,13 +473,17 @@ func newLayoutImage(imageOS string) (*layoutImage, error) {
 		return nil, err
 	}
 
-	configFile.OS = imageOS
+	configFile.OS = target.OS
+	if target.Arch != "" {
+		configFile.Architecture = target.Arch
+		configFile.Variant = target.ArchVariant
+	}
 	i, err = mutate.ConfigFile(i, configFile)
 	if err != nil {
 		return nil, err
 	}
 
-	if imageOS == "windows" {
+	if target.OS == "windows" {
 		opener := func() (io.ReadCloser, error) {
 			reader, err := layer.WindowsBaseLayer()
 			return io.NopCloser(reader), err
@@ -452,15 +504,32 @@ func newLayoutImage(imageOS string) (*layoutImage, error) {
 }
 
 func (b *PackageBuilder) SaveAsImage(repoName string, publish bool, imageOS string, labels map[string]string) (imgutil.Image, error) {
+	return b.SaveAsTargetImage(repoName, publish, dist.Target{OS: imageOS}, labels)
+}
+
+// SaveAsTargetImage saves the buildpackage as an image for the given target. The architecture
+// of the image is only set when the target declares one.
+func (b *PackageBuilder) SaveAsTargetImage(repoName string, publish bool, target dist.Target, labels map[string]string) (imgutil.Image, error) {
 	if err := b.validate(); err != nil {
 		return nil, err
 	}
 
-	image, err := b.imageFactory.NewImage(repoName, !publish, imageOS)
+	image, err := b.imageFactory.NewImage(repoName, !publish, target.OS)
 	if err != nil {
 		return nil, errors.Wrapf(err, "creating image")
 	}
 
+	if target.Arch != "" {
+		if err := image.SetArchitecture(target.Arch); err != nil {
+			return nil, errors.Wrapf(err, "setting architecture %s", target.Arch)
+		}
+		if target.ArchVariant != "" {
+			if err := image.SetVariant(target.ArchVariant); err != nil {
+				return nil, errors.Wrapf(err, "setting variant %s", target.ArchVariant)
+			}
+		}
+	}
+
 	for labelKey, labelValue := range labels {
 		err = image.SetLabel(labelKey, labelValue)
 		if err != nil {
@@ -468,14 +537,7 @@ func (b *PackageBuilder) SaveAsImage(repoName string, publish bool, imageOS stri
 		}
 	}
 
-	tempDirName := ""
-	if b.buildpack != nil {
-		tempDirName = "package-buildpack"
-	} else if b.extension != nil {
-		tempDirName = "extension-buildpack"
-	}
-
-	tmpDir, err := os.MkdirTemp("", tempDirName)
+	tmpDir, err := os.MkdirTemp("", b.tempDirName())
 	if err != nil {
 		return nil, err
 	}
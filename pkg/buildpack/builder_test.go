@@ -1100,6 +1100,80 @@ func testPackageBuilder(t *testing.T, when spec.G, it spec.S) {
 			)
 		})
 	})
+	when("#SaveAsTargetImage", func() {
+		it("sets the platform of the image", func() {
+			buildpack1, err := ifakes.NewFakeBuildpack(dist.BuildpackDescriptor{
+				WithAPI:  api.MustParse("0.2"),
+				WithInfo: dist.ModuleInfo{ID: "bp.1.id", Version: "bp.1.version"},
+			}, 0644)
+			h.AssertNil(t, err)
+
+			fakePackageImage := fakes.NewImage("some/package", "", nil)
+			imageFactory := testmocks.NewMockImageFactory(mockController)
+			imageFactory.EXPECT().NewImage("some/package", false, "linux").Return(fakePackageImage, nil)
+
+			builder := buildpack.NewBuilder(imageFactory)
+			builder.SetBuildpack(buildpack1)
+
+			_, err = builder.SaveAsTargetImage("some/package", true, dist.Target{OS: "linux", Arch: "arm", ArchVariant: "v7"}, map[string]string{})
+			h.AssertNil(t, err)
+
+			arch, err := fakePackageImage.Architecture()
+			h.AssertNil(t, err)
+			h.AssertEq(t, arch, "arm")
+			variant, err := fakePackageImage.Variant()
+			h.AssertNil(t, err)
+			h.AssertEq(t, variant, "v7")
+		})
+	})
+
+	when("#SaveAsMultiPlatformFile", func() {
+		it("writes an index referencing an image per target", func() {
+			var packages []buildpack.TargetPackage
+			for _, arch := range []string{"amd64", "arm64"} {
+				bp, err := ifakes.NewFakeBuildpack(dist.BuildpackDescriptor{
+					WithAPI:     api.MustParse("0.2"),
+					WithInfo:    dist.ModuleInfo{ID: "bp.1.id", Version: "bp.1.version"},
+					WithTargets: []dist.Target{{OS: "linux", Arch: arch}},
+				}, 0644)
+				h.AssertNil(t, err)
+
+				builder := buildpack.NewBuilder(mockImageFactory(""))
+				builder.SetBuildpack(bp)
+				packages = append(packages, buildpack.TargetPackage{Target: dist.Target{OS: "linux", Arch: arch}, Builder: builder})
+			}
+
+			outputFile := filepath.Join(tmpDir, fmt.Sprintf("package-%s.cnb", h.RandString(10)))
+			h.AssertNil(t, buildpack.SaveAsMultiPlatformFile(outputFile, packages, map[string]string{}))
+
+			h.AssertOnTarEntry(t, outputFile, "/index.json",
+				func(t *testing.T, header *tar.Header, data []byte) {
+					index := v1.Index{}
+					h.AssertNil(t, json.Unmarshal(data, &index))
+					h.AssertEq(t, len(index.Manifests), 2)
+					h.AssertEq(t, index.Manifests[0].Platform.Architecture, "amd64")
+					h.AssertEq(t, index.Manifests[1].Platform.Architecture, "arm64")
+
+					h.AssertOnTarEntry(t, outputFile,
+						"/blobs/sha256/"+index.Manifests[1].Digest.Hex(),
+						func(t *testing.T, header *tar.Header, data []byte) {
+							manifest := v1.Manifest{}
+							h.AssertNil(t, json.Unmarshal(data, &manifest))
+							h.AssertOnTarEntry(t, outputFile,
+								"/blobs/sha256/"+manifest.Config.Digest.Hex(),
+								h.ContentContains(`"architecture":"arm64"`),
+								h.ContentContains(`"os":"linux"`),
+							)
+						})
+				})
+		})
+
+		it("requires an architecture for every target", func() {
+			builder := buildpack.NewBuilder(mockImageFactory(""))
+			err := buildpack.SaveAsMultiPlatformFile(filepath.Join(tmpDir, "package.cnb"), []buildpack.TargetPackage{{Target: dist.Target{OS: "linux"}, Builder: builder}}, nil)
+			h.AssertError(t, err, "missing architecture for target 'linux'")
+		})
+	})
 }
 
 func computeLayerSHA(reader io.ReadCloser) (string, error) {
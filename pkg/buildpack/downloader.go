@@ -42,19 +42,34 @@ type RegistryResolver interface {
 }
 
 type buildpackDownloader struct {
-	logger           Logger
-	imageFetcher     ImageFetcher
-	downloader       Downloader
-	registryResolver RegistryResolver
+	logger               Logger
+	imageFetcher         ImageFetcher
+	downloader           Downloader
+	registryResolver     RegistryResolver
+	verificationPolicies []image.VerificationPolicy
 }
 
-func NewDownloader(logger Logger, imageFetcher ImageFetcher, downloader Downloader, registryResolver RegistryResolver) *buildpackDownloader { //nolint:revive,gosimple
-	return &buildpackDownloader{
+type DownloaderOption func(*buildpackDownloader)
+
+// WithVerificationPolicies refuses buildpack archives that don't satisfy the policy for their URI, if any. Images are
+// verified by the image fetcher.
+func WithVerificationPolicies(policies []image.VerificationPolicy) DownloaderOption {
+	return func(d *buildpackDownloader) {
+		d.verificationPolicies = policies
+	}
+}
+
+func NewDownloader(logger Logger, imageFetcher ImageFetcher, downloader Downloader, registryResolver RegistryResolver, opts ...DownloaderOption) *buildpackDownloader { //nolint:revive,gosimple
+	d := &buildpackDownloader{
 		logger:           logger,
 		imageFetcher:     imageFetcher,
 		downloader:       downloader,
 		registryResolver: registryResolver,
 	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
 }
 
 type DownloadOptions struct {
@@ -140,6 +155,9 @@ func (c *buildpackDownloader) Download(ctx context.Context, moduleURI string, op
 		if err != nil {
 			return nil, nil, errors.Wrapf(err, "downloading %s from %s", kind, style.Symbol(moduleURI))
 		}
+		if err := c.verifyArchive(moduleURI, blob); err != nil {
+			return nil, nil, err
+		}
 
 		mainBP, depBPs, err = decomposeBlob(blob, kind, opts.ImageOS, c.logger)
 		if err != nil {
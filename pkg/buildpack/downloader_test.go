@@ -3,9 +3,12 @@ package buildpack_test
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/buildpacks/imgutil/fakes"
@@ -301,6 +304,47 @@ func testBuildpackDownloader(t *testing.T, when spec.G, it spec.S) {
 				h.AssertEq(t, mainBP.Descriptor().Info().ID, "bp.one")
 			})
 
+			when("the archive has a verification policy", func() {
+				var (
+					buildpackURI string
+					digest       string
+				)
+
+				it.Before(func() {
+					buildpackPath := filepath.Join("testdata", "buildpack")
+					buildpackURI, _ = paths.FilePathToURI(buildpackPath, "")
+					mockDownloader.EXPECT().Download(gomock.Any(), buildpackURI).Return(blob.NewBlob(buildpackPath), nil).AnyTimes()
+
+					rc, err := blob.NewBlob(buildpackPath).Open()
+					h.AssertNil(t, err)
+					defer rc.Close()
+					hash := sha256.New()
+					_, err = io.Copy(hash, rc)
+					h.AssertNil(t, err)
+					digest = fmt.Sprintf("sha256:%x", hash.Sum(nil))
+				})
+
+				download := func(policy image.VerificationPolicy) error {
+					buildpackDownloader = buildpack.NewDownloader(logger, mockImageFetcher, mockDownloader, mockRegistryResolver, buildpack.WithVerificationPolicies([]image.VerificationPolicy{policy}))
+					_, _, err := buildpackDownloader.Download(context.TODO(), buildpackURI, downloadOptions)
+					return err
+				}
+
+				it("retrieves the archive when its digest is pinned", func() {
+					h.AssertNil(t, download(image.VerificationPolicy{URI: buildpackURI, Digests: []string{digest}}))
+				})
+
+				it("refuses the archive when its digest is not pinned", func() {
+					err := download(image.VerificationPolicy{URI: buildpackURI, Digests: []string{"sha256:" + strings.Repeat("a", 64)}})
+					h.AssertError(t, err, fmt.Sprintf("archive '%s' has digest '%s', which is not pinned by its verification policy", buildpackURI, digest))
+				})
+
+				it("refuses policies requiring public keys", func() {
+					err := download(image.VerificationPolicy{URI: buildpackURI, PublicKeys: []string{"cosign.pub"}})
+					h.AssertError(t, err, "archives can only be pinned to digests")
+				})
+			})
+
 			when("kind == extension", func() {
 				it("succeeds", func() {
 					extensionPath := filepath.Join("testdata", "extension")
@@ -37,6 +37,8 @@ var (
 	// https://semver.org/#is-there-a-suggested-regular-expression-regex-to-check-a-semver-string
 	semverPattern   = `(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?`
 	registryPattern = regexp.MustCompile(`^[a-z0-9\-\.]+\/[a-z0-9\-\.]+(?:@` + semverPattern + `)?$`)
+	// registryRangePattern matches registry IDs with any version, which must then be a version range
+	registryRangePattern = regexp.MustCompile(`^[a-z0-9\-\.]+\/[a-z0-9\-\.]+@(.+)$`)
 )
 
 func (l LocatorType) String() string {
@@ -120,11 +122,19 @@ func canBePackageRef(locator string) bool {
 }
 
 func canBeRegistryRef(locator string) bool {
-	return registryPattern.MatchString(locator)
+	if registryPattern.MatchString(locator) {
+		return true
+	}
+	matches := registryRangePattern.FindStringSubmatch(locator)
+	return matches != nil && IsVersionRange(matches[1])
 }
 
 func isFoundInBuilder(locator string, candidates []dist.ModuleInfo) bool {
 	id, version := ParseIDLocator(locator)
+	if IsVersionRange(version) {
+		_, found := ResolveVersionRange(version, versionsOf(id, candidates))
+		return found
+	}
 	for _, c := range candidates {
 		if id == c.ID && (version == "" || version == c.Version) {
 			return true
@@ -133,6 +143,26 @@ func isFoundInBuilder(locator string, candidates []dist.ModuleInfo) bool {
 	return false
 }
 
+// ResolveBuilderVersion returns the version of the builder buildpack designated by an ID locator, resolving version
+// ranges against the versions of the buildpack on the builder.
+func ResolveBuilderVersion(locator string, candidates []dist.ModuleInfo) (id string, version string) {
+	id, version = ParseIDLocator(locator)
+	if IsVersionRange(version) {
+		version, _ = ResolveVersionRange(version, versionsOf(id, candidates))
+	}
+	return id, version
+}
+
+func versionsOf(id string, candidates []dist.ModuleInfo) []string {
+	var versions []string
+	for _, c := range candidates {
+		if c.ID == id {
+			versions = append(versions, c.Version)
+		}
+	}
+	return versions
+}
+
 func isLocalFile(locator, relativeBaseDir string) bool {
 	if !filepath.IsAbs(locator) {
 		locator = filepath.Join(relativeBaseDir, locator)
@@ -155,6 +155,33 @@ func testGetLocatorType(t *testing.T, when spec.G, it spec.S) {
 			locator:      "example/registry-cnb",
 			expectedType: buildpack.RegistryLocator,
 		},
+		{
+			locator:      "example/foo@^1.2",
+			expectedType: buildpack.RegistryLocator,
+		},
+		{
+			locator:      "example/foo@~2.0",
+			expectedType: buildpack.RegistryLocator,
+		},
+		{
+			locator:      "urn:cnb:registry:example/foo@latest",
+			expectedType: buildpack.RegistryLocator,
+		},
+		{
+			locator:      "urn:cnb:builder:some-bp@^1.2",
+			builderBPs:   []dist.ModuleInfo{{ID: "some-bp", Version: "1.3.0"}},
+			expectedType: buildpack.IDLocator,
+		},
+		{
+			locator:     "urn:cnb:builder:some-bp@~1.2",
+			builderBPs:  []dist.ModuleInfo{{ID: "some-bp", Version: "1.3.0"}},
+			expectedErr: "'urn:cnb:builder:some-bp@~1.2' is not a valid identifier",
+		},
+		{
+			locator:      "some-bp@latest",
+			builderBPs:   []dist.ModuleInfo{{ID: "some-bp", Version: "1.3.0"}},
+			expectedType: buildpack.IDLocator,
+		},
 		{
 			locator:      "cnbs/sample-package@hello-universe",
 			expectedType: buildpack.InvalidLocator,
@@ -0,0 +1,61 @@
+package buildpack
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/blob"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+// verifyArchive checks a downloaded buildpack archive against the verification policy for its URI, if any
+func (c *buildpackDownloader) verifyArchive(uri string, b blob.Blob) error {
+	var policy *image.VerificationPolicy
+	for i := range c.verificationPolicies {
+		if c.verificationPolicies[i].URI == uri {
+			policy = &c.verificationPolicies[i]
+			break
+		}
+	}
+	if policy == nil {
+		return nil
+	}
+
+	if len(policy.PublicKeys) > 0 {
+		return errors.Errorf("verification policy for %s cannot require public keys, archives can only be pinned to digests", style.Symbol(uri))
+	}
+	if len(policy.Digests) == 0 {
+		return errors.Errorf("verification policy for %s requires digests", style.Symbol(uri))
+	}
+
+	digest, err := archiveDigest(b)
+	if err != nil {
+		return errors.Wrapf(err, "reading digest of %s", style.Symbol(uri))
+	}
+	for _, pinned := range policy.Digests {
+		if pinned == digest {
+			c.logger.Debugf("Verified %s as %s", style.Symbol(uri), style.Symbol(digest))
+			return nil
+		}
+	}
+	return errors.Errorf("archive %s has digest %s, which is not pinned by its verification policy", style.Symbol(uri), style.Symbol(digest))
+}
+
+// archiveDigest returns the digest of the uncompressed tar of a buildpack archive or directory
+func archiveDigest(b blob.Blob) (string, error) {
+	rc, err := b.Open()
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, rc); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("sha256:%x", hash.Sum(nil)), nil
+}
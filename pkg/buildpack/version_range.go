@@ -0,0 +1,56 @@
+package buildpack
+
+import (
+	"regexp"
+
+	"github.com/Masterminds/semver"
+)
+
+// LatestVersion may be used as the version of a buildpack locator to refer to the highest version available.
+const LatestVersion = "latest"
+
+var exactVersionPattern = regexp.MustCompile(`^` + semverPattern + `$`)
+
+// IsVersionRange returns whether the version of a buildpack locator is a range, such as `^1.2`, `~2.0` or `latest`,
+// rather than an exact version.
+func IsVersionRange(version string) bool {
+	if version == LatestVersion {
+		return true
+	}
+	if version == "" || exactVersionPattern.MatchString(version) {
+		return false
+	}
+	_, err := semver.NewConstraint(version)
+	return err == nil
+}
+
+// ResolveVersionRange returns the highest of versions that satisfies versionRange, and false when none does. Versions
+// that aren't semantic versions never satisfy a range, and pre-releases only satisfy `latest` or ranges that
+// include a pre-release.
+func ResolveVersionRange(versionRange string, versions []string) (string, bool) {
+	var constraints *semver.Constraints
+	if versionRange != LatestVersion {
+		var err error
+		if constraints, err = semver.NewConstraint(versionRange); err != nil {
+			return "", false
+		}
+	}
+
+	var (
+		highest  *semver.Version
+		resolved string
+	)
+	for _, version := range versions {
+		v, err := semver.NewVersion(version)
+		if err != nil {
+			continue
+		}
+		if constraints != nil && !constraints.Check(v) {
+			continue
+		}
+		if highest == nil || v.GreaterThan(highest) {
+			highest, resolved = v, version
+		}
+	}
+	return resolved, highest != nil
+}
@@ -0,0 +1,86 @@
+package buildpack_test
+
+import (
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/dist"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestVersionRange(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "VersionRange", testVersionRange, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testVersionRange(t *testing.T, when spec.G, it spec.S) {
+	var (
+		assert = h.NewAssertionManager(t)
+	)
+
+	when("#IsVersionRange", func() {
+		it("is true for ranges", func() {
+			for _, version := range []string{"^1.2", "~2.0", "latest", ">= 1.0, < 2.0", "1.x"} {
+				assert.TrueWithMessage(buildpack.IsVersionRange(version), version+" should be a range")
+			}
+		})
+
+		it("is false for exact versions and versions that aren't semantic versions", func() {
+			for _, version := range []string{"", "1.2.3", "1.2.3-rc.1", "hello-universe", "some-version"} {
+				assert.TrueWithMessage(!buildpack.IsVersionRange(version), version+" should not be a range")
+			}
+		})
+	})
+
+	when("#ResolveVersionRange", func() {
+		versions := []string{"1.1.0", "1.2.0", "1.3.1", "2.0.0", "2.0.5", "3.0.0-rc.1", "not-semver"}
+
+		it("returns the highest version satisfying a caret range", func() {
+			version, found := buildpack.ResolveVersionRange("^1.2", versions)
+			assert.TrueWithMessage(found, "should find a version")
+			assert.Equal(version, "1.3.1")
+		})
+
+		it("returns the highest version satisfying a tilde range", func() {
+			version, found := buildpack.ResolveVersionRange("~2.0", versions)
+			assert.TrueWithMessage(found, "should find a version")
+			assert.Equal(version, "2.0.5")
+		})
+
+		it("returns the highest version, including pre-releases, for latest", func() {
+			version, found := buildpack.ResolveVersionRange("latest", versions)
+			assert.TrueWithMessage(found, "should find a version")
+			assert.Equal(version, "3.0.0-rc.1")
+		})
+
+		it("returns false when no version satisfies the range", func() {
+			_, found := buildpack.ResolveVersionRange("^4", versions)
+			assert.TrueWithMessage(!found, "should not find a version")
+		})
+	})
+
+	when("#ResolveBuilderVersion", func() {
+		builderBPs := []dist.ModuleInfo{
+			{ID: "some-bp", Version: "1.2.0"},
+			{ID: "some-bp", Version: "1.4.0"},
+			{ID: "other-bp", Version: "1.9.0"},
+		}
+
+		it("resolves ranges against the versions of the buildpack on the builder", func() {
+			id, version := buildpack.ResolveBuilderVersion("urn:cnb:builder:some-bp@^1.0", builderBPs)
+			assert.Equal(id, "some-bp")
+			assert.Equal(version, "1.4.0")
+		})
+
+		it("keeps exact versions", func() {
+			id, version := buildpack.ResolveBuilderVersion("some-bp@1.2.0", builderBPs)
+			assert.Equal(id, "some-bp")
+			assert.Equal(version, "1.2.0")
+		})
+	})
+}
@@ -25,6 +25,8 @@ const (
 	CacheVolume Format = iota
 	CacheImage
 	CacheBind
+	CacheHTTP
+	CacheDir
 )
 
 func (f Format) String() string {
@@ -35,6 +37,10 @@ func (f Format) String() string {
 		return "volume"
 	case CacheBind:
 		return "bind"
+	case CacheHTTP:
+		return "http"
+	case CacheDir:
+		return "dir"
 	}
 	return ""
 }
@@ -45,8 +51,10 @@ func (c *CacheInfo) SourceName() string {
 		fallthrough
 	case CacheVolume:
 		return "name"
-	case CacheBind:
+	case CacheBind, CacheDir:
 		return "source"
+	case CacheHTTP:
+		return "url"
 	}
 	return ""
 }
@@ -59,6 +67,7 @@ func (c *CacheOpts) Set(value string) error {
 		return err
 	}
 
+	var dirSource string
 	cache := &c.Build
 	for _, field := range fields {
 		parts := strings.SplitN(field, "=", 2)
@@ -96,6 +105,10 @@ func (c *CacheOpts) Set(value string) error {
 				cache.Format = CacheVolume
 			case "bind":
 				cache.Format = CacheBind
+			case "http":
+				cache.Format = CacheHTTP
+			case "dir":
+				cache.Format = CacheDir
 			default:
 				return errors.Errorf("invalid cache format '%s'", value)
 			}
@@ -103,9 +116,18 @@ func (c *CacheOpts) Set(value string) error {
 			cache.Source = value
 		case "source":
 			cache.Source = value
+			dirSource = parts[1]
+		case "url":
+			// URLs may be case-sensitive
+			cache.Source = parts[1]
 		}
 	}
 
+	// paths of dir caches may be case-sensitive
+	if cache.Format == CacheDir && dirSource != "" {
+		cache.Source = dirSource
+	}
+
 	err = sanitize(c)
 	if err != nil {
 		return err
@@ -140,6 +162,10 @@ func sanitize(c *CacheOpts) error {
 		}
 	}
 
+	if c.Launch.Format == CacheHTTP || c.Launch.Format == CacheDir {
+		return errors.Errorf("cache format '%s' is only supported for the build cache", c.Launch.Format)
+	}
+
 	var (
 		resolvedPath string
 		err          error
@@ -150,6 +176,11 @@ func sanitize(c *CacheOpts) error {
 		}
 		c.Build.Source = filepath.Join(resolvedPath, "build-cache")
 	}
+	if c.Build.Format == CacheDir {
+		if c.Build.Source, err = filepath.Abs(c.Build.Source); err != nil {
+			return errors.Wrap(err, "resolve absolute path")
+		}
+	}
 	if c.Launch.Format == CacheBind {
 		if resolvedPath, err = filepath.Abs(c.Launch.Source); err != nil {
 			return errors.Wrap(err, "resolve absolute path")
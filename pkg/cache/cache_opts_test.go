@@ -3,6 +3,7 @@ package cache
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
@@ -296,4 +297,50 @@ func testCacheOpts(t *testing.T, when spec.G, it spec.S) {
 			}
 		})
 	})
+
+	when("remote cache format options are passed", func() {
+		it("keeps the case of the url", func() {
+			var cacheFlags CacheOpts
+			h.AssertNil(t, cacheFlags.Set("type=build;format=http;url=https://cache.example.com/My-Project"))
+			h.AssertEq(t, cacheFlags.String(), "type=build;format=http;url=https://cache.example.com/My-Project;type=launch;format=volume;")
+		})
+
+		it("resolves the absolute path of a dir cache", func() {
+			cwd, err := os.Getwd()
+			h.AssertNil(t, err)
+
+			var cacheFlags CacheOpts
+			h.AssertNil(t, cacheFlags.Set("type=build;format=dir;source=Some-Dir"))
+			h.AssertEq(t, cacheFlags.Build.Source, filepath.Join(cwd, "Some-Dir"))
+		})
+
+		it("keeps the case of the source of a dir cache", func() {
+			cwd, err := os.Getwd()
+			h.AssertNil(t, err)
+
+			var cacheFlags CacheOpts
+			h.AssertNil(t, cacheFlags.Set("type=build;source=Some-Dir;format=dir"))
+			h.AssertEq(t, cacheFlags.Build.Source, filepath.Join(cwd, "Some-Dir"))
+		})
+
+		it("lowercases the source of a bind cache", func() {
+			cwd, err := os.Getwd()
+			h.AssertNil(t, err)
+
+			var cacheFlags CacheOpts
+			h.AssertNil(t, cacheFlags.Set("type=build;format=bind;source=Some-Dir"))
+			h.AssertEq(t, cacheFlags.Build.Source, filepath.Join(cwd, "some-dir", "build-cache"))
+		})
+
+		it("requires a url or source", func() {
+			var cacheFlags CacheOpts
+			h.AssertError(t, cacheFlags.Set("type=build;format=http"), "cache 'url' is required")
+			h.AssertError(t, cacheFlags.Set("type=build;format=dir"), "cache 'source' is required")
+		})
+
+		it("is only supported for the build cache", func() {
+			var cacheFlags CacheOpts
+			h.AssertError(t, cacheFlags.Set("type=launch;format=http;url=https://cache.example.com"), "cache format 'http' is only supported for the build cache")
+		})
+	})
 }
@@ -0,0 +1,232 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ErrSnapshotNotFound is returned by a ChunkStore when no snapshot manifest exists for a name.
+var ErrSnapshotNotFound = errors.New("cache snapshot not found")
+
+// ErrChunkNotFound is returned by a ChunkStore when a chunk listed by a snapshot manifest doesn't exist, meaning the
+// snapshot is incomplete. Saving a new snapshot uploads the chunk again.
+var ErrChunkNotFound = errors.New("cache chunk not found")
+
+// ChunkStore stores content-addressed chunks, and the manifests that list the chunks of a cache snapshot.
+//
+// Chunks are stored under chunks/<algorithm>/<hex> and manifests under manifests/<name>.json.
+type ChunkStore interface {
+	HasChunk(ctx context.Context, digest string) (bool, error)
+	GetChunk(ctx context.Context, digest string) (io.ReadCloser, error)
+	PutChunk(ctx context.Context, digest string, content io.Reader, size int64) error
+	GetManifest(ctx context.Context, name string) (io.ReadCloser, error)
+	PutManifest(ctx context.Context, name string, content io.Reader, size int64) error
+}
+
+func chunkPath(digest string) (string, error) {
+	parts := strings.SplitN(digest, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" || strings.ContainsAny(parts[1], `/\.`) {
+		return "", errors.Errorf("invalid chunk digest '%s'", digest)
+	}
+	return "chunks/" + parts[0] + "/" + parts[1], nil
+}
+
+func manifestPath(name string) string {
+	return "manifests/" + name + ".json"
+}
+
+// DirChunkStore is a ChunkStore backed by a local directory, e.g. one shared between CI runners.
+type DirChunkStore struct {
+	root string
+}
+
+func NewDirChunkStore(root string) *DirChunkStore {
+	return &DirChunkStore{root: root}
+}
+
+func (s *DirChunkStore) HasChunk(_ context.Context, digest string) (bool, error) {
+	path, err := chunkPath(digest)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = os.Stat(filepath.Join(s.root, filepath.FromSlash(path)))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (s *DirChunkStore) GetChunk(_ context.Context, digest string) (io.ReadCloser, error) {
+	path, err := chunkPath(digest)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(filepath.Join(s.root, filepath.FromSlash(path)))
+	if os.IsNotExist(err) {
+		return nil, ErrChunkNotFound
+	}
+	return f, err
+}
+
+func (s *DirChunkStore) PutChunk(_ context.Context, digest string, content io.Reader, _ int64) error {
+	path, err := chunkPath(digest)
+	if err != nil {
+		return err
+	}
+
+	return s.write(path, content)
+}
+
+func (s *DirChunkStore) GetManifest(_ context.Context, name string) (io.ReadCloser, error) {
+	f, err := os.Open(filepath.Join(s.root, filepath.FromSlash(manifestPath(name))))
+	if os.IsNotExist(err) {
+		return nil, ErrSnapshotNotFound
+	}
+	return f, err
+}
+
+func (s *DirChunkStore) PutManifest(_ context.Context, name string, content io.Reader, _ int64) error {
+	return s.write(manifestPath(name), content)
+}
+
+// write atomically writes a file, so that concurrent readers never see partial content
+func (s *DirChunkStore) write(path string, content io.Reader) error {
+	dest := filepath.Join(s.root, filepath.FromSlash(path))
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(dest), ".tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), dest)
+}
+
+// HTTPChunkStore is a ChunkStore backed by an HTTP server supporting GET, HEAD and PUT, such as a
+// WebDAV server or an object storage bucket. Credentials may be provided in the URL.
+type HTTPChunkStore struct {
+	baseURL string
+	client  *http.Client
+}
+
+func NewHTTPChunkStore(baseURL string, client *http.Client) *HTTPChunkStore {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &HTTPChunkStore{baseURL: strings.TrimSuffix(baseURL, "/"), client: client}
+}
+
+func (s *HTTPChunkStore) HasChunk(ctx context.Context, digest string) (bool, error) {
+	path, err := chunkPath(digest)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := s.do(ctx, http.MethodHead, path, nil, 0)
+	if err != nil {
+		return false, err
+	}
+	resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	}
+	return false, statusError(resp)
+}
+
+func (s *HTTPChunkStore) GetChunk(ctx context.Context, digest string) (io.ReadCloser, error) {
+	path, err := chunkPath(digest)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.get(ctx, path, ErrChunkNotFound)
+}
+
+func (s *HTTPChunkStore) PutChunk(ctx context.Context, digest string, content io.Reader, size int64) error {
+	path, err := chunkPath(digest)
+	if err != nil {
+		return err
+	}
+
+	return s.put(ctx, path, content, size)
+}
+
+func (s *HTTPChunkStore) GetManifest(ctx context.Context, name string) (io.ReadCloser, error) {
+	return s.get(ctx, manifestPath(name), ErrSnapshotNotFound)
+}
+
+func (s *HTTPChunkStore) PutManifest(ctx context.Context, name string, content io.Reader, size int64) error {
+	return s.put(ctx, manifestPath(name), content, size)
+}
+
+// get returns the content of path, or errNotFound if the server has no such object
+func (s *HTTPChunkStore) get(ctx context.Context, path string, errNotFound error) (io.ReadCloser, error) {
+	resp, err := s.do(ctx, http.MethodGet, path, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, errNotFound
+	}
+	resp.Body.Close()
+	return nil, statusError(resp)
+}
+
+func (s *HTTPChunkStore) put(ctx context.Context, path string, content io.Reader, size int64) error {
+	resp, err := s.do(ctx, http.MethodPut, path, content, size)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return statusError(resp)
+	}
+	return nil
+}
+
+func (s *HTTPChunkStore) do(ctx context.Context, method, path string, body io.Reader, size int64) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, s.baseURL+"/"+path, body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.ContentLength = size
+		req.Header.Set("Content-Type", "application/octet-stream")
+	}
+
+	return s.client.Do(req)
+}
+
+func statusError(resp *http.Response) error {
+	return fmt.Errorf("%s %s: unexpected status %s", resp.Request.Method, resp.Request.URL.Redacted(), resp.Status)
+}
@@ -0,0 +1,269 @@
+package cache
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/pkg/archive"
+)
+
+const snapshotMediaType = "application/vnd.buildpacks.pack.cache.snapshot.v1+json"
+
+type snapshotManifest struct {
+	MediaType string   `json:"mediaType"`
+	Chunks    []string `json:"chunks"`
+}
+
+// chunkSize is the size of content after which a chunk is closed. Entries are never split, so an entry larger than
+// chunkSize, such as a cached layer, always ends its chunk.
+const chunkSize = 4 << 20
+
+// RemoteCache snapshots the contents of a cache volume into a ChunkStore, so that it can be shared between machines
+// without registry access. Consecutive entries of the cache are stored together as tar chunks of about chunkSize,
+// named by their digest, so that unchanged layers are only uploaded once.
+type RemoteCache struct {
+	store ChunkStore
+	name  string
+}
+
+// NewRemoteCache returns the remote cache described by cacheInfo. Snapshots are stored under name, which should
+// identify the app being built.
+func NewRemoteCache(cacheInfo CacheInfo, name string) (*RemoteCache, error) {
+	var store ChunkStore
+	switch cacheInfo.Format {
+	case CacheHTTP:
+		store = NewHTTPChunkStore(cacheInfo.Source, nil)
+	case CacheDir:
+		store = NewDirChunkStore(cacheInfo.Source)
+	default:
+		return nil, errors.Errorf("cache format '%s' is not a remote cache format", cacheInfo.Format)
+	}
+
+	return NewRemoteCacheWithStore(store, name), nil
+}
+
+// NewRemoteCacheWithStore returns a remote cache backed by the provided ChunkStore.
+func NewRemoteCacheWithStore(store ChunkStore, name string) *RemoteCache {
+	return &RemoteCache{store: store, name: name}
+}
+
+func (c *RemoteCache) Name() string {
+	return c.name
+}
+
+// Save stores a snapshot of the cache, read as a tar stream. Load returns the same entries.
+func (c *RemoteCache) Save(ctx context.Context, snapshot io.Reader) error {
+	manifest := snapshotManifest{MediaType: snapshotMediaType, Chunks: []string{}}
+
+	var chunk *chunkWriter
+	defer func() {
+		if chunk != nil {
+			chunk.discard()
+		}
+	}()
+
+	saveChunk := func() error {
+		digest, err := c.saveChunk(ctx, chunk)
+		if err != nil {
+			return err
+		}
+		manifest.Chunks = append(manifest.Chunks, digest)
+		chunk.discard()
+		chunk = nil
+		return nil
+	}
+
+	tr := tar.NewReader(snapshot)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.Wrap(err, "reading cache snapshot")
+		}
+
+		if chunk == nil {
+			if chunk, err = newChunkWriter(); err != nil {
+				return err
+			}
+		}
+		if err := chunk.add(header, tr); err != nil {
+			return errors.Wrapf(err, "saving '%s'", header.Name)
+		}
+		if chunk.size >= chunkSize {
+			if err := saveChunk(); err != nil {
+				return errors.Wrapf(err, "saving chunk ending with '%s'", header.Name)
+			}
+		}
+	}
+	if chunk != nil {
+		if err := saveChunk(); err != nil {
+			return errors.Wrap(err, "saving last chunk")
+		}
+	}
+
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	return c.store.PutManifest(ctx, c.name, bytes.NewReader(data), int64(len(data)))
+}
+
+// saveChunk uploads a chunk unless a chunk with the same digest already exists
+func (c *RemoteCache) saveChunk(ctx context.Context, chunk *chunkWriter) (string, error) {
+	if err := chunk.tw.Close(); err != nil {
+		return "", err
+	}
+
+	digest := digestOf(chunk.hasher)
+	exists, err := c.store.HasChunk(ctx, digest)
+	if err != nil || exists {
+		return digest, err
+	}
+
+	size, err := chunk.file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return "", err
+	}
+	if _, err := chunk.file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	return digest, c.store.PutChunk(ctx, digest, chunk.file, size)
+}
+
+// Load returns the entries of the latest snapshot of the cache as a tar stream, or ErrSnapshotNotFound if no
+// snapshot exists. Chunks are fetched and verified as the stream is read; reading fails with ErrChunkNotFound
+// when a chunk of the snapshot is missing.
+func (c *RemoteCache) Load(ctx context.Context) (io.ReadCloser, error) {
+	rc, err := c.store.GetManifest(ctx, c.name)
+	if err == ErrSnapshotNotFound {
+		return nil, err
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching cache snapshot")
+	}
+	defer rc.Close()
+
+	var manifest snapshotManifest
+	if err := json.NewDecoder(rc).Decode(&manifest); err != nil {
+		return nil, errors.Wrap(err, "reading cache snapshot")
+	}
+	if manifest.MediaType != snapshotMediaType {
+		return nil, errors.Errorf("unsupported cache snapshot media type '%s'", manifest.MediaType)
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		tw := tar.NewWriter(pw)
+		for _, digest := range manifest.Chunks {
+			if err := c.loadChunk(ctx, digest, tw); err != nil {
+				pw.CloseWithError(errors.Wrapf(err, "loading chunk '%s'", digest))
+				return
+			}
+		}
+		pw.CloseWithError(tw.Close())
+	}()
+	return pr, nil
+}
+
+func (c *RemoteCache) loadChunk(ctx context.Context, digest string, tw *tar.Writer) error {
+	rc, err := c.store.GetChunk(ctx, digest)
+	if err == ErrChunkNotFound {
+		return errors.Wrap(err, "cache snapshot is incomplete")
+	}
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	hasher := sha256.New()
+	tr := tar.NewReader(io.TeeReader(rc, hasher))
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := io.Copy(tw, tr); err != nil {
+			return err
+		}
+	}
+
+	// consume any padding so the digest covers the whole chunk
+	if _, err := io.Copy(io.Discard, io.TeeReader(rc, hasher)); err != nil {
+		return err
+	}
+	if actual := digestOf(hasher); actual != digest {
+		return fmt.Errorf("digest mismatch, got '%s'", actual)
+	}
+	return nil
+}
+
+// chunkWriter writes consecutive entries of a snapshot to a tar chunk in a temporary file
+type chunkWriter struct {
+	file   *os.File
+	hasher hash.Hash
+	tw     *tar.Writer
+	// size is the size of the content of the entries written so far
+	size int64
+}
+
+func newChunkWriter() (*chunkWriter, error) {
+	f, err := os.CreateTemp("", "pack-cache-chunk-")
+	if err != nil {
+		return nil, err
+	}
+
+	hasher := sha256.New()
+	return &chunkWriter{file: f, hasher: hasher, tw: tar.NewWriter(io.MultiWriter(f, hasher))}, nil
+}
+
+func (w *chunkWriter) add(header *tar.Header, content io.Reader) error {
+	if err := w.tw.WriteHeader(normalizedHeader(header)); err != nil {
+		return err
+	}
+	n, err := io.Copy(w.tw, content)
+	w.size += n
+	return err
+}
+
+func (w *chunkWriter) discard() {
+	w.file.Close()
+	os.Remove(w.file.Name())
+}
+
+// normalizedHeader removes the fields of a header that differ between otherwise identical entries
+func normalizedHeader(header *tar.Header) *tar.Header {
+	return &tar.Header{
+		Typeflag: header.Typeflag,
+		Name:     header.Name,
+		Linkname: header.Linkname,
+		Size:     header.Size,
+		Mode:     header.Mode,
+		Uid:      header.Uid,
+		Gid:      header.Gid,
+		ModTime:  archive.NormalizedDateTime,
+		Format:   tar.FormatPAX,
+	}
+}
+
+func digestOf(hasher hash.Hash) string {
+	return "sha256:" + hex.EncodeToString(hasher.Sum(nil))
+}
@@ -0,0 +1,316 @@
+package cache_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/cache"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestRemoteCache(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+
+	spec.Run(t, "RemoteCache", testRemoteCache, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+// fakeObjectStore is a minimal HTTP object store supporting GET, HEAD and PUT
+type fakeObjectStore struct {
+	sync.Mutex
+	objects map[string][]byte
+	puts    []string
+}
+
+func (s *fakeObjectStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.Lock()
+	defer s.Unlock()
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		data, ok := s.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodGet {
+			w.Write(data)
+		}
+	case http.MethodPut:
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		s.objects[r.URL.Path] = data
+		s.puts = append(s.puts, r.URL.Path)
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func testRemoteCache(t *testing.T, when spec.G, it spec.S) {
+	var snapshot = func(files map[string]string) io.Reader {
+		buf := &bytes.Buffer{}
+		tw := tar.NewWriter(buf)
+		h.AssertNil(t, tw.WriteHeader(&tar.Header{Name: "cache/", Typeflag: tar.TypeDir, Mode: 0755, Uid: 1000, Gid: 1000}))
+		for _, name := range []string{"cache/committed/layer-a.tar", "cache/committed/layer-b.tar"} {
+			content, ok := files[name]
+			if !ok {
+				continue
+			}
+			h.AssertNil(t, tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0644, Uid: 1000, Gid: 1000, Size: int64(len(content))}))
+			_, err := tw.Write([]byte(content))
+			h.AssertNil(t, err)
+		}
+		h.AssertNil(t, tw.Close())
+		return buf
+	}
+
+	var readSnapshot = func(rc io.ReadCloser) map[string]string {
+		defer rc.Close()
+
+		files := map[string]string{}
+		tr := tar.NewReader(rc)
+		for {
+			header, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			h.AssertNil(t, err)
+			h.AssertEq(t, header.Uid, 1000)
+
+			data, err := io.ReadAll(tr)
+			h.AssertNil(t, err)
+			files[header.Name] = string(data)
+		}
+		return files
+	}
+
+	when("backed by an HTTP server", func() {
+		var (
+			store  *fakeObjectStore
+			server *httptest.Server
+		)
+
+		it.Before(func() {
+			store = &fakeObjectStore{objects: map[string][]byte{}}
+			server = httptest.NewServer(store)
+		})
+
+		it.After(func() {
+			server.Close()
+		})
+
+		it("restores a saved snapshot", func() {
+			subject, err := cache.NewRemoteCache(cache.CacheInfo{Format: cache.CacheHTTP, Source: server.URL + "/some-project/"}, "some-app")
+			h.AssertNil(t, err)
+
+			h.AssertNil(t, subject.Save(context.TODO(), snapshot(map[string]string{
+				"cache/committed/layer-a.tar": "some-content-a",
+				"cache/committed/layer-b.tar": "some-content-b",
+			})))
+
+			rc, err := subject.Load(context.TODO())
+			h.AssertNil(t, err)
+			h.AssertEq(t, readSnapshot(rc), map[string]string{
+				"cache/":                      "",
+				"cache/committed/layer-a.tar": "some-content-a",
+				"cache/committed/layer-b.tar": "some-content-b",
+			})
+			h.AssertNotNil(t, store.objects["/some-project/manifests/some-app.json"])
+		})
+
+		it("stores small entries together", func() {
+			subject, err := cache.NewRemoteCache(cache.CacheInfo{Format: cache.CacheHTTP, Source: server.URL}, "some-app")
+			h.AssertNil(t, err)
+
+			h.AssertNil(t, subject.Save(context.TODO(), snapshot(map[string]string{
+				"cache/committed/layer-a.tar": "some-content-a",
+				"cache/committed/layer-b.tar": "some-content-b",
+			})))
+			h.AssertEq(t, len(store.puts), 2)
+			h.AssertContains(t, store.puts[0], "/chunks/sha256/")
+			h.AssertEq(t, store.puts[1], "/manifests/some-app.json")
+		})
+
+		it("only uploads chunks that changed", func() {
+			subject, err := cache.NewRemoteCache(cache.CacheInfo{Format: cache.CacheHTTP, Source: server.URL}, "some-app")
+			h.AssertNil(t, err)
+
+			// a large entry ends its chunk
+			largeContent := strings.Repeat("a", 4<<20)
+			h.AssertNil(t, subject.Save(context.TODO(), snapshot(map[string]string{
+				"cache/committed/layer-a.tar": largeContent,
+				"cache/committed/layer-b.tar": "some-content-b",
+			})))
+			h.AssertEq(t, len(store.puts), 3)
+
+			store.puts = nil
+			h.AssertNil(t, subject.Save(context.TODO(), snapshot(map[string]string{
+				"cache/committed/layer-a.tar": largeContent,
+				"cache/committed/layer-b.tar": "some-new-content-b",
+			})))
+			h.AssertEq(t, len(store.puts), 2)
+			h.AssertContains(t, store.puts[0], "/chunks/sha256/")
+			h.AssertEq(t, store.puts[1], "/manifests/some-app.json")
+
+			rc, err := subject.Load(context.TODO())
+			h.AssertNil(t, err)
+			h.AssertEq(t, readSnapshot(rc), map[string]string{
+				"cache/":                      "",
+				"cache/committed/layer-a.tar": largeContent,
+				"cache/committed/layer-b.tar": "some-new-content-b",
+			})
+		})
+
+		it("returns ErrSnapshotNotFound when nothing was saved", func() {
+			subject, err := cache.NewRemoteCache(cache.CacheInfo{Format: cache.CacheHTTP, Source: server.URL}, "some-app")
+			h.AssertNil(t, err)
+
+			_, err = subject.Load(context.TODO())
+			h.AssertSameInstance(t, err, cache.ErrSnapshotNotFound)
+		})
+
+		it("fails when a chunk does not match its digest", func() {
+			subject, err := cache.NewRemoteCache(cache.CacheInfo{Format: cache.CacheHTTP, Source: server.URL}, "some-app")
+			h.AssertNil(t, err)
+			h.AssertNil(t, subject.Save(context.TODO(), snapshot(map[string]string{
+				"cache/committed/layer-a.tar": "some-content-a",
+			})))
+
+			for path := range store.objects {
+				if strings.HasPrefix(path, "/chunks/") {
+					store.objects[path] = snapshotBytes(t, snapshot(map[string]string{"cache/committed/layer-a.tar": "tampered"}))
+				}
+			}
+
+			rc, err := subject.Load(context.TODO())
+			h.AssertNil(t, err)
+			defer rc.Close()
+			_, err = io.Copy(io.Discard, rc)
+			h.AssertError(t, err, "digest mismatch")
+		})
+
+		it("returns ErrChunkNotFound when a chunk is missing", func() {
+			subject, err := cache.NewRemoteCache(cache.CacheInfo{Format: cache.CacheHTTP, Source: server.URL}, "some-app")
+			h.AssertNil(t, err)
+			h.AssertNil(t, subject.Save(context.TODO(), snapshot(map[string]string{
+				"cache/committed/layer-a.tar": "some-content-a",
+			})))
+
+			for path := range store.objects {
+				if strings.HasPrefix(path, "/chunks/") {
+					delete(store.objects, path)
+				}
+			}
+
+			rc, err := subject.Load(context.TODO())
+			h.AssertNil(t, err)
+			defer rc.Close()
+			_, err = io.Copy(io.Discard, rc)
+			h.AssertSameInstance(t, errors.Cause(err), cache.ErrChunkNotFound)
+			h.AssertError(t, err, "cache snapshot is incomplete")
+		})
+
+		it("reports unexpected server responses", func() {
+			failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+			}))
+			defer failing.Close()
+
+			subject, err := cache.NewRemoteCache(cache.CacheInfo{Format: cache.CacheHTTP, Source: failing.URL}, "some-app")
+			h.AssertNil(t, err)
+
+			err = subject.Save(context.TODO(), snapshot(nil))
+			h.AssertError(t, err, "unexpected status 403 Forbidden")
+		})
+	})
+
+	when("backed by a directory", func() {
+		var tmpDir string
+
+		it.Before(func() {
+			var err error
+			tmpDir, err = os.MkdirTemp("", "pack.remote.cache.test.")
+			h.AssertNil(t, err)
+		})
+
+		it.After(func() {
+			h.AssertNil(t, os.RemoveAll(tmpDir))
+		})
+
+		it("restores a saved snapshot", func() {
+			subject, err := cache.NewRemoteCache(cache.CacheInfo{Format: cache.CacheDir, Source: tmpDir}, "some-app")
+			h.AssertNil(t, err)
+
+			_, err = subject.Load(context.TODO())
+			h.AssertSameInstance(t, err, cache.ErrSnapshotNotFound)
+
+			h.AssertNil(t, subject.Save(context.TODO(), snapshot(map[string]string{
+				"cache/committed/layer-a.tar": "some-content-a",
+			})))
+
+			rc, err := subject.Load(context.TODO())
+			h.AssertNil(t, err)
+			h.AssertEq(t, readSnapshot(rc), map[string]string{
+				"cache/":                      "",
+				"cache/committed/layer-a.tar": "some-content-a",
+			})
+		})
+
+		it("returns ErrChunkNotFound when a chunk is missing", func() {
+			subject, err := cache.NewRemoteCache(cache.CacheInfo{Format: cache.CacheDir, Source: tmpDir}, "some-app")
+			h.AssertNil(t, err)
+			h.AssertNil(t, subject.Save(context.TODO(), snapshot(map[string]string{
+				"cache/committed/layer-a.tar": "some-content-a",
+			})))
+			h.AssertNil(t, os.RemoveAll(filepath.Join(tmpDir, "chunks")))
+
+			rc, err := subject.Load(context.TODO())
+			h.AssertNil(t, err)
+			defer rc.Close()
+			_, err = io.Copy(io.Discard, rc)
+			h.AssertSameInstance(t, errors.Cause(err), cache.ErrChunkNotFound)
+		})
+	})
+
+	it("rejects other cache formats", func() {
+		_, err := cache.NewRemoteCache(cache.CacheInfo{Format: cache.CacheVolume}, "some-app")
+		h.AssertError(t, err, "cache format 'volume' is not a remote cache format")
+	})
+}
+
+// snapshotBytes returns a chunk holding only the first file of a snapshot
+func snapshotBytes(t *testing.T, r io.Reader) []byte {
+	t.Helper()
+
+	tr := tar.NewReader(r)
+	_, err := tr.Next() // skip the root directory
+	h.AssertNil(t, err)
+	header, err := tr.Next()
+	h.AssertNil(t, err)
+
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+	h.AssertNil(t, tw.WriteHeader(header))
+	_, err = io.Copy(tw, tr)
+	h.AssertNil(t, err)
+	h.AssertNil(t, tw.Close())
+	return buf.Bytes()
+}
@@ -12,6 +12,8 @@ import (
 	"github.com/buildpacks/pack/internal/paths"
 )
 
+const volumeCachePrefix = "pack-cache-"
+
 type VolumeCache struct {
 	docker DockerClient
 	volume string
@@ -22,7 +24,7 @@ func NewVolumeCache(imageRef name.Reference, cacheType CacheInfo, suffix string,
 	if cacheType.Source == "" {
 		sum := sha256.Sum256([]byte(imageRef.Name()))
 		vol := paths.FilterReservedNames(fmt.Sprintf("%s-%x", sanitizedRef(imageRef), sum[:6]))
-		volumeName = fmt.Sprintf("pack-cache-%s.%s", vol, suffix)
+		volumeName = fmt.Sprintf("%s%s.%s", volumeCachePrefix, vol, suffix)
 	} else {
 		volumeName = paths.FilterReservedNames(cacheType.Source)
 	}
@@ -49,6 +51,20 @@ func (c *VolumeCache) Type() Type {
 	return Volume
 }
 
+// VolumeCacheType returns the suffix (e.g. build, launch or kaniko) of a volume named by NewVolumeCache,
+// and false if the volume name was not generated by pack
+func VolumeCacheType(volumeName string) (string, bool) {
+	if !strings.HasPrefix(volumeName, volumeCachePrefix) {
+		return "", false
+	}
+
+	i := strings.LastIndex(volumeName, ".")
+	if i < 0 || i == len(volumeName)-1 {
+		return "", false
+	}
+	return volumeName[i+1:], true
+}
+
 // note image names and volume names are validated using the same restrictions:
 // see https://github.com/moby/moby/blob/f266f13965d5bfb1825afa181fe6c32f3a597fa3/daemon/names/names.go#L5
 func sanitizedRef(ref name.Reference) string {
@@ -260,4 +260,21 @@ func testCache(t *testing.T, when spec.G, it spec.S) {
 			h.AssertEq(t, subject.Type(), expected)
 		})
 	})
+
+	when("#VolumeCacheType", func() {
+		it("returns the suffix of a generated volume name", func() {
+			ref, err := name.ParseReference("my/repo", name.WeakValidation)
+			h.AssertNil(t, err)
+			subject := cache.NewVolumeCache(ref, cache.CacheInfo{}, "build", dockerClient)
+
+			cacheType, ok := cache.VolumeCacheType(subject.Name())
+			h.AssertTrue(t, ok)
+			h.AssertEq(t, cacheType, "build")
+		})
+
+		it("returns false for a named volume", func() {
+			_, ok := cache.VolumeCacheType("test-volume-name")
+			h.AssertFalse(t, ok)
+		})
+	})
 }
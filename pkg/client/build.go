@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/url"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -25,6 +26,7 @@ import (
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/volume/mounts"
 	"github.com/google/go-containerregistry/pkg/name"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
 	ignore "github.com/sabhiram/go-gitignore"
 
@@ -34,6 +36,7 @@ import (
 	internalConfig "github.com/buildpacks/pack/internal/config"
 	pname "github.com/buildpacks/pack/internal/name"
 	"github.com/buildpacks/pack/internal/paths"
+	"github.com/buildpacks/pack/internal/sign"
 	"github.com/buildpacks/pack/internal/stack"
 	"github.com/buildpacks/pack/internal/stringset"
 	"github.com/buildpacks/pack/internal/style"
@@ -46,6 +49,7 @@ import (
 	"github.com/buildpacks/pack/pkg/logging"
 	projectTypes "github.com/buildpacks/pack/pkg/project/types"
 	v02 "github.com/buildpacks/pack/pkg/project/v02"
+	"github.com/buildpacks/pack/pkg/sbom"
 )
 
 const (
@@ -201,7 +205,7 @@ type BuildOptions struct {
 	// Directory to output any SBOM artifacts
 	SBOMDestinationDir string
 
-	// Directory to output the report.toml metadata artifact
+	// Directory to output the report.toml metadata artifact and the build-stats.toml timing summary
 	ReportDestinationDir string
 
 	// Desired create time in the output image config
@@ -209,6 +213,62 @@ type BuildOptions struct {
 
 	// Configuration to export to OCI layout format
 	LayoutConfig *LayoutConfig
+
+	// Rebuild whenever the files in AppPath change, until the context is cancelled. The ephemeral builder
+	// and cache volumes are kept between builds. AppPath must be a directory.
+	Watch bool
+
+	// Resolve the builder, run image, lifecycle and buildpacks, and print the resulting
+	// BuildPlan instead of running the lifecycle. No containers or images are created.
+	DryRun bool
+
+	// Format of the plan printed by a dry run, either DryRunFormatText (the default) or DryRunFormatJSON.
+	DryRunFormat string
+
+	// Runtime that runs the lifecycle phases, either build.RuntimeDocker (the default) or build.RuntimeLocal.
+	// The local runtime runs the phases as processes in the unpacked builder filesystem, without a Docker daemon.
+	// It requires a trusted builder without extensions, and the image to be published or exported to an OCI layout.
+	Runtime string
+
+	// Host to run the lifecycle on, in the form ssh://[user@]host[:port]. The builder and app are uploaded to the
+	// host over SSH and the creator runs there as the SSH user, so the host needs no container runtime.
+	// It requires a trusted builder without extensions, and the image to be published.
+	RemoteHost string
+
+	// Platform to build for, in the form os/arch[/variant] (e.g. linux/arm64).
+	// When set, the matching manifest is selected from builder and run-image indexes
+	// and the lifecycle runs in containers for this platform. Building for a platform
+	// other than the daemon's requires emulation to be available on the host.
+	Platform string
+
+	// Path to an SBOM policy file. When set, the packages in the SBOM of the exported image are checked
+	// against the policy, and the build fails if any of them violates it. The image is exported regardless;
+	// when publishing, the pushed image is checked in the registry. It cannot be used when exporting to an OCI layout.
+	SBOMPolicy string
+
+	// Verify that the build is reproducible, by comparing the layers of the built image with those of
+	// PreviousImage when set, or else with those of a second build from a clean cache. The build compared with
+	// PreviousImage neither reuses its layers nor restores the cache. The files that differ in each differing
+	// layer are logged, and the build fails. Rebuilding requires the image not to be published.
+	VerifyReproducible bool
+
+	// Sign the published image with a cosign-compatible key, and attach the provenance of the build and the
+	// SBOM files of the image as signed attestations. Requires Publish.
+	Sign *SignOptions
+
+	// Path to write the SLSA v1 provenance of the build to, as an in-toto statement. It records the builder,
+	// run image, buildpacks, project source and build options, along with the resolved image digests. Only the
+	// names of build env vars are recorded, as their values may hold secrets. The image is identified by its
+	// manifest digest when published, or else by its image ID. It cannot be used when exporting to an OCI layout.
+	ProvenanceOutput string
+
+	// Path to a lockfile recording the resolved digests of the builder, run image, lifecycle image, buildpacks and
+	// extensions of the build. The lockfile is written when it doesn't exist; otherwise the build fails when any of
+	// its inputs differs from those recorded, unless UpdateLock is set.
+	LockFile string
+
+	// Rewrite LockFile with the inputs resolved for this build instead of verifying them.
+	UpdateLock bool
 }
 
 func (b *BuildOptions) Layout() bool {
@@ -313,6 +373,60 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		return errors.Wrapf(err, "invalid app path '%s'", opts.AppPath)
 	}
 
+	if opts.Watch {
+		if fi, err := os.Stat(appPath); err != nil || !fi.IsDir() {
+			return errors.Errorf("watch mode requires app path %s to be a directory", style.Symbol(appPath))
+		}
+	}
+
+	switch opts.Runtime {
+	case "", build.RuntimeDocker:
+	case build.RuntimeLocal:
+		if !opts.Publish && !opts.Layout() {
+			return errors.Errorf("the %s runtime requires the image to be published or exported to an OCI layout", style.Symbol(opts.Runtime))
+		}
+	default:
+		return errors.Errorf("invalid runtime %s, must be one of %s or %s", style.Symbol(opts.Runtime), style.Symbol(build.RuntimeDocker), style.Symbol(build.RuntimeLocal))
+	}
+
+	if opts.VerifyReproducible {
+		if err := validateVerifyReproducible(opts); err != nil {
+			return err
+		}
+	}
+
+	var steps exportSteps
+	if opts.SBOMPolicy != "" {
+		if opts.Layout() {
+			return errors.New("SBOM policy cannot be checked when exporting to an OCI layout")
+		}
+		policy, err := sbom.ReadPolicy(opts.SBOMPolicy)
+		if err != nil {
+			return err
+		}
+		steps.sbomPolicy = &policy
+	}
+
+	if opts.ProvenanceOutput != "" && opts.Layout() {
+		return errors.New("provenance cannot be written when exporting to an OCI layout")
+	}
+
+	if opts.Sign != nil {
+		if !opts.Publish {
+			return errors.New("signing requires the image to be published")
+		}
+		if steps.signer, err = c.newSigner(*opts.Sign); err != nil {
+			return err
+		}
+	}
+
+	var remoteHost *url.URL
+	if opts.RemoteHost != "" {
+		if remoteHost, err = parseRemoteHost(opts); err != nil {
+			return err
+		}
+	}
+
 	proxyConfig := c.processProxyConfig(opts.ProxyConfig)
 
 	builderRef, err := c.processBuilderName(opts.Builder)
@@ -320,7 +434,24 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		return errors.Wrapf(err, "invalid builder '%s'", opts.Builder)
 	}
 
-	rawBuilderImage, err := c.imageFetcher.Fetch(ctx, builderRef.Name(), image.FetchOptions{Daemon: true, PullPolicy: opts.PullPolicy})
+	platform, err := parsePlatform(opts.Platform)
+	if err != nil {
+		return err
+	}
+
+	builderFetchOptions := image.FetchOptions{Daemon: true, PullPolicy: opts.PullPolicy, Platform: opts.Platform}
+	if opts.Runtime == build.RuntimeLocal || remoteHost != nil {
+		// without a daemon, the builder is saved to an OCI layout, which the local runtime or remote build unpacks
+		builderLayoutDir, err := os.MkdirTemp("", "pack.builder")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(builderLayoutDir)
+
+		builderFetchOptions.Daemon = false
+		builderFetchOptions.LayoutOption = image.LayoutOption{Path: builderLayoutDir}
+	}
+	rawBuilderImage, err := c.imageFetcher.Fetch(ctx, builderRef.Name(), builderFetchOptions)
 	if err != nil {
 		return errors.Wrapf(err, "failed to fetch builder image '%s'", builderRef.Name())
 	}
@@ -335,6 +466,10 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		return errors.Wrapf(err, "getting builder architecture")
 	}
 
+	if platform != nil && (builderOS != platform.OS || builderArch != platform.Architecture) {
+		return errors.Errorf("builder %s is %s, expected %s", style.Symbol(builderRef.Name()), style.Symbol(builderOS+"/"+builderArch), style.Symbol(opts.Platform))
+	}
+
 	bldr, err := c.getBuilder(rawBuilderImage)
 	if err != nil {
 		return errors.Wrapf(err, "invalid builder %s", style.Symbol(opts.Builder))
@@ -367,6 +502,16 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		return errors.Wrapf(err, "invalid run-image '%s'", runImageName)
 	}
 
+	if platform != nil {
+		runImageArch, err := runImage.Architecture()
+		if err != nil {
+			return errors.Wrapf(err, "getting run-image architecture")
+		}
+		if runImageArch != platform.Architecture {
+			return errors.Errorf("run-image %s has architecture %s, expected %s", style.Symbol(runImageName), style.Symbol(runImageArch), style.Symbol(platform.Architecture))
+		}
+	}
+
 	var runMixins []string
 	if _, err := dist.GetLabel(runImage, stack.MixinsLabel, &runMixins); err != nil {
 		return err
@@ -382,6 +527,12 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		return err
 	}
 
+	if platform != nil {
+		if err = c.validateTargets(bldr.Image(), fetchedBPs, platform); err != nil {
+			return fmt.Errorf("validating buildpack targets: %w", err)
+		}
+	}
+
 	// Default mode: if the TrustBuilder option is not set, trust the suggested builders.
 	if opts.TrustBuilder == nil {
 		opts.TrustBuilder = IsTrustedBuilderFunc
@@ -400,9 +551,16 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 	// Get the platform API version to use
 	lifecycleVersion := bldr.LifecycleDescriptor().Info.Version
 	useCreator := supportsCreator(lifecycleVersion) && opts.TrustBuilder(opts.Builder)
+	if opts.Runtime == build.RuntimeLocal && !useCreator {
+		return errors.Errorf("the %s runtime requires a trusted builder", style.Symbol(opts.Runtime))
+	}
+	if remoteHost != nil && !useCreator {
+		return errors.New("building on a remote host requires a trusted builder")
+	}
 	var (
 		lifecycleOptsLifecycleImage string
 		lifecycleAPIs               []string
+		lifecycleImage              imgutil.Image
 	)
 	if !(useCreator) {
 		// fetch the lifecycle image
@@ -412,7 +570,7 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 				lifecycleImageName = fmt.Sprintf("%s:%s", internalConfig.DefaultLifecycleImageRepo, lifecycleVersion.String())
 			}
 
-			lifecycleImage, err := c.imageFetcher.Fetch(
+			lifecycleImage, err = c.imageFetcher.Fetch(
 				ctx,
 				lifecycleImageName,
 				image.FetchOptions{
@@ -460,13 +618,8 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		buildEnvs[k] = v
 	}
 
-	ephemeralBuilder, err := c.createEphemeralBuilder(rawBuilderImage, buildEnvs, order, fetchedBPs, orderExtensions, fetchedExs, usingPlatformAPI.LessThan("0.12"), opts.RunImage)
-	if err != nil {
-		return err
-	}
-	defer c.docker.ImageRemove(context.Background(), ephemeralBuilder.Name(), types.ImageRemoveOptions{Force: true})
-
-	if len(bldr.OrderExtensions()) > 0 || len(ephemeralBuilder.OrderExtensions()) > 0 {
+	// The ephemeral builder uses the builder's order for extensions unless one was provided, see createEphemeralBuilder
+	if len(bldr.OrderExtensions()) > 0 || len(effectiveOrder(orderExtensions, nil)) > 0 {
 		if !c.experimental {
 			return fmt.Errorf("experimental features must be enabled when builder contains image extensions")
 		}
@@ -478,6 +631,17 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		}
 	}
 
+	if opts.Runtime == build.RuntimeLocal && len(effectiveOrder(orderExtensions, bldr.OrderExtensions())) > 0 {
+		return errors.Errorf("builder contains image extensions which are not supported by the %s runtime", style.Symbol(opts.Runtime))
+	}
+	if remoteHost != nil && len(effectiveOrder(orderExtensions, bldr.OrderExtensions())) > 0 {
+		return errors.New("builder contains image extensions which are not supported on a remote host")
+	}
+
+	if !useCreator && !supportsLifecycleImage(lifecycleVersion) && !opts.TrustBuilder(opts.Builder) {
+		return errors.Errorf("Lifecycle %s does not have an associated lifecycle image. Builder must be trusted.", lifecycleVersion.String())
+	}
+
 	if opts.Layout() {
 		opts.ContainerConfig.Volumes = appendLayoutVolumes(opts.ContainerConfig.Volumes, pathsConfig)
 	}
@@ -496,11 +660,59 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		return err
 	}
 
+	if opts.LockFile != "" {
+		if err := c.checkBuildLock(ctx, opts, buildLockInputs{
+			builderName:    builderRef.Name(),
+			builderImage:   rawBuilderImage,
+			runImageName:   runImageName,
+			runImage:       runImage,
+			lifecycleImage: lifecycleImage,
+			builderBPs:     bldr.Buildpacks(),
+			builderExs:     bldr.Extensions(),
+		}); err != nil {
+			return err
+		}
+	}
+
 	runImageName, err = pname.TranslateRegistry(runImageName, c.registryMirrors, c.logger)
 	if err != nil {
 		return err
 	}
 
+	if opts.DryRun {
+		plan := BuildPlan{
+			Image:            imageRef.Name(),
+			Builder:          builderRef.Name(),
+			Platform:         builderOS + "/" + builderArch,
+			RunImage:         runImageName,
+			LifecycleVersion: lifecycleVersion.String(),
+			LifecycleImage:   lifecycleOptsLifecycleImage,
+			PlatformAPI:      usingPlatformAPI.String(),
+			TrustedBuilder:   opts.TrustBuilder(opts.Builder),
+			UseCreator:       useCreator,
+			Publish:          opts.Publish,
+			Buildpacks:       moduleInfos(fetchedBPs),
+			Order:            effectiveOrder(order, bldr.Order()),
+			Extensions:       moduleInfos(fetchedExs),
+			OrderExtensions:  effectiveOrder(orderExtensions, bldr.OrderExtensions()),
+			Env:              buildEnvs,
+			Volumes:          processedVolumes,
+		}
+		if logging.EventsEnabled(c.logger) {
+			logging.LogEvent(c.logger, logging.Event{Type: logging.EventBuildPlan, Image: plan.Image, Plan: plan})
+			return nil
+		}
+		return writeBuildPlan(c.logger.Writer(), plan, opts.DryRunFormat)
+	}
+
+	ephemeralBuilder, err := c.createEphemeralBuilder(rawBuilderImage, buildEnvs, order, fetchedBPs, orderExtensions, fetchedExs, usingPlatformAPI.LessThan("0.12"), opts.RunImage)
+	if err != nil {
+		return err
+	}
+	if opts.Runtime != build.RuntimeLocal && remoteHost == nil {
+		defer c.docker.ImageRemove(context.Background(), ephemeralBuilder.Name(), types.ImageRemoveOptions{Force: true})
+	}
+
 	projectMetadata := files.ProjectMetadata{}
 	if c.experimental {
 		version := opts.ProjectDescriptor.Project.Version
@@ -551,6 +763,30 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		CreationTime:             opts.CreationTime,
 		Layout:                   opts.Layout(),
 		Keychain:                 c.keychain,
+		Platform:                 platform,
+		Runtime:                  opts.Runtime,
+	}
+
+	if steps.signer != nil || opts.ProvenanceOutput != "" {
+		steps.provenance = c.newProvenanceMaterials(ctx, rawBuilderImage, lifecycleVersion.String(), opts, buildEnvs, lifecycleOpts)
+	}
+
+	if opts.Runtime == build.RuntimeLocal {
+		packHome, err := internalConfig.PackHome()
+		if err != nil {
+			return err
+		}
+		lifecycleOpts.LocalRuntimeDir = filepath.Join(packHome, "local-runtime", "volumes")
+	}
+
+	if remoteHost != nil {
+		c.logger.Debugf("Connecting to remote host %s", style.Symbol(remoteHost.Host))
+		shell, err := c.remoteShellDialer(remoteHost)
+		if err != nil {
+			return errors.Wrapf(err, "connecting to remote host %s", style.Symbol(remoteHost.Host))
+		}
+		defer shell.Close()
+		lifecycleOpts.Remote = shell
 	}
 
 	switch {
@@ -559,8 +795,6 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 	case supportsLifecycleImage(lifecycleVersion):
 		lifecycleOpts.LifecycleImage = lifecycleOptsLifecycleImage
 		lifecycleOpts.LifecycleApis = lifecycleAPIs
-	case !opts.TrustBuilder(opts.Builder):
-		return errors.Errorf("Lifecycle %s does not have an associated lifecycle image. Builder must be trusted.", lifecycleVersion.String())
 	}
 
 	lifecycleOpts.FetchRunImageWithLifecycleLayer = func(runImageName string) (string, error) {
@@ -697,12 +931,146 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		return ephemeralRunImageName, nil
 	}
 
-	if err = c.lifecycleExecutor.Execute(ctx, lifecycleOpts); err != nil {
+	if opts.Watch {
+		outputPaths := []string{opts.ReportDestinationDir, opts.SBOMDestinationDir, opts.ProvenanceOutput, opts.LockFile}
+		for _, cacheInfo := range []cache.CacheInfo{opts.Cache.Build, opts.Cache.Launch} {
+			if cacheInfo.Format == cache.CacheBind || cacheInfo.Format == cache.CacheDir {
+				outputPaths = append(outputPaths, cacheInfo.Source)
+			}
+		}
+		return c.watchAndRebuild(ctx, appPath, watchFilter(appPath, fileFilter, outputPaths...), func() error {
+			err := c.executeLifecycle(ctx, lifecycleOpts, opts, imageRef, steps)
+			// the cache only needs to be cleared before the first build
+			lifecycleOpts.ClearCache = false
+			return err
+		})
+	}
+
+	if opts.VerifyReproducible {
+		lifecycleOpts = c.reproducibleBuildOptions(lifecycleOpts, opts)
+	}
+	if err := c.executeLifecycle(ctx, lifecycleOpts, opts, imageRef, steps); err != nil {
+		return err
+	}
+	if opts.VerifyReproducible {
+		return c.verifyReproducible(ctx, lifecycleOpts, opts, imageRef)
+	}
+	return nil
+}
+
+// validateVerifyReproducible validates the options combined with opts.VerifyReproducible
+func validateVerifyReproducible(opts BuildOptions) error {
+	switch {
+	case opts.Watch || opts.DryRun || opts.Interactive:
+		return errors.New("verifying reproducibility cannot be combined with watch, dry run or interactive mode")
+	case opts.Layout():
+		return errors.New("verifying reproducibility is not supported when exporting to an OCI layout")
+	case opts.Publish && opts.PreviousImage == "":
+		return errors.New("verifying reproducibility of a published image requires a previous image to compare with")
+	case opts.PreviousImage != "" && sameImage(opts.Image, opts.PreviousImage):
+		// the built image replaces the previous image, which would then be compared with itself
+		return errors.New("verifying reproducibility requires a previous image other than the built image")
+	}
+	return nil
+}
+
+func sameImage(ref1, ref2 string) bool {
+	parsed1, err := name.ParseReference(ref1, name.WeakValidation)
+	if err != nil {
+		return false
+	}
+	parsed2, err := name.ParseReference(ref2, name.WeakValidation)
+	if err != nil {
+		return false
+	}
+	return parsed1.Name() == parsed2.Name()
+}
+
+// exportSteps are run once the lifecycle has exported the app image
+type exportSteps struct {
+	sbomPolicy *sbom.Policy
+	signer     *sign.Signer
+	// provenance is only gathered when the image is signed or its provenance is written
+	provenance provenanceMaterials
+}
+
+func (c *Client) executeLifecycle(ctx context.Context, lifecycleOpts build.LifecycleOptions, opts BuildOptions, imageRef name.Reference, steps exportSteps) error {
+	logging.LogEvent(c.logger, logging.Event{Type: logging.EventBuildStarted, Image: imageRef.Name(), Builder: lifecycleOpts.BuilderImage, RunImage: lifecycleOpts.RunImage})
+	start := time.Now()
+	if err := c.lifecycleExecutor.Execute(ctx, lifecycleOpts); err != nil {
+		logging.LogEvent(c.logger, logging.Event{Type: logging.EventBuildFinished, Image: imageRef.Name(), Error: err.Error()}.WithDuration(time.Since(start)))
 		return fmt.Errorf("executing lifecycle: %w", err)
 	}
+	logging.LogEvent(c.logger, logging.Event{Type: logging.EventBuildFinished, Image: imageRef.Name()}.WithDuration(time.Since(start)))
+
+	if logging.EventsEnabled(c.logger) && !opts.Layout() {
+		digest, err := c.builtImageDigest(ctx, opts.Publish, imageRef)
+		if err != nil {
+			return err
+		}
+		logging.LogEvent(c.logger, logging.Event{Type: logging.EventImageExported, Image: imageRef.Name(), Digest: digest})
+	}
+
+	if steps.sbomPolicy != nil {
+		if err := c.enforceSBOMPolicy(imageRef.Name(), opts.Publish, opts.SBOMPolicy, *steps.sbomPolicy); err != nil {
+			return err
+		}
+	}
+	finish := time.Now()
+	if steps.signer != nil {
+		if err := c.signAndAttest(ctx, steps.signer, imageRef, lifecycleOpts, steps.provenance, start, finish); err != nil {
+			return err
+		}
+	}
+	if opts.ProvenanceOutput != "" {
+		if err := c.writeProvenance(ctx, opts.ProvenanceOutput, imageRef, opts.Publish, lifecycleOpts, steps.provenance, start, finish); err != nil {
+			return err
+		}
+	}
 	return c.logImageNameAndSha(ctx, opts.Publish, imageRef)
 }
 
+// enforceSBOMPolicy checks the SBOM of the built image against a policy, logging any violations. A published image
+// is read from the registry it was pushed to.
+func (c *Client) enforceSBOMPolicy(imageName string, publish bool, policyPath string, policy sbom.Policy) error {
+	report, err := c.checkSBOM(imageName, !publish, policy)
+	if err != nil {
+		return errors.Wrap(err, "checking SBOM policy")
+	}
+
+	if report.Passed() {
+		c.logger.Debugf("SBOM of %s complies with policy %s, %d packages checked", style.Symbol(imageName), style.Symbol(policyPath), report.Packages)
+		return nil
+	}
+
+	for _, violation := range report.Violations {
+		pkgName := violation.Package.Name
+		if violation.Package.Version != "" {
+			pkgName += "@" + violation.Package.Version
+		}
+		c.logger.Errorf("%s %s: %s", violation.Rule, pkgName, violation.Details)
+	}
+	return errors.Errorf("image %s violates SBOM policy %s: %d violation(s) found", style.Symbol(imageName), style.Symbol(policyPath), len(report.Violations))
+}
+
+// parseRemoteHost validates opts.RemoteHost and the options it is combined with
+func parseRemoteHost(opts BuildOptions) (*url.URL, error) {
+	remoteHost, err := url.Parse(opts.RemoteHost)
+	if err != nil || remoteHost.Scheme != "ssh" || remoteHost.Host == "" {
+		return nil, errors.Errorf("invalid remote host %s, must be of the form %s", style.Symbol(opts.RemoteHost), style.Symbol("ssh://[user@]host[:port]"))
+	}
+
+	switch {
+	case !opts.Publish:
+		return nil, errors.New("building on a remote host requires the image to be published")
+	case opts.Runtime == build.RuntimeLocal:
+		return nil, errors.Errorf("a remote host cannot be used with the %s runtime", style.Symbol(opts.Runtime))
+	case opts.Interactive:
+		return nil, errors.New("a remote host cannot be used in interactive mode")
+	}
+	return remoteHost, nil
+}
+
 func extractSupportedLifecycleApis(labels map[string]string) ([]string, error) {
 	// sample contents of labels:
 	//    {io.buildpacks.builder.metadata:\"{\"lifecycle\":{\"version\":\"0.15.3\"},\"api\":{\"buildpack\":\"0.2\",\"platform\":\"0.3\"}}",
@@ -817,6 +1185,38 @@ func (c *Client) validateRunImage(context context.Context, name string, opts ima
 	return img, nil
 }
 
+func (c *Client) validateTargets(builderImage imgutil.Image, additionalBuildpacks []buildpack.BuildModule, platform *specs.Platform) error {
+	bps, err := allBuildpacks(builderImage, additionalBuildpacks)
+	if err != nil {
+		return err
+	}
+
+	for _, bp := range bps {
+		if err := bp.EnsureTargetSupport(platform.OS, platform.Architecture, "", ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parsePlatform parses a platform in the form os/arch[/variant]. An empty platform returns nil.
+func parsePlatform(platform string) (*specs.Platform, error) {
+	if platform == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(platform, "/")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+		return nil, errors.Errorf("invalid platform %s, must be in the form %s", style.Symbol(platform), style.Symbol("os/arch[/variant]"))
+	}
+
+	p := &specs.Platform{OS: parts[0], Architecture: parts[1]}
+	if len(parts) == 3 {
+		p.Variant = parts[2]
+	}
+	return p, nil
+}
+
 func (c *Client) validateMixins(additionalBuildpacks []buildpack.BuildModule, bldr *builder.Builder, runImageName string, runMixins []string) error {
 	if err := stack.ValidateMixins(bldr.Image().Name(), bldr.Mixins(), runImageName, runMixins); err != nil {
 		return err
@@ -1168,7 +1568,7 @@ func (c *Client) fetchBuildpack(ctx context.Context, bp string, relativeBaseDir
 	var moduleInfo *dist.ModuleInfo
 	switch locatorType {
 	case buildpack.IDLocator:
-		id, version := buildpack.ParseIDLocator(bp)
+		id, version := buildpack.ResolveBuilderVersion(bp, builderBPs)
 		moduleInfo = &dist.ModuleInfo{
 			ID:      id,
 			Version: version,
@@ -1422,26 +1822,38 @@ func (c *Client) logImageNameAndSha(ctx context.Context, publish bool, imageRef
 	if !logging.IsQuiet(c.logger) {
 		return nil
 	}
-
-	img, err := c.imageFetcher.Fetch(ctx, imageRef.Name(), image.FetchOptions{Daemon: !publish, PullPolicy: image.PullNever})
-	if err != nil {
-		return fmt.Errorf("fetching built image: %w", err)
+	// With structured events, the image_exported event already carries the digest
+	if logging.EventsEnabled(c.logger) {
+		return nil
 	}
 
-	id, err := img.Identifier()
+	digest, err := c.builtImageDigest(ctx, publish, imageRef)
 	if err != nil {
-		return fmt.Errorf("reading image sha: %w", err)
+		return err
 	}
 
 	// Remove tag, if it exists, from the image name
 	imgName := strings.TrimSuffix(imageRef.String(), imageRef.Identifier())
-	imgNameAndSha := fmt.Sprintf("%s@%s\n", imgName, parseDigestFromImageID(id))
+	imgNameAndSha := fmt.Sprintf("%s@%s\n", imgName, digest)
 
 	// Access the logger's Writer directly to bypass ReportSuccessfulQuietBuild mode
 	_, err = c.logger.Writer().Write([]byte(imgNameAndSha))
 	return err
 }
 
+func (c *Client) builtImageDigest(ctx context.Context, publish bool, imageRef name.Reference) (string, error) {
+	img, err := c.imageFetcher.Fetch(ctx, imageRef.Name(), image.FetchOptions{Daemon: !publish, PullPolicy: image.PullNever})
+	if err != nil {
+		return "", fmt.Errorf("fetching built image: %w", err)
+	}
+
+	id, err := img.Identifier()
+	if err != nil {
+		return "", fmt.Errorf("reading image sha: %w", err)
+	}
+	return parseDigestFromImageID(id), nil
+}
+
 func parseDigestFromImageID(id imgutil.Identifier) string {
 	var digest string
 	switch v := id.(type) {
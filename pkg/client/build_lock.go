@@ -0,0 +1,330 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/buildpacks/imgutil"
+	"github.com/buildpacks/imgutil/remote"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/paths"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+// BuildLockFileName is the name of the lockfile kept next to the project descriptor.
+const BuildLockFileName = "project.lock"
+
+// BuildLock records the inputs resolved for a build, so that later builds can verify that they use the same inputs.
+type BuildLock struct {
+	Builder   ImageLock  `toml:"builder"`
+	RunImage  ImageLock  `toml:"run-image"`
+	Lifecycle *ImageLock `toml:"lifecycle,omitempty"`
+	// Buildpacks and Extensions are the modules declared for the build, in order of declaration.
+	Buildpacks []ModuleLock `toml:"buildpacks,omitempty"`
+	Extensions []ModuleLock `toml:"extensions,omitempty"`
+}
+
+// ImageLock is an image resolved for a build. It is identified by its manifest digest when known, or else by its
+// image ID.
+type ImageLock struct {
+	Name   string `toml:"name"`
+	Digest string `toml:"digest"`
+}
+
+func (i ImageLock) String() string {
+	if i.Digest == "" {
+		return i.Name
+	}
+	return fmt.Sprintf("%s@%s", i.Name, i.Digest)
+}
+
+// ModuleLock is a buildpack or extension resolved for a build. Only the fields that apply to the kind of locator are set.
+type ModuleLock struct {
+	// Locator is the module as declared, e.g. example/my-buildpack@^1.2 or ./my-buildpack.
+	Locator string `toml:"locator"`
+	// ID and Version of a module on the builder.
+	ID      string `toml:"id,omitempty"`
+	Version string `toml:"version,omitempty"`
+	// Address is the digest reference a registry module resolved to.
+	Address string `toml:"address,omitempty"`
+	// SHA256 is the digest of the archive of a module from a URI or path.
+	SHA256 string `toml:"sha256,omitempty"`
+	// Digest of the image of a packaged module.
+	Digest string `toml:"digest,omitempty"`
+}
+
+func (m ModuleLock) String() string {
+	switch {
+	case m.Address != "":
+		return m.Address
+	case m.SHA256 != "":
+		return "sha256:" + m.SHA256
+	case m.Digest != "":
+		return m.Digest
+	case m.ID != "":
+		return fmt.Sprintf("%s@%s", m.ID, m.Version)
+	default:
+		return "(unresolved)"
+	}
+}
+
+// buildLockInputs are the images resolved for a build, along with the modules on its builder
+type buildLockInputs struct {
+	builderName    string
+	builderImage   imgutil.Image
+	runImageName   string
+	runImage       imgutil.Image
+	lifecycleImage imgutil.Image
+	builderBPs     []dist.ModuleInfo
+	builderExs     []dist.ModuleInfo
+}
+
+// checkBuildLock compares the inputs resolved for a build with those recorded in opts.LockFile. The lockfile is
+// written when it doesn't exist or opts.UpdateLock is set; otherwise the build fails when any input differs.
+func (c *Client) checkBuildLock(ctx context.Context, opts BuildOptions, inputs buildLockInputs) error {
+	resolved, err := c.resolveBuildLock(ctx, opts, inputs)
+	if err != nil {
+		return errors.Wrap(err, "resolving build inputs for lockfile")
+	}
+
+	locked, err := readBuildLock(opts.LockFile)
+	if os.IsNotExist(errors.Cause(err)) || opts.UpdateLock {
+		if err := writeBuildLock(opts.LockFile, resolved); err != nil {
+			return err
+		}
+		c.logger.Infof("Wrote build inputs to lockfile %s", style.Symbol(opts.LockFile))
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	drifts := diffBuildLocks(locked, resolved)
+	if len(drifts) == 0 {
+		c.logger.Debugf("Build inputs match lockfile %s", style.Symbol(opts.LockFile))
+		return nil
+	}
+	return errors.Errorf("build inputs differ from lockfile %s:\n  %s", style.Symbol(opts.LockFile), strings.Join(drifts, "\n  "))
+}
+
+func (c *Client) resolveBuildLock(ctx context.Context, opts BuildOptions, inputs buildLockInputs) (BuildLock, error) {
+	var (
+		lock BuildLock
+		err  error
+	)
+	if lock.Builder, err = c.imageLock(ctx, inputs.builderName, inputs.builderImage); err != nil {
+		return BuildLock{}, err
+	}
+	if lock.RunImage, err = c.imageLock(ctx, inputs.runImageName, inputs.runImage); err != nil {
+		return BuildLock{}, err
+	}
+	if inputs.lifecycleImage != nil {
+		lifecycleLock, err := c.imageLock(ctx, inputs.lifecycleImage.Name(), inputs.lifecycleImage)
+		if err != nil {
+			return BuildLock{}, err
+		}
+		lock.Lifecycle = &lifecycleLock
+	}
+
+	relativeBaseDir := opts.RelativeBaseDir
+	buildpacks := opts.Buildpacks
+	if len(buildpacks) == 0 && len(opts.ProjectDescriptor.Build.Buildpacks) != 0 {
+		relativeBaseDir = opts.ProjectDescriptorBaseDir
+		for _, bp := range opts.ProjectDescriptor.Build.Buildpacks {
+			// inline buildpacks are part of the project descriptor itself
+			if bp.Script.Inline != "" && bp.URI == "" {
+				continue
+			}
+			locator, err := getBuildpackLocator(bp, "")
+			if err != nil {
+				return BuildLock{}, err
+			}
+			buildpacks = append(buildpacks, locator)
+		}
+	}
+	buildpacks = append(append(buildpacks, opts.PreBuildpacks...), opts.PostBuildpacks...)
+
+	if lock.Buildpacks, err = c.lockModules(ctx, buildpacks, relativeBaseDir, inputs.builderBPs, opts); err != nil {
+		return BuildLock{}, err
+	}
+	if lock.Extensions, err = c.lockModules(ctx, opts.Extensions, opts.RelativeBaseDir, inputs.builderExs, opts); err != nil {
+		return BuildLock{}, err
+	}
+	return lock, nil
+}
+
+func (c *Client) lockModules(ctx context.Context, locators []string, relativeBaseDir string, builderModules []dist.ModuleInfo, opts BuildOptions) ([]ModuleLock, error) {
+	var modules []ModuleLock
+	for _, locator := range locators {
+		locatorType, err := buildpack.GetLocatorType(locator, relativeBaseDir, builderModules)
+		if err != nil {
+			return nil, err
+		}
+
+		module := ModuleLock{Locator: locator}
+		switch locatorType {
+		case buildpack.FromBuilderLocator:
+			continue
+		case buildpack.IDLocator:
+			module.ID, module.Version = buildpack.ResolveBuilderVersion(locator, builderModules)
+		case buildpack.RegistryLocator:
+			resolver := &registryResolver{logger: c.logger, keychain: c.keychain}
+			if module.Address, err = resolver.Resolve(opts.Registry, locator); err != nil {
+				return nil, errors.Wrapf(err, "locating in registry: %s", style.Symbol(locator))
+			}
+		case buildpack.PackageLocator:
+			img, err := c.imageFetcher.Fetch(ctx, buildpack.ParsePackageLocator(locator), image.FetchOptions{Daemon: !opts.Publish, PullPolicy: image.PullNever})
+			if err != nil {
+				return nil, errors.Wrapf(err, "fetching image %s", style.Symbol(locator))
+			}
+			if module.Digest, err = c.lockDigest(ctx, img); err != nil {
+				return nil, err
+			}
+		case buildpack.URILocator:
+			uri, err := paths.FilePathToURI(locator, relativeBaseDir)
+			if err != nil {
+				return nil, errors.Wrapf(err, "making absolute: %s", style.Symbol(locator))
+			}
+			if module.SHA256, err = c.blobSHA256(ctx, uri); err != nil {
+				return nil, errors.Wrapf(err, "hashing %s", style.Symbol(locator))
+			}
+		default:
+			return nil, errors.Errorf("invalid locator %s", style.Symbol(locator))
+		}
+		modules = append(modules, module)
+	}
+	return modules, nil
+}
+
+func (c *Client) imageLock(ctx context.Context, imageName string, img imgutil.Image) (ImageLock, error) {
+	digest, err := c.lockDigest(ctx, img)
+	if err != nil {
+		return ImageLock{}, err
+	}
+	return ImageLock{Name: imageName, Digest: digest}, nil
+}
+
+// lockDigest returns the digest of an image in its repository when known, or else its identifier. The digest is the
+// same whether the image was fetched from the daemon or the registry, so that publishing doesn't report drift.
+func (c *Client) lockDigest(ctx context.Context, img imgutil.Image) (string, error) {
+	if id, err := img.Identifier(); err == nil {
+		if _, ok := id.(remote.DigestIdentifier); ok {
+			digest, err := c.repositoryDigest(ctx, img)
+			if err != nil {
+				return "", errors.Wrapf(err, "reading digest of %s", style.Symbol(img.Name()))
+			}
+			return digest, nil
+		}
+	}
+
+	if digest := c.imageDigest(ctx, img); digest != "" {
+		return digest, nil
+	}
+	if id, err := img.Identifier(); err == nil && id != nil {
+		return id.String(), nil
+	}
+	return "", nil
+}
+
+func (c *Client) blobSHA256(ctx context.Context, uri string) (string, error) {
+	b, err := c.downloader.Download(ctx, uri)
+	if err != nil {
+		return "", err
+	}
+	rc, err := b.Open()
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, rc); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
+
+func readBuildLock(path string) (BuildLock, error) {
+	var lock BuildLock
+	md, err := toml.DecodeFile(path, &lock)
+	if err != nil {
+		return BuildLock{}, errors.Wrapf(err, "reading lockfile %s", style.Symbol(path))
+	}
+	if undecoded := md.Undecoded(); len(undecoded) > 0 {
+		return BuildLock{}, errors.Errorf("lockfile %s has unknown keys %s", style.Symbol(path), undecoded)
+	}
+	return lock, nil
+}
+
+func writeBuildLock(path string, lock BuildLock) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return errors.Wrap(err, "writing lockfile")
+	}
+	defer f.Close()
+
+	if _, err := io.WriteString(f, "# Inputs resolved for pack build. Regenerate with pack build --update-lock.\n\n"); err != nil {
+		return errors.Wrap(err, "writing lockfile")
+	}
+	if err := toml.NewEncoder(f).Encode(lock); err != nil {
+		return errors.Wrap(err, "writing lockfile")
+	}
+	return nil
+}
+
+// diffBuildLocks describes the inputs that differ between two builds
+func diffBuildLocks(locked, resolved BuildLock) []string {
+	var drifts []string
+	if locked.Builder != resolved.Builder {
+		drifts = append(drifts, fmt.Sprintf("builder: %s -> %s", locked.Builder, resolved.Builder))
+	}
+	if locked.RunImage != resolved.RunImage {
+		drifts = append(drifts, fmt.Sprintf("run image: %s -> %s", locked.RunImage, resolved.RunImage))
+	}
+	if lockedLifecycle, resolvedLifecycle := imageLockOrEmpty(locked.Lifecycle), imageLockOrEmpty(resolved.Lifecycle); lockedLifecycle != resolvedLifecycle {
+		drifts = append(drifts, fmt.Sprintf("lifecycle: %s -> %s", orNone(lockedLifecycle.String()), orNone(resolvedLifecycle.String())))
+	}
+	drifts = append(drifts, diffModuleLocks(buildpack.KindBuildpack, locked.Buildpacks, resolved.Buildpacks)...)
+	drifts = append(drifts, diffModuleLocks(buildpack.KindExtension, locked.Extensions, resolved.Extensions)...)
+	return drifts
+}
+
+func diffModuleLocks(kind string, locked, resolved []ModuleLock) []string {
+	var drifts []string
+	for i := 0; i < len(locked) || i < len(resolved); i++ {
+		switch {
+		case i >= len(resolved):
+			drifts = append(drifts, fmt.Sprintf("%s %s: removed", kind, style.Symbol(locked[i].Locator)))
+		case i >= len(locked):
+			drifts = append(drifts, fmt.Sprintf("%s %s: added", kind, style.Symbol(resolved[i].Locator)))
+		case locked[i].Locator != resolved[i].Locator:
+			drifts = append(drifts, fmt.Sprintf("%s %s: replaced by %s", kind, style.Symbol(locked[i].Locator), style.Symbol(resolved[i].Locator)))
+		case locked[i] != resolved[i]:
+			drifts = append(drifts, fmt.Sprintf("%s %s: %s -> %s", kind, style.Symbol(locked[i].Locator), locked[i], resolved[i]))
+		}
+	}
+	return drifts
+}
+
+func imageLockOrEmpty(lock *ImageLock) ImageLock {
+	if lock == nil {
+		return ImageLock{}
+	}
+	return *lock
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
@@ -0,0 +1,122 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/buildpacks/imgutil/local"
+	"github.com/buildpacks/imgutil/remote"
+	"github.com/docker/docker/api/types"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	ggcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBuildLock(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "BuildLock", testBuildLock, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBuildLock(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		mockController   *gomock.Controller
+		mockDockerClient *testmocks.MockCommonAPIClient
+		server           *httptest.Server
+		imageName        string
+		indexDigest      v1.Hash
+		manifestDigest   v1.Hash
+		outBuf           bytes.Buffer
+	)
+
+	it.Before(func() {
+		server = httptest.NewServer(registry.New())
+		imageName = strings.TrimPrefix(server.URL, "http://") + "/some/run:latest"
+
+		img, err := random.Image(10, 1)
+		h.AssertNil(t, err)
+		img, err = mutate.ConfigFile(img, &v1.ConfigFile{OS: "linux", Architecture: "amd64"})
+		h.AssertNil(t, err)
+		index := mutate.AppendManifests(empty.Index, mutate.IndexAddendum{
+			Add:        img,
+			Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "linux", Architecture: "amd64"}},
+		})
+		tag, err := name.NewTag(imageName)
+		h.AssertNil(t, err)
+		h.AssertNil(t, ggcrremote.WriteIndex(tag, index))
+
+		indexDigest, err = index.Digest()
+		h.AssertNil(t, err)
+		manifestDigest, err = img.Digest()
+		h.AssertNil(t, err)
+
+		mockController = gomock.NewController(t)
+		mockDockerClient = testmocks.NewMockCommonAPIClient(mockController)
+		subject = &Client{
+			logger:   logging.NewLogWithWriters(&outBuf, &outBuf),
+			docker:   mockDockerClient,
+			keychain: authn.DefaultKeychain,
+		}
+	})
+
+	it.After(func() {
+		mockController.Finish()
+		server.Close()
+	})
+
+	when("#lockDigest", func() {
+		it("records the same digest whether the image was fetched from the daemon or the registry", func() {
+			repoDigest := strings.TrimSuffix(imageName, ":latest") + "@" + indexDigest.String()
+			mockDockerClient.EXPECT().
+				ImageInspectWithRaw(gomock.Any(), imageName).
+				Return(types.ImageInspect{RepoDigests: []string{"other/run@sha256:" + strings.Repeat("b", 64), repoDigest}}, nil, nil)
+			daemonDigest, err := subject.lockDigest(context.TODO(), fakes.NewImage(imageName, "", nil))
+			h.AssertNil(t, err)
+
+			manifestRef, err := name.NewDigest(strings.TrimSuffix(imageName, ":latest") + "@" + manifestDigest.String())
+			h.AssertNil(t, err)
+			publishDigest, err := subject.lockDigest(context.TODO(), fakes.NewImage(imageName, "", remote.DigestIdentifier{Digest: manifestRef}))
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, daemonDigest, indexDigest.String())
+			h.AssertEq(t, publishDigest, daemonDigest)
+		})
+
+		it("records the image ID of daemon images that are not in a registry", func() {
+			mockDockerClient.EXPECT().
+				ImageInspectWithRaw(gomock.Any(), "some/app").
+				Return(types.ImageInspect{}, nil, nil)
+			id := "sha256:" + strings.Repeat("c", 64)
+
+			digest, err := subject.lockDigest(context.TODO(), fakes.NewImage("some/app", "", local.IDIdentifier{ImageID: id}))
+			h.AssertNil(t, err)
+			h.AssertEq(t, digest, id)
+		})
+
+		it("fails when the digest of a registry image cannot be resolved", func() {
+			manifestRef, err := name.NewDigest(strings.TrimSuffix(imageName, ":latest") + "@sha256:" + strings.Repeat("d", 64))
+			h.AssertNil(t, err)
+
+			_, err = subject.lockDigest(context.TODO(), fakes.NewImage(imageName, "", remote.DigestIdentifier{Digest: manifestRef}))
+			h.AssertError(t, err, "reading digest of")
+		})
+	})
+}
@@ -0,0 +1,142 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/dist"
+)
+
+const (
+	// DryRunFormatText prints the build plan in a human-readable format
+	DryRunFormatText = "text"
+	// DryRunFormatJSON prints the build plan as JSON
+	DryRunFormatJSON = "json"
+)
+
+// BuildPlan describes how a build would run: the images, lifecycle and buildpacks resolved from the BuildOptions.
+// It is printed instead of running the lifecycle when BuildOptions.DryRun is set.
+type BuildPlan struct {
+	Image            string            `json:"image"`
+	Builder          string            `json:"builder"`
+	Platform         string            `json:"platform"`
+	RunImage         string            `json:"run_image"`
+	LifecycleVersion string            `json:"lifecycle_version"`
+	LifecycleImage   string            `json:"lifecycle_image,omitempty"`
+	PlatformAPI      string            `json:"platform_api"`
+	TrustedBuilder   bool              `json:"trusted_builder"`
+	UseCreator       bool              `json:"use_creator"`
+	Publish          bool              `json:"publish"`
+	Buildpacks       []dist.ModuleInfo `json:"buildpacks"`
+	Order            dist.Order        `json:"order"`
+	Extensions       []dist.ModuleInfo `json:"extensions,omitempty"`
+	OrderExtensions  dist.Order        `json:"order_extensions,omitempty"`
+	Env              map[string]string `json:"env,omitempty"`
+	Volumes          []string          `json:"volumes,omitempty"`
+}
+
+// effectiveOrder returns the order the ephemeral builder will use: the resolved order when one was
+// provided, otherwise the builder's own order. See createEphemeralBuilder.
+func effectiveOrder(resolved, builderOrder dist.Order) dist.Order {
+	if len(resolved) > 0 && len(resolved[0].Group) > 0 {
+		return resolved
+	}
+	return builderOrder
+}
+
+func moduleInfos(modules []buildpack.BuildModule) []dist.ModuleInfo {
+	infos := []dist.ModuleInfo{}
+	for _, module := range modules {
+		infos = append(infos, module.Descriptor().Info())
+	}
+	return infos
+}
+
+func writeBuildPlan(out io.Writer, plan BuildPlan, format string) error {
+	switch format {
+	case "", DryRunFormatText:
+		return writeBuildPlanText(out, plan)
+	case DryRunFormatJSON:
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(plan)
+	default:
+		return errors.Errorf("invalid dry-run format '%s', must be one of '%s' or '%s'", format, DryRunFormatText, DryRunFormatJSON)
+	}
+}
+
+func writeBuildPlanText(out io.Writer, plan BuildPlan) error {
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Image:\t%s\n", plan.Image)
+	fmt.Fprintf(tw, "Builder:\t%s\n", plan.Builder)
+	fmt.Fprintf(tw, "Platform:\t%s\n", plan.Platform)
+	fmt.Fprintf(tw, "Run image:\t%s\n", plan.RunImage)
+	fmt.Fprintf(tw, "Lifecycle:\t%s\n", plan.LifecycleVersion)
+	if plan.LifecycleImage != "" {
+		fmt.Fprintf(tw, "Lifecycle image:\t%s\n", plan.LifecycleImage)
+	}
+	fmt.Fprintf(tw, "Platform API:\t%s\n", plan.PlatformAPI)
+	fmt.Fprintf(tw, "Trusted builder:\t%t\n", plan.TrustedBuilder)
+	fmt.Fprintf(tw, "Use creator:\t%t\n", plan.UseCreator)
+	fmt.Fprintf(tw, "Publish:\t%t\n", plan.Publish)
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	writeModules(out, "Buildpacks added to builder", plan.Buildpacks)
+	writeOrder(out, "Detection order", plan.Order)
+	if len(plan.OrderExtensions) > 0 {
+		writeModules(out, "Extensions added to builder", plan.Extensions)
+		writeOrder(out, "Detection order (extensions)", plan.OrderExtensions)
+	}
+
+	if len(plan.Env) > 0 {
+		fmt.Fprintln(out, "\nBuild environment:")
+		var keys []string
+		for key := range plan.Env {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Fprintf(out, "  %s=%s\n", key, plan.Env[key])
+		}
+	}
+
+	if len(plan.Volumes) > 0 {
+		fmt.Fprintln(out, "\nVolumes:")
+		fmt.Fprintf(out, "  %s\n", strings.Join(plan.Volumes, "\n  "))
+	}
+	return nil
+}
+
+func writeModules(out io.Writer, title string, modules []dist.ModuleInfo) {
+	fmt.Fprintf(out, "\n%s:\n", title)
+	if len(modules) == 0 {
+		fmt.Fprintln(out, "  (none)")
+		return
+	}
+	for _, module := range modules {
+		fmt.Fprintf(out, "  %s\n", module.FullName())
+	}
+}
+
+func writeOrder(out io.Writer, title string, order dist.Order) {
+	fmt.Fprintf(out, "\n%s:\n", title)
+	for i, entry := range order {
+		fmt.Fprintf(out, "  Group #%d:\n", i+1)
+		for _, ref := range entry.Group {
+			optional := ""
+			if ref.Optional {
+				optional = " (optional)"
+			}
+			fmt.Fprintf(out, "    %s%s\n", ref.FullName(), optional)
+		}
+	}
+}
@@ -3,17 +3,25 @@ package client
 import (
 	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/sha256"
+	"crypto/x509"
 	"encoding/hex"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/Masterminds/semver"
 	"github.com/buildpacks/imgutil"
@@ -23,9 +31,16 @@ import (
 	"github.com/buildpacks/lifecycle/api"
 	"github.com/buildpacks/lifecycle/platform/files"
 	dockerclient "github.com/docker/docker/client"
+	"github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	ggcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/heroku/color"
 	"github.com/onsi/gomega/ghttp"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
 	"github.com/sclevine/spec"
 	"github.com/sclevine/spec/report"
@@ -166,6 +181,730 @@ func testBuild(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("Watch option", func() {
+			var (
+				appDir                            string
+				origPollInterval, origDebounceFor time.Duration
+			)
+
+			it.Before(func() {
+				var err error
+				appDir, err = os.MkdirTemp(tmpDir, "watched-app")
+				h.AssertNil(t, err)
+				h.AssertNil(t, os.WriteFile(filepath.Join(appDir, "main.go"), []byte("package main"), 0600))
+
+				origPollInterval, origDebounceFor = watchPollInterval, watchDebounce
+				watchPollInterval, watchDebounce = 10*time.Millisecond, 20*time.Millisecond
+			})
+
+			it.After(func() {
+				watchPollInterval, watchDebounce = origPollInterval, origDebounceFor
+			})
+
+			it("rebuilds when the app changes, until the context is cancelled", func() {
+				ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Second)
+				defer cancel()
+
+				fakeLifecycle.ExecuteFunc = func(opts build.LifecycleOptions) error {
+					switch fakeLifecycle.ExecuteCallCount {
+					case 1:
+						h.AssertEq(t, opts.ClearCache, true)
+						future := time.Now().Add(time.Minute)
+						h.AssertNil(t, os.WriteFile(filepath.Join(appDir, "main.go"), []byte("package main // changed"), 0600))
+						h.AssertNil(t, os.Chtimes(filepath.Join(appDir, "main.go"), future, future))
+						return errors.New("some-build-error")
+					default:
+						h.AssertEq(t, opts.ClearCache, false)
+						cancel()
+						return nil
+					}
+				}
+
+				h.AssertNil(t, subject.Build(ctx, BuildOptions{
+					Image:      "some/app",
+					Builder:    defaultBuilderName,
+					AppPath:    appDir,
+					ClearCache: true,
+					Watch:      true,
+				}))
+
+				h.AssertEq(t, fakeLifecycle.ExecuteCallCount, 2)
+				h.AssertContains(t, outBuf.String(), "some-build-error")
+				h.AssertContains(t, outBuf.String(), "Detected changes to main.go, rebuilding")
+			})
+
+			it("doesn't rebuild when the build writes its outputs within the app", func() {
+				ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Second)
+				defer cancel()
+
+				reportDir := filepath.Join(appDir, "report")
+				fakeLifecycle.ExecuteFunc = func(opts build.LifecycleOptions) error {
+					h.AssertNil(t, os.MkdirAll(reportDir, 0755))
+					h.AssertNil(t, os.WriteFile(filepath.Join(reportDir, "report.toml"), []byte(time.Now().String()), 0600))
+					if fakeLifecycle.ExecuteCallCount == 1 {
+						go func() {
+							time.Sleep(200 * time.Millisecond)
+							cancel()
+						}()
+					}
+					return nil
+				}
+
+				h.AssertNil(t, subject.Build(ctx, BuildOptions{
+					Image:                "some/app",
+					Builder:              defaultBuilderName,
+					AppPath:              appDir,
+					ReportDestinationDir: reportDir,
+					Watch:                true,
+				}))
+
+				h.AssertEq(t, fakeLifecycle.ExecuteCallCount, 1)
+			})
+
+			it("requires the app path to be a directory", func() {
+				h.AssertError(t, subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+					AppPath: filepath.Join("testdata", "zip-file.zip"),
+					Watch:   true,
+				}), "watch mode requires app path")
+			})
+		})
+
+		when("DryRun option", func() {
+			it("prints the build plan without running the lifecycle", func() {
+				fakeImageFetcher.RemoteImages["registry1.example.com/run/mirror"] = fakeDefaultRunImage
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:   "registry1.example.com/some/app",
+					Builder: defaultBuilderName,
+					Publish: true,
+					Env:     map[string]string{"SOME_VAR": "some-value"},
+					DryRun:  true,
+				}))
+
+				h.AssertNil(t, fakeLifecycle.Opts.Image)
+				h.AssertContains(t, outBuf.String(), "Run image:        registry1.example.com/run/mirror")
+				h.AssertContains(t, outBuf.String(), fmt.Sprintf("Lifecycle:        %s", builder.DefaultLifecycleVersion))
+				h.AssertContains(t, outBuf.String(), "Detection order:\n  Group #1:\n    buildpack.1.id@buildpack.1.version")
+				h.AssertContains(t, outBuf.String(), "Build environment:\n  SOME_VAR=some-value")
+			})
+
+			it("prints the build plan as JSON", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:        "some/app",
+					Builder:      defaultBuilderName,
+					DryRun:       true,
+					DryRunFormat: DryRunFormatJSON,
+				}))
+
+				var plan BuildPlan
+				h.AssertNil(t, json.Unmarshal(outBuf.Bytes(), &plan))
+				h.AssertEq(t, plan.Image, "index.docker.io/some/app:latest")
+				h.AssertEq(t, plan.Builder, defaultBuilderName)
+				h.AssertEq(t, plan.RunImage, "default/run")
+				h.AssertEq(t, plan.Platform, "linux/amd64")
+				h.AssertEq(t, plan.UseCreator, false)
+				h.AssertEq(t, plan.LifecycleImage, fakeLifecycleImage.Name())
+				h.AssertEq(t, plan.PlatformAPI, "0.12")
+			})
+
+			it("emits the build plan as an event when logging JSON", func() {
+				logger.WantJSON(true)
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+					DryRun:  true,
+				}))
+
+				var plan *BuildPlan
+				for _, line := range strings.Split(strings.TrimSpace(outBuf.String()), "\n") {
+					var event struct {
+						Type string     `json:"type"`
+						Plan *BuildPlan `json:"plan"`
+					}
+					h.AssertNil(t, json.Unmarshal([]byte(line), &event))
+					if event.Type == "build_plan" {
+						plan = event.Plan
+					}
+				}
+				h.AssertNotNil(t, plan)
+				h.AssertEq(t, plan.Image, "index.docker.io/some/app:latest")
+				h.AssertEq(t, plan.Builder, defaultBuilderName)
+			})
+
+			it("fails for an unknown format", func() {
+				h.AssertError(t, subject.Build(context.TODO(), BuildOptions{
+					Image:        "some/app",
+					Builder:      defaultBuilderName,
+					DryRun:       true,
+					DryRunFormat: "yaml",
+				}), "invalid dry-run format 'yaml'")
+			})
+		})
+
+		when("Runtime option", func() {
+			it.Before(func() {
+				fakeImageFetcher.RemoteImages[defaultBuilderName] = defaultBuilderImage
+				fakeImageFetcher.RemoteImages["default/run"] = fakeDefaultRunImage
+			})
+
+			it("fetches the builder to an OCI layout and runs the lifecycle with the local runtime", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:        "some/app",
+					Builder:      defaultBuilderName,
+					Publish:      true,
+					TrustBuilder: func(string) bool { return true },
+					Runtime:      build.RuntimeLocal,
+				}))
+
+				args := fakeImageFetcher.FetchCalls[defaultBuilderName]
+				h.AssertEq(t, args.Daemon, false)
+				h.AssertNotEq(t, args.LayoutOption.Path, "")
+				h.AssertEq(t, fakeLifecycle.Opts.Runtime, build.RuntimeLocal)
+				h.AssertContains(t, fakeLifecycle.Opts.LocalRuntimeDir, filepath.Join("local-runtime", "volumes"))
+			})
+
+			it("requires the image to be published or exported to an OCI layout", func() {
+				h.AssertError(t, subject.Build(context.TODO(), BuildOptions{
+					Image:        "some/app",
+					Builder:      defaultBuilderName,
+					TrustBuilder: func(string) bool { return true },
+					Runtime:      build.RuntimeLocal,
+				}), "the 'local' runtime requires the image to be published or exported to an OCI layout")
+			})
+
+			it("requires a trusted builder", func() {
+				h.AssertError(t, subject.Build(context.TODO(), BuildOptions{
+					Image:        "some/app",
+					Builder:      defaultBuilderName,
+					Publish:      true,
+					TrustBuilder: func(string) bool { return false },
+					Runtime:      build.RuntimeLocal,
+				}), "the 'local' runtime requires a trusted builder")
+			})
+
+			it("fails for an unknown runtime", func() {
+				h.AssertError(t, subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+					Runtime: "some-runtime",
+				}), "invalid runtime 'some-runtime', must be one of 'docker' or 'local'")
+			})
+		})
+
+		when("RemoteHost option", func() {
+			var (
+				shell      *fakeRemoteShell
+				dialedHost *url.URL
+			)
+
+			it.Before(func() {
+				fakeImageFetcher.RemoteImages[defaultBuilderName] = defaultBuilderImage
+				fakeImageFetcher.RemoteImages["default/run"] = fakeDefaultRunImage
+
+				shell = &fakeRemoteShell{}
+				subject.remoteShellDialer = func(host *url.URL) (build.RemoteShell, error) {
+					dialedHost = host
+					return shell, nil
+				}
+			})
+
+			it("fetches the builder to an OCI layout and runs the lifecycle on the remote host", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:        "some/app",
+					Builder:      defaultBuilderName,
+					Publish:      true,
+					TrustBuilder: func(string) bool { return true },
+					RemoteHost:   "ssh://some-user@some-host:2222",
+				}))
+
+				args := fakeImageFetcher.FetchCalls[defaultBuilderName]
+				h.AssertEq(t, args.Daemon, false)
+				h.AssertNotEq(t, args.LayoutOption.Path, "")
+				h.AssertEq(t, dialedHost.String(), "ssh://some-user@some-host:2222")
+				h.AssertSameInstance(t, fakeLifecycle.Opts.Remote, shell)
+				h.AssertTrue(t, shell.closed)
+			})
+
+			it("fails when the remote host cannot be reached", func() {
+				subject.remoteShellDialer = func(host *url.URL) (build.RemoteShell, error) {
+					return nil, errors.New("some-error")
+				}
+
+				h.AssertError(t, subject.Build(context.TODO(), BuildOptions{
+					Image:        "some/app",
+					Builder:      defaultBuilderName,
+					Publish:      true,
+					TrustBuilder: func(string) bool { return true },
+					RemoteHost:   "ssh://some-host",
+				}), "connecting to remote host 'some-host': some-error")
+			})
+
+			it("must be an ssh URL", func() {
+				h.AssertError(t, subject.Build(context.TODO(), BuildOptions{
+					Image:        "some/app",
+					Builder:      defaultBuilderName,
+					Publish:      true,
+					TrustBuilder: func(string) bool { return true },
+					RemoteHost:   "tcp://some-host",
+				}), "invalid remote host 'tcp://some-host', must be of the form 'ssh://[user@]host[:port]'")
+			})
+
+			it("requires the image to be published", func() {
+				h.AssertError(t, subject.Build(context.TODO(), BuildOptions{
+					Image:        "some/app",
+					Builder:      defaultBuilderName,
+					TrustBuilder: func(string) bool { return true },
+					RemoteHost:   "ssh://some-host",
+				}), "building on a remote host requires the image to be published")
+			})
+
+			it("cannot be used with the local runtime", func() {
+				h.AssertError(t, subject.Build(context.TODO(), BuildOptions{
+					Image:        "some/app",
+					Builder:      defaultBuilderName,
+					Publish:      true,
+					TrustBuilder: func(string) bool { return true },
+					Runtime:      build.RuntimeLocal,
+					RemoteHost:   "ssh://some-host",
+				}), "a remote host cannot be used with the 'local' runtime")
+			})
+
+			it("requires a trusted builder", func() {
+				h.AssertError(t, subject.Build(context.TODO(), BuildOptions{
+					Image:        "some/app",
+					Builder:      defaultBuilderName,
+					Publish:      true,
+					TrustBuilder: func(string) bool { return false },
+					RemoteHost:   "ssh://some-host",
+				}), "building on a remote host requires a trusted builder")
+			})
+		})
+
+		when("SBOMPolicy option", func() {
+			var policyPath string
+
+			it.Before(func() {
+				policyPath = filepath.Join(tmpDir, "policy.toml")
+				h.AssertNil(t, os.WriteFile(policyPath, []byte(`
+[[deny.packages]]
+name = "some-package"
+versions = "< 2.0.0"
+reason = "some-reason"
+`), 0600))
+			})
+
+			it("checks the SBOM of the built image against the policy", func() {
+				fakeImageFetcher.LocalImages["index.docker.io/some/app:latest"] = imageWithSBOM(t, "some/app", map[string]string{
+					"/layers/sbom/launch/some-buildpack/sbom.syft.json": `{"artifacts": [{"name": "some-package", "version": "2.0.0"}]}`,
+				})
+
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:      "some/app",
+					Builder:    defaultBuilderName,
+					SBOMPolicy: policyPath,
+				}))
+			})
+
+			it("fails the build when the SBOM violates the policy", func() {
+				fakeImageFetcher.LocalImages["index.docker.io/some/app:latest"] = imageWithSBOM(t, "some/app", map[string]string{
+					"/layers/sbom/launch/some-buildpack/sbom.syft.json": `{"artifacts": [{"name": "some-package", "version": "1.0.0"}]}`,
+				})
+
+				err := subject.Build(context.TODO(), BuildOptions{
+					Image:      "some/app",
+					Builder:    defaultBuilderName,
+					SBOMPolicy: policyPath,
+				})
+				h.AssertError(t, err, fmt.Sprintf("image 'index.docker.io/some/app:latest' violates SBOM policy '%s': 1 violation(s) found", policyPath))
+				h.AssertContains(t, outBuf.String(), "denied-package some-package@1.0.0: package is denied: some-reason")
+			})
+
+			it("fails before building when the policy cannot be read", func() {
+				err := subject.Build(context.TODO(), BuildOptions{
+					Image:      "some/app",
+					Builder:    defaultBuilderName,
+					SBOMPolicy: filepath.Join(tmpDir, "missing.toml"),
+				})
+				h.AssertError(t, err, "reading policy")
+				h.AssertEq(t, fakeLifecycle.Opts.Image, nil)
+			})
+
+			when("publishing", func() {
+				it.Before(func() {
+					fakeImageFetcher.RemoteImages["default/run"] = fakeDefaultRunImage
+				})
+
+				it("fails the build when the SBOM of the published image violates the policy", func() {
+					fakeImageFetcher.RemoteImages["index.docker.io/some/app:latest"] = imageWithSBOM(t, "some/app", map[string]string{
+						"/layers/sbom/launch/some-buildpack/sbom.syft.json": `{"artifacts": [{"name": "some-package", "version": "1.0.0"}]}`,
+					})
+
+					err := subject.Build(context.TODO(), BuildOptions{
+						Image:      "some/app",
+						Builder:    defaultBuilderName,
+						Publish:    true,
+						SBOMPolicy: policyPath,
+					})
+					h.AssertError(t, err, fmt.Sprintf("image 'index.docker.io/some/app:latest' violates SBOM policy '%s': 1 violation(s) found", policyPath))
+					h.AssertEq(t, fakeLifecycle.Opts.Publish, true)
+				})
+
+				it("checks the published image rather than an image in the daemon", func() {
+					fakeImageFetcher.RemoteImages["index.docker.io/some/app:latest"] = imageWithSBOM(t, "some/app", map[string]string{
+						"/layers/sbom/launch/some-buildpack/sbom.syft.json": `{"artifacts": [{"name": "some-package", "version": "2.0.0"}]}`,
+					})
+					fakeImageFetcher.LocalImages["index.docker.io/some/app:latest"] = imageWithSBOM(t, "some/app", map[string]string{
+						"/layers/sbom/launch/some-buildpack/sbom.syft.json": `{"artifacts": [{"name": "some-package", "version": "1.0.0"}]}`,
+					})
+
+					h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+						Image:      "some/app",
+						Builder:    defaultBuilderName,
+						Publish:    true,
+						SBOMPolicy: policyPath,
+					}))
+				})
+			})
+		})
+
+		when("ProvenanceOutput option", func() {
+			var provenancePath string
+
+			it.Before(func() {
+				provenancePath = filepath.Join(tmpDir, "provenance.json")
+
+				appImage := fakes.NewImage("index.docker.io/some/app:latest", "", local.IDIdentifier{ImageID: "sha256:" + strings.Repeat("b", 64)})
+				h.AssertNil(t, appImage.SetLabel("io.buildpacks.build.metadata", `{"buildpacks": [{"id": "some/buildpack", "version": "1.2.3", "homepage": "https://example.com"}]}`))
+				h.AssertNil(t, appImage.SetLabel("io.buildpacks.lifecycle.metadata", fmt.Sprintf(`{"runImage": {"image": "default/run", "reference": "default/run@sha256:%s"}}`, strings.Repeat("c", 64))))
+				fakeImageFetcher.LocalImages["index.docker.io/some/app:latest"] = appImage
+			})
+
+			it("writes the provenance of the build as an in-toto statement", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:            "some/app",
+					Builder:          defaultBuilderName,
+					Env:              map[string]string{"SOME_VAR": "some-value"},
+					AdditionalTags:   []string{"some/app:v1"},
+					ProvenanceOutput: provenancePath,
+				}))
+				h.AssertContains(t, outBuf.String(), fmt.Sprintf("Wrote provenance of 'index.docker.io/some/app:latest' to '%s'", provenancePath))
+
+				contents, err := os.ReadFile(provenancePath)
+				h.AssertNil(t, err)
+				var statement struct {
+					Type          string `json:"_type"`
+					PredicateType string `json:"predicateType"`
+					Subject       []struct {
+						Name   string            `json:"name"`
+						Digest map[string]string `json:"digest"`
+					} `json:"subject"`
+					Predicate struct {
+						BuildDefinition struct {
+							BuildType            string                 `json:"buildType"`
+							ExternalParameters   map[string]interface{} `json:"externalParameters"`
+							ResolvedDependencies []struct {
+								Name   string            `json:"name"`
+								URI    string            `json:"uri"`
+								Digest map[string]string `json:"digest"`
+							} `json:"resolvedDependencies"`
+						} `json:"buildDefinition"`
+						RunDetails struct {
+							Builder struct {
+								ID      string            `json:"id"`
+								Version map[string]string `json:"version"`
+							} `json:"builder"`
+						} `json:"runDetails"`
+					} `json:"predicate"`
+				}
+				h.AssertNil(t, json.Unmarshal(contents, &statement))
+
+				h.AssertEq(t, statement.Type, "https://in-toto.io/Statement/v1")
+				h.AssertEq(t, statement.PredicateType, "https://slsa.dev/provenance/v1")
+				h.AssertEq(t, len(statement.Subject), 1)
+				h.AssertEq(t, statement.Subject[0].Name, "index.docker.io/some/app")
+				h.AssertEq(t, statement.Subject[0].Digest, map[string]string{"sha256": strings.Repeat("b", 64)})
+
+				buildDefinition := statement.Predicate.BuildDefinition
+				h.AssertEq(t, buildDefinition.BuildType, ProvenanceBuildType)
+				h.AssertEq(t, buildDefinition.ExternalParameters["image"], "index.docker.io/some/app:latest")
+				h.AssertEq(t, buildDefinition.ExternalParameters["builder"], defaultBuilderName)
+				h.AssertEq(t, buildDefinition.ExternalParameters["env"], []interface{}{"SOME_VAR"})
+				h.AssertNotContains(t, string(contents), "some-value")
+				h.AssertEq(t, buildDefinition.ExternalParameters["tags"], []interface{}{"some/app:v1"})
+				h.AssertEq(t, buildDefinition.ExternalParameters["publish"], false)
+
+				h.AssertEq(t, len(buildDefinition.ResolvedDependencies), 3)
+				h.AssertEq(t, buildDefinition.ResolvedDependencies[0].Name, "builder")
+				h.AssertEq(t, buildDefinition.ResolvedDependencies[0].URI, defaultBuilderName)
+				h.AssertEq(t, buildDefinition.ResolvedDependencies[1].Name, "run-image")
+				h.AssertEq(t, buildDefinition.ResolvedDependencies[1].Digest, map[string]string{"sha256": strings.Repeat("c", 64)})
+				h.AssertEq(t, buildDefinition.ResolvedDependencies[2].Name, "some/buildpack")
+
+				h.AssertEq(t, statement.Predicate.RunDetails.Builder.ID, ProvenanceBuilderID)
+				h.AssertEq(t, statement.Predicate.RunDetails.Builder.Version["lifecycle"], builder.DefaultLifecycleVersion)
+			})
+
+			it("records the git repository of the app", func() {
+				appDir := filepath.Join(tmpDir, "app")
+				h.AssertNil(t, os.MkdirAll(appDir, 0755))
+				repo, err := git.PlainInit(appDir, false)
+				h.AssertNil(t, err)
+				h.AssertNil(t, os.WriteFile(filepath.Join(appDir, "file.txt"), []byte("some-contents"), 0600))
+				worktree, err := repo.Worktree()
+				h.AssertNil(t, err)
+				_, err = worktree.Add("file.txt")
+				h.AssertNil(t, err)
+				commit, err := worktree.Commit("some commit", &git.CommitOptions{Author: &object.Signature{Name: "some-author", When: time.Now()}})
+				h.AssertNil(t, err)
+				_, err = repo.CreateRemote(&gitconfig.RemoteConfig{Name: "origin", URLs: []string{"https://example.com/some/app.git"}})
+				h.AssertNil(t, err)
+
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Image:            "some/app",
+					Builder:          defaultBuilderName,
+					AppPath:          appDir,
+					ProvenanceOutput: provenancePath,
+				}))
+
+				contents, err := os.ReadFile(provenancePath)
+				h.AssertNil(t, err)
+				h.AssertContains(t, string(contents), `"uri": "git+https://example.com/some/app.git"`)
+				h.AssertContains(t, string(contents), fmt.Sprintf(`"gitCommit": "%s"`, commit.String()))
+			})
+		})
+
+		when("LockFile option", func() {
+			var (
+				lockPath   string
+				buildpacks = []string{"buildpack.1.id@buildpack.1.version", filepath.Join("testdata", "buildpack")}
+			)
+
+			it.Before(func() {
+				lockPath = filepath.Join(tmpDir, BuildLockFileName)
+			})
+
+			buildWithLock := func(updateLock bool) error {
+				return subject.Build(context.TODO(), BuildOptions{
+					Image:      "some/app",
+					Builder:    defaultBuilderName,
+					Buildpacks: buildpacks,
+					LockFile:   lockPath,
+					UpdateLock: updateLock,
+				})
+			}
+
+			readLock := func() BuildLock {
+				lock, err := readBuildLock(lockPath)
+				h.AssertNil(t, err)
+				return lock
+			}
+
+			it("writes the resolved inputs when the lockfile doesn't exist", func() {
+				h.AssertNil(t, buildWithLock(false))
+				h.AssertContains(t, outBuf.String(), fmt.Sprintf("Wrote build inputs to lockfile '%s'", lockPath))
+
+				lock := readLock()
+				h.AssertEq(t, lock.Builder.Name, defaultBuilderName)
+				h.AssertEq(t, lock.RunImage.Name, "default/run")
+				h.AssertEq(t, len(lock.Buildpacks), 2)
+				h.AssertEq(t, lock.Buildpacks[0], ModuleLock{Locator: "buildpack.1.id@buildpack.1.version", ID: "buildpack.1.id", Version: "buildpack.1.version"})
+				h.AssertEq(t, lock.Buildpacks[1].Locator, filepath.Join("testdata", "buildpack"))
+				h.AssertEq(t, len(lock.Buildpacks[1].SHA256), 64)
+			})
+
+			it("builds when the inputs match the lockfile", func() {
+				h.AssertNil(t, buildWithLock(false))
+				contents, err := os.ReadFile(lockPath)
+				h.AssertNil(t, err)
+
+				h.AssertNil(t, buildWithLock(false))
+				newContents, err := os.ReadFile(lockPath)
+				h.AssertNil(t, err)
+				h.AssertEq(t, string(newContents), string(contents))
+			})
+
+			when("the inputs differ from the lockfile", func() {
+				it.Before(func() {
+					h.AssertNil(t, buildWithLock(false))
+					lock := readLock()
+					lock.RunImage.Digest = "sha256:" + strings.Repeat("d", 64)
+					lock.Buildpacks = lock.Buildpacks[:1]
+					h.AssertNil(t, writeBuildLock(lockPath, lock))
+					fakeLifecycle.Opts = build.LifecycleOptions{}
+				})
+
+				it("fails before building", func() {
+					err := buildWithLock(false)
+					h.AssertError(t, err, fmt.Sprintf("build inputs differ from lockfile '%s'", lockPath))
+					h.AssertError(t, err, fmt.Sprintf("run image: default/run@sha256:%s -> default/run", strings.Repeat("d", 64)))
+					h.AssertError(t, err, "buildpack 'testdata/buildpack': added")
+					h.AssertEq(t, fakeLifecycle.Opts.Image, nil)
+				})
+
+				it("rewrites the lockfile when updating it", func() {
+					h.AssertNil(t, buildWithLock(true))
+
+					lock := readLock()
+					h.AssertEq(t, lock.RunImage.Digest, "")
+					h.AssertEq(t, len(lock.Buildpacks), 2)
+				})
+			})
+		})
+
+		when("Sign option", func() {
+			var keyPath string
+
+			it.Before(func() {
+				keyPath = writeSigningKey(t, tmpDir)
+				subject.keychain = authn.DefaultKeychain
+			})
+
+			it("requires the image to be published", func() {
+				h.AssertError(t, subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+					Sign:    &SignOptions{KeyPath: keyPath},
+				}), "signing requires the image to be published")
+				h.AssertEq(t, fakeLifecycle.Opts.Image, nil)
+			})
+
+			it("fails before building when the key cannot be read", func() {
+				err := subject.Build(context.TODO(), BuildOptions{
+					Image:   "some/app",
+					Builder: defaultBuilderName,
+					Publish: true,
+					Sign:    &SignOptions{KeyPath: filepath.Join(tmpDir, "missing.key")},
+				})
+				h.AssertError(t, err, "reading signing key")
+				h.AssertEq(t, fakeLifecycle.Opts.Image, nil)
+			})
+
+			when("the image is published", func() {
+				var (
+					server *httptest.Server
+					repo   string
+					digest name.Digest
+				)
+
+				it.Before(func() {
+					server = httptest.NewServer(registry.New())
+					repo = strings.TrimPrefix(server.URL, "http://") + "/some/app"
+
+					var err error
+					digest, err = name.NewDigest(repo + "@sha256:" + strings.Repeat("a", 64))
+					h.AssertNil(t, err)
+					appImage := fakes.NewImage(repo, "", remote.DigestIdentifier{Digest: digest})
+					h.AssertNil(t, appImage.SetLabel("io.buildpacks.build.metadata", `{"buildpacks": [{"id": "some/buildpack", "version": "1.2.3"}]}`))
+					fakeImageFetcher.RemoteImages[repo+":latest"] = appImage
+					fakeImageFetcher.RemoteImages["default/run"] = fakeDefaultRunImage
+				})
+
+				it.After(func() {
+					server.Close()
+				})
+
+				it("signs the image and attaches the provenance of the build", func() {
+					h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+						Image:   repo,
+						Builder: defaultBuilderName,
+						Publish: true,
+						Sign:    &SignOptions{KeyPath: keyPath},
+					}))
+					h.AssertContains(t, outBuf.String(), fmt.Sprintf("Signed '%s' and attached 1 attestation(s)", digest.Name()))
+
+					sigTag := digest.Context().Tag("sha256-" + strings.Repeat("a", 64) + ".sig")
+					sigImage, err := ggcrremote.Image(sigTag)
+					h.AssertNil(t, err)
+					sigLayers, err := sigImage.Layers()
+					h.AssertNil(t, err)
+					h.AssertEq(t, len(sigLayers), 1)
+
+					attTag := digest.Context().Tag("sha256-" + strings.Repeat("a", 64) + ".att")
+					attImage, err := ggcrremote.Image(attTag)
+					h.AssertNil(t, err)
+					attManifest, err := attImage.Manifest()
+					h.AssertNil(t, err)
+					h.AssertEq(t, len(attManifest.Layers), 1)
+					h.AssertEq(t, attManifest.Layers[0].Annotations["predicateType"], "https://slsa.dev/provenance/v1")
+				})
+			})
+		})
+
+		when("VerifyReproducible option", func() {
+			it("requires a previous image when publishing", func() {
+				h.AssertError(t, subject.Build(context.TODO(), BuildOptions{
+					Image:              "some/app",
+					Builder:            defaultBuilderName,
+					Publish:            true,
+					VerifyReproducible: true,
+				}), "verifying reproducibility of a published image requires a previous image to compare with")
+			})
+
+			it("cannot be used in watch mode", func() {
+				h.AssertError(t, subject.Build(context.TODO(), BuildOptions{
+					Image:              "some/app",
+					Builder:            defaultBuilderName,
+					Watch:              true,
+					VerifyReproducible: true,
+				}), "verifying reproducibility cannot be combined with watch, dry run or interactive mode")
+			})
+
+			it("requires a previous image other than the built image", func() {
+				h.AssertError(t, subject.Build(context.TODO(), BuildOptions{
+					Image:              "some/app",
+					Builder:            defaultBuilderName,
+					PreviousImage:      "index.docker.io/some/app:latest",
+					VerifyReproducible: true,
+				}), "verifying reproducibility requires a previous image other than the built image")
+			})
+		})
+
+		when("Platform option", func() {
+			it("fetches the builder for the platform and creates containers for it", func() {
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Builder:  defaultBuilderName,
+					Image:    "example.com/some/repo:tag",
+					Platform: "linux/amd64",
+				}))
+
+				h.AssertEq(t, fakeImageFetcher.FetchCalls[defaultBuilderName].Platform, "linux/amd64")
+				h.AssertEq(t, fakeLifecycle.Opts.Platform, &specs.Platform{OS: "linux", Architecture: "amd64"})
+			})
+
+			it("must be in the form os/arch[/variant]", func() {
+				h.AssertError(t, subject.Build(context.TODO(), BuildOptions{
+					Builder:  defaultBuilderName,
+					Image:    "example.com/some/repo:tag",
+					Platform: "linux",
+				}),
+					"invalid platform 'linux', must be in the form 'os/arch[/variant]'",
+				)
+			})
+
+			it("fails when the builder does not match the platform", func() {
+				h.AssertError(t, subject.Build(context.TODO(), BuildOptions{
+					Builder:  defaultBuilderName,
+					Image:    "example.com/some/repo:tag",
+					Platform: "linux/arm64",
+				}),
+					fmt.Sprintf("builder '%s' is 'linux/amd64', expected 'linux/arm64'", defaultBuilderName),
+				)
+			})
+
+			it("fails when the run image does not match the platform", func() {
+				h.AssertNil(t, fakeDefaultRunImage.SetArchitecture("arm64"))
+
+				h.AssertError(t, subject.Build(context.TODO(), BuildOptions{
+					Builder:  defaultBuilderName,
+					Image:    "example.com/some/repo:tag",
+					Platform: "linux/amd64",
+				}),
+					"run-image 'default/run' has architecture 'arm64', expected 'amd64'",
+				)
+			})
+		})
+
 		when("Image option", func() {
 			it("is required", func() {
 				h.AssertError(t, subject.Build(context.TODO(), BuildOptions{
@@ -3207,6 +3946,18 @@ func diffIDForFile(t *testing.T, path string) string {
 	return "sha256:" + hex.EncodeToString(hasher.Sum(make([]byte, 0, hasher.Size())))
 }
 
+// writeSigningKey writes an unencrypted ECDSA key to sign images with to dir
+func writeSigningKey(t *testing.T, dir string) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	h.AssertNil(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	h.AssertNil(t, err)
+
+	keyPath := filepath.Join(dir, "cosign.key")
+	h.AssertNil(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600))
+	return keyPath
+}
+
 func newLinuxImage(name, topLayerSha string, identifier imgutil.Identifier) *fakes.Image {
 	return fakes.NewImage(name, topLayerSha, identifier)
 }
@@ -3375,3 +4126,16 @@ func (f *executeFailsLifecycle) Execute(_ context.Context, opts build.LifecycleO
 	f.Opts = opts
 	return errors.New("")
 }
+
+type fakeRemoteShell struct {
+	closed bool
+}
+
+func (s *fakeRemoteShell) Run(ctx context.Context, cmd string, stdin io.Reader, stdout, stderr io.Writer) error {
+	return nil
+}
+
+func (s *fakeRemoteShell) Close() error {
+	s.closed = true
+	return nil
+}
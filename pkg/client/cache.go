@@ -0,0 +1,397 @@
+package client
+
+import (
+	"archive/tar"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/volume"
+	dockerClient "github.com/docker/docker/client"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/builder"
+	iconfig "github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/cache"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+const cacheMountPath = "/cache"
+
+var cacheTypes = []string{"build", "launch", "kaniko"}
+
+// CacheVolume describes a cache volume created by pack.
+type CacheVolume struct {
+	// Name of the volume.
+	Name string
+
+	// Type of cache stored in the volume: build, launch or kaniko.
+	Type string
+
+	// Image the cache belongs to. Empty when no image in the daemon matches the volume.
+	Image string
+
+	// Size of the volume contents in bytes, or -1 when the daemon does not report it.
+	Size int64
+
+	// CreatedAt is the time the volume was created.
+	CreatedAt time.Time
+}
+
+// InspectCacheOptions define the image whose caches should be inspected.
+type InspectCacheOptions struct {
+	// Name of the app image the caches were created for.
+	Image string
+}
+
+// PruneCachesOptions define which cache volumes are removed. When no criteria are set, all cache volumes are removed.
+type PruneCachesOptions struct {
+	// Remove caches created more than OlderThan ago.
+	OlderThan time.Duration
+
+	// Remove caches that do not belong to an image in the daemon. Published images are not in the daemon, so their
+	// caches are unused too; OlderThan must be set alongside to keep the caches of recent builds.
+	Unused bool
+
+	// Report the caches that would be removed without removing them.
+	DryRun bool
+}
+
+// CacheVolumeOptions identify a cache volume, either by the image and cache type it belongs to or by name.
+type CacheVolumeOptions struct {
+	// Name of the app image the cache belongs to.
+	Image string
+
+	// Type of cache: build, launch or kaniko. Defaults to build.
+	Type string
+
+	// Name of the cache volume. Overrides Image and Type, and is needed for caches created with a custom name.
+	Name string
+}
+
+// ExportCacheOptions define the cache to export and where to write it.
+type ExportCacheOptions struct {
+	CacheVolumeOptions
+
+	// Path of the tarball to write.
+	Path string
+
+	// Image used to create the container the volume is read through.
+	// Defaults to the lifecycle image of the default lifecycle version.
+	HelperImage string
+}
+
+// ImportCacheOptions define the tarball to import and the cache it is imported into.
+type ImportCacheOptions struct {
+	CacheVolumeOptions
+
+	// Path of the tarball to read.
+	Path string
+
+	// Image used to create the container the volume is written through.
+	// Defaults to the lifecycle image of the default lifecycle version.
+	HelperImage string
+}
+
+// ListCaches returns the cache volumes pack created for builds, along with the image they belong to and their size.
+func (c *Client) ListCaches(ctx context.Context) ([]CacheVolume, error) {
+	du, err := c.docker.DiskUsage(ctx, types.DiskUsageOptions{Types: []types.DiskUsageObject{types.VolumeObject, types.ImageObject}})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing volumes")
+	}
+
+	owners := map[string]string{}
+	for _, img := range du.Images {
+		for _, tag := range img.RepoTags {
+			ref, err := name.ParseReference(tag, name.WeakValidation)
+			if err != nil {
+				continue
+			}
+			for _, cacheType := range cacheTypes {
+				owners[cache.NewVolumeCache(ref, cache.CacheInfo{}, cacheType, nil).Name()] = tag
+			}
+		}
+	}
+
+	var caches []CacheVolume
+	for _, vol := range du.Volumes {
+		cacheType, ok := cache.VolumeCacheType(vol.Name)
+		if !ok {
+			continue
+		}
+
+		caches = append(caches, toCacheVolume(vol, cacheType, owners[vol.Name]))
+	}
+
+	sort.Slice(caches, func(i, j int) bool {
+		return caches[i].Name < caches[j].Name
+	})
+	return caches, nil
+}
+
+// InspectCache returns the cache volumes that exist for an image.
+func (c *Client) InspectCache(ctx context.Context, opts InspectCacheOptions) ([]CacheVolume, error) {
+	ref, err := name.ParseReference(opts.Image, name.WeakValidation)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid image name %s", style.Symbol(opts.Image))
+	}
+
+	caches, err := c.ListCaches(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[string]bool{}
+	for _, cacheType := range cacheTypes {
+		names[cache.NewVolumeCache(ref, cache.CacheInfo{}, cacheType, nil).Name()] = true
+	}
+
+	var result []CacheVolume
+	for _, cacheVolume := range caches {
+		if names[cacheVolume.Name] {
+			cacheVolume.Image = opts.Image
+			result = append(result, cacheVolume)
+		}
+	}
+	return result, nil
+}
+
+// PruneCaches removes the cache volumes matching all of the provided criteria and returns them.
+func (c *Client) PruneCaches(ctx context.Context, opts PruneCachesOptions) ([]CacheVolume, error) {
+	if opts.Unused && opts.OlderThan <= 0 {
+		return nil, errors.New("pruning unused caches requires an age, as the caches of published images are unused as well")
+	}
+
+	caches, err := c.ListCaches(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := time.Now().Add(-opts.OlderThan)
+	var pruned []CacheVolume
+	for _, cacheVolume := range caches {
+		if opts.OlderThan > 0 && (cacheVolume.CreatedAt.IsZero() || cacheVolume.CreatedAt.After(cutoff)) {
+			continue
+		}
+		if opts.Unused && cacheVolume.Image != "" {
+			continue
+		}
+
+		if !opts.DryRun {
+			if err := c.docker.VolumeRemove(ctx, cacheVolume.Name, true); err != nil && !dockerClient.IsErrNotFound(err) {
+				return pruned, errors.Wrapf(err, "removing cache volume %s", style.Symbol(cacheVolume.Name))
+			}
+		}
+		pruned = append(pruned, cacheVolume)
+	}
+
+	return pruned, nil
+}
+
+// ExportCache writes the contents of a cache volume to a tarball.
+func (c *Client) ExportCache(ctx context.Context, opts ExportCacheOptions) error {
+	volumeName, err := resolveCacheVolume(opts.CacheVolumeOptions)
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.docker.VolumeInspect(ctx, volumeName); err != nil {
+		if dockerClient.IsErrNotFound(err) {
+			return errors.Errorf("cache volume %s does not exist", style.Symbol(volumeName))
+		}
+		return errors.Wrapf(err, "inspecting cache volume %s", style.Symbol(volumeName))
+	}
+
+	helperImage, err := c.cacheHelperImage(ctx, opts.HelperImage)
+	if err != nil {
+		return err
+	}
+
+	ctrID, err := c.createCacheContainer(ctx, volumeName, helperImage)
+	if err != nil {
+		return err
+	}
+	defer c.docker.ContainerRemove(context.Background(), ctrID, containertypes.RemoveOptions{Force: true})
+
+	reader, _, err := c.docker.CopyFromContainer(ctx, ctrID, cacheMountPath)
+	if err != nil {
+		return errors.Wrapf(err, "reading cache volume %s", style.Symbol(volumeName))
+	}
+	defer reader.Close()
+
+	f, err := os.Create(opts.Path)
+	if err != nil {
+		return errors.Wrapf(err, "creating %s", style.Symbol(opts.Path))
+	}
+	defer f.Close()
+
+	if err := stripTarRoot(reader, f, strings.TrimPrefix(cacheMountPath, "/")); err != nil {
+		return errors.Wrapf(err, "writing %s", style.Symbol(opts.Path))
+	}
+
+	c.logger.Infof("Exported cache volume %s to %s", style.Symbol(volumeName), style.Symbol(opts.Path))
+	return nil
+}
+
+// ImportCache replaces the contents of a cache volume with the contents of a tarball created by ExportCache.
+func (c *Client) ImportCache(ctx context.Context, opts ImportCacheOptions) error {
+	volumeName, err := resolveCacheVolume(opts.CacheVolumeOptions)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(opts.Path)
+	if err != nil {
+		return errors.Wrapf(err, "opening %s", style.Symbol(opts.Path))
+	}
+	defer f.Close()
+
+	helperImage, err := c.cacheHelperImage(ctx, opts.HelperImage)
+	if err != nil {
+		return err
+	}
+
+	if err := c.docker.VolumeRemove(ctx, volumeName, true); err != nil && !dockerClient.IsErrNotFound(err) {
+		return errors.Wrapf(err, "removing cache volume %s", style.Symbol(volumeName))
+	}
+
+	ctrID, err := c.createCacheContainer(ctx, volumeName, helperImage)
+	if err != nil {
+		return err
+	}
+	defer c.docker.ContainerRemove(context.Background(), ctrID, containertypes.RemoveOptions{Force: true})
+
+	if err := c.docker.CopyToContainer(ctx, ctrID, cacheMountPath, f, types.CopyToContainerOptions{}); err != nil {
+		return errors.Wrapf(err, "writing cache volume %s", style.Symbol(volumeName))
+	}
+
+	c.logger.Infof("Imported %s into cache volume %s", style.Symbol(opts.Path), style.Symbol(volumeName))
+	return nil
+}
+
+// cacheHelperImage ensures the image used to create cache containers is available in the daemon and returns its name
+func (c *Client) cacheHelperImage(ctx context.Context, helperImage string) (string, error) {
+	info, err := c.docker.Info(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "getting docker info")
+	}
+	if info.OSType == "windows" {
+		return "", errors.New("cache export and import are not supported with Windows containers")
+	}
+
+	if helperImage == "" {
+		helperImage = fmt.Sprintf("%s:%s", iconfig.DefaultLifecycleImageRepo, builder.DefaultLifecycleVersion)
+	}
+	img, err := c.imageFetcher.Fetch(ctx, helperImage, image.FetchOptions{Daemon: true, PullPolicy: image.PullIfNotPresent})
+	if err != nil {
+		return "", errors.Wrapf(err, "fetching helper image %s", style.Symbol(helperImage))
+	}
+	return img.Name(), nil
+}
+
+// createCacheContainer creates (but does not start) a container with the volume mounted, so that its contents
+// can be read and written through the daemon's archive API
+func (c *Client) createCacheContainer(ctx context.Context, volumeName, helperImage string) (string, error) {
+	ctr, err := c.docker.ContainerCreate(ctx,
+		&containertypes.Config{
+			Image: helperImage,
+			Cmd:   []string{"true"}, // never run
+		},
+		&containertypes.HostConfig{
+			Binds: []string{fmt.Sprintf("%s:%s", volumeName, cacheMountPath)},
+		},
+		nil, nil, "",
+	)
+	if err != nil {
+		return "", errors.Wrap(err, "creating helper container")
+	}
+	return ctr.ID, nil
+}
+
+func resolveCacheVolume(opts CacheVolumeOptions) (string, error) {
+	if opts.Name != "" {
+		return opts.Name, nil
+	}
+
+	cacheType := opts.Type
+	if cacheType == "" {
+		cacheType = "build"
+	}
+	if !contains(cacheTypes, cacheType) {
+		return "", errors.Errorf("invalid cache type %s, must be one of %s", style.Symbol(cacheType), strings.Join(cacheTypes, ", "))
+	}
+
+	if opts.Image == "" {
+		return "", errors.New("an image name or cache volume name must be provided")
+	}
+	ref, err := name.ParseReference(opts.Image, name.WeakValidation)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid image name %s", style.Symbol(opts.Image))
+	}
+
+	return cache.NewVolumeCache(ref, cache.CacheInfo{}, cacheType, nil).Name(), nil
+}
+
+func toCacheVolume(vol *volume.Volume, cacheType, owner string) CacheVolume {
+	size := int64(-1)
+	if vol.UsageData != nil {
+		size = vol.UsageData.Size
+	}
+
+	createdAt, _ := time.Parse(time.RFC3339, vol.CreatedAt)
+	return CacheVolume{
+		Name:      vol.Name,
+		Type:      cacheType,
+		Image:     owner,
+		Size:      size,
+		CreatedAt: createdAt,
+	}
+}
+
+// stripTarRoot copies a tar stream, removing the root directory from the name of each entry
+func stripTarRoot(in io.Reader, out io.Writer, root string) error {
+	tr := tar.NewReader(in)
+	tw := tar.NewWriter(out)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		entryName := stripRoot(header.Name, root)
+		if entryName == "" {
+			continue
+		}
+		header.Name = entryName
+		if header.Typeflag == tar.TypeLink {
+			header.Linkname = stripRoot(header.Linkname, root)
+		}
+
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := io.Copy(tw, tr); err != nil {
+			return err
+		}
+	}
+
+	return tw.Close()
+}
+
+func stripRoot(path, root string) string {
+	if path == root {
+		return ""
+	}
+	return strings.TrimPrefix(path, root+"/")
+}
@@ -0,0 +1,290 @@
+package client
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/docker/docker/api/types"
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/system"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/errdefs"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/builder"
+	"github.com/buildpacks/pack/pkg/cache"
+	pkgimage "github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCache(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Cache", testCache, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testCache(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		mockImageFetcher *testmocks.MockImageFetcher
+		mockDockerClient *testmocks.MockCommonAPIClient
+		mockController   *gomock.Controller
+		out              bytes.Buffer
+		tmpDir           string
+
+		appBuildVolume    string
+		appLaunchVolume   string
+		otherBuildVolume  string
+		diskUsageResponse types.DiskUsage
+	)
+
+	volumeName := func(imageName, cacheType string) string {
+		ref, err := name.ParseReference(imageName, name.WeakValidation)
+		h.AssertNil(t, err)
+		return cache.NewVolumeCache(ref, cache.CacheInfo{}, cacheType, nil).Name()
+	}
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockImageFetcher = testmocks.NewMockImageFetcher(mockController)
+		mockDockerClient = testmocks.NewMockCommonAPIClient(mockController)
+
+		var err error
+		subject, err = NewClient(WithLogger(logging.NewLogWithWriters(&out, &out)), WithFetcher(mockImageFetcher), WithDockerClient(mockDockerClient))
+		h.AssertNil(t, err)
+
+		tmpDir, err = os.MkdirTemp("", "pack.cache.test.")
+		h.AssertNil(t, err)
+
+		appBuildVolume = volumeName("some/app", "build")
+		appLaunchVolume = volumeName("some/app", "launch")
+		otherBuildVolume = volumeName("other/app", "build")
+		diskUsageResponse = types.DiskUsage{
+			Images: []*image.Summary{
+				{RepoTags: []string{"some/app:latest"}},
+			},
+			Volumes: []*volume.Volume{
+				{Name: appLaunchVolume, CreatedAt: time.Now().Add(-time.Hour).Format(time.RFC3339), UsageData: &volume.UsageData{Size: 20}},
+				{Name: appBuildVolume, CreatedAt: time.Now().Add(-48 * time.Hour).Format(time.RFC3339), UsageData: &volume.UsageData{Size: 10}},
+				{Name: otherBuildVolume, CreatedAt: time.Now().Add(-48 * time.Hour).Format(time.RFC3339)},
+				{Name: "some-unrelated-volume"},
+			},
+		}
+	})
+
+	it.After(func() {
+		mockController.Finish()
+		h.AssertNil(t, os.RemoveAll(tmpDir))
+	})
+
+	when("#ListCaches", func() {
+		it("lists the cache volumes created by pack along with their image", func() {
+			mockDockerClient.EXPECT().DiskUsage(gomock.Any(), gomock.Any()).Return(diskUsageResponse, nil)
+
+			caches, err := subject.ListCaches(context.TODO())
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, len(caches), 3)
+			for _, c := range caches {
+				switch c.Name {
+				case appBuildVolume:
+					h.AssertEq(t, c.Type, "build")
+					h.AssertEq(t, c.Image, "some/app:latest")
+					h.AssertEq(t, c.Size, int64(10))
+				case appLaunchVolume:
+					h.AssertEq(t, c.Type, "launch")
+					h.AssertEq(t, c.Image, "some/app:latest")
+					h.AssertEq(t, c.Size, int64(20))
+				case otherBuildVolume:
+					h.AssertEq(t, c.Type, "build")
+					h.AssertEq(t, c.Image, "")
+					h.AssertEq(t, c.Size, int64(-1))
+				default:
+					t.Fatalf("unexpected cache volume %s", c.Name)
+				}
+			}
+		})
+
+		it("returns an error when the daemon cannot be queried", func() {
+			mockDockerClient.EXPECT().DiskUsage(gomock.Any(), gomock.Any()).Return(types.DiskUsage{}, errors.New("some-error"))
+
+			_, err := subject.ListCaches(context.TODO())
+			h.AssertError(t, err, "listing volumes: some-error")
+		})
+	})
+
+	when("#InspectCache", func() {
+		it("returns the caches of the image", func() {
+			mockDockerClient.EXPECT().DiskUsage(gomock.Any(), gomock.Any()).Return(diskUsageResponse, nil)
+
+			caches, err := subject.InspectCache(context.TODO(), InspectCacheOptions{Image: "some/app"})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, len(caches), 2)
+			h.AssertEq(t, caches[0].Image, "some/app")
+			h.AssertEq(t, caches[1].Image, "some/app")
+		})
+	})
+
+	when("#PruneCaches", func() {
+		it("removes caches older than the given duration", func() {
+			mockDockerClient.EXPECT().DiskUsage(gomock.Any(), gomock.Any()).Return(diskUsageResponse, nil)
+			mockDockerClient.EXPECT().VolumeRemove(gomock.Any(), appBuildVolume, true).Return(nil)
+			mockDockerClient.EXPECT().VolumeRemove(gomock.Any(), otherBuildVolume, true).Return(nil)
+
+			pruned, err := subject.PruneCaches(context.TODO(), PruneCachesOptions{OlderThan: 24 * time.Hour})
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(pruned), 2)
+		})
+
+		it("removes old caches without an image", func() {
+			publishedBuildVolume := volumeName("registry.example.com/published/app", "build")
+			diskUsageResponse.Volumes = append(diskUsageResponse.Volumes, &volume.Volume{Name: publishedBuildVolume, CreatedAt: time.Now().Add(-time.Hour).Format(time.RFC3339)})
+			mockDockerClient.EXPECT().DiskUsage(gomock.Any(), gomock.Any()).Return(diskUsageResponse, nil)
+			mockDockerClient.EXPECT().VolumeRemove(gomock.Any(), otherBuildVolume, true).Return(nil)
+
+			pruned, err := subject.PruneCaches(context.TODO(), PruneCachesOptions{Unused: true, OlderThan: 24 * time.Hour})
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(pruned), 1)
+			h.AssertEq(t, pruned[0].Name, otherBuildVolume)
+		})
+
+		it("requires an age to remove caches without an image", func() {
+			_, err := subject.PruneCaches(context.TODO(), PruneCachesOptions{Unused: true})
+			h.AssertError(t, err, "pruning unused caches requires an age, as the caches of published images are unused as well")
+		})
+
+		it("does not remove anything on a dry run", func() {
+			mockDockerClient.EXPECT().DiskUsage(gomock.Any(), gomock.Any()).Return(diskUsageResponse, nil)
+
+			pruned, err := subject.PruneCaches(context.TODO(), PruneCachesOptions{DryRun: true})
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(pruned), 3)
+		})
+	})
+
+	when("#ExportCache", func() {
+		it.Before(func() {
+			mockDockerClient.EXPECT().Info(gomock.Any()).Return(system.Info{OSType: "linux"}, nil).AnyTimes()
+			mockImageFetcher.EXPECT().
+				Fetch(gomock.Any(), "buildpacksio/lifecycle:"+builder.DefaultLifecycleVersion, pkgimage.FetchOptions{Daemon: true, PullPolicy: pkgimage.PullIfNotPresent}).
+				Return(fakes.NewImage("buildpacksio/lifecycle:"+builder.DefaultLifecycleVersion, "", nil), nil).AnyTimes()
+		})
+
+		it("writes the contents of the volume to a tarball", func() {
+			mockDockerClient.EXPECT().VolumeInspect(gomock.Any(), appBuildVolume).Return(volume.Volume{Name: appBuildVolume}, nil)
+			mockDockerClient.EXPECT().ContainerCreate(gomock.Any(), gomock.Any(), gomock.Any(), nil, nil, "").
+				DoAndReturn(func(_ context.Context, config *containertypes.Config, hostConfig *containertypes.HostConfig, _, _ interface{}, _ string) (containertypes.CreateResponse, error) {
+					h.AssertEq(t, config.Image, "buildpacksio/lifecycle:"+builder.DefaultLifecycleVersion)
+					h.AssertEq(t, hostConfig.Binds, []string{appBuildVolume + ":/cache"})
+					return containertypes.CreateResponse{ID: "some-container"}, nil
+				})
+			mockDockerClient.EXPECT().CopyFromContainer(gomock.Any(), "some-container", "/cache").
+				Return(io.NopCloser(cacheTar(t, "cache/", "cache/some-layer.tar")), types.ContainerPathStat{}, nil)
+			mockDockerClient.EXPECT().ContainerRemove(gomock.Any(), "some-container", gomock.Any()).Return(nil)
+
+			path := filepath.Join(tmpDir, "cache.tar")
+			h.AssertNil(t, subject.ExportCache(context.TODO(), ExportCacheOptions{
+				CacheVolumeOptions: CacheVolumeOptions{Image: "some/app"},
+				Path:               path,
+			}))
+
+			h.AssertOnTarEntry(t, path, "some-layer.tar", h.ContentEquals("some-content"))
+		})
+
+		it("fails when the volume does not exist", func() {
+			mockDockerClient.EXPECT().VolumeInspect(gomock.Any(), "some-volume").Return(volume.Volume{}, errdefs.NotFound(errors.New("not found")))
+
+			err := subject.ExportCache(context.TODO(), ExportCacheOptions{
+				CacheVolumeOptions: CacheVolumeOptions{Name: "some-volume"},
+				Path:               filepath.Join(tmpDir, "cache.tar"),
+			})
+			h.AssertError(t, err, "cache volume 'some-volume' does not exist")
+		})
+
+		it("fails for an invalid cache type", func() {
+			err := subject.ExportCache(context.TODO(), ExportCacheOptions{
+				CacheVolumeOptions: CacheVolumeOptions{Image: "some/app", Type: "other"},
+				Path:               filepath.Join(tmpDir, "cache.tar"),
+			})
+			h.AssertError(t, err, "invalid cache type 'other'")
+		})
+	})
+
+	when("#ImportCache", func() {
+		it("replaces the contents of the volume with the tarball", func() {
+			path := filepath.Join(tmpDir, "cache.tar")
+			f, err := os.Create(path)
+			h.AssertNil(t, err)
+			_, err = io.Copy(f, cacheTar(t, "", "some-layer.tar"))
+			h.AssertNil(t, err)
+			h.AssertNil(t, f.Close())
+
+			mockDockerClient.EXPECT().Info(gomock.Any()).Return(system.Info{OSType: "linux"}, nil)
+			mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/helper", gomock.Any()).Return(fakes.NewImage("some/helper", "", nil), nil)
+			mockDockerClient.EXPECT().VolumeRemove(gomock.Any(), appLaunchVolume, true).Return(nil)
+			mockDockerClient.EXPECT().ContainerCreate(gomock.Any(), gomock.Any(), gomock.Any(), nil, nil, "").
+				Return(containertypes.CreateResponse{ID: "some-container"}, nil)
+			mockDockerClient.EXPECT().CopyToContainer(gomock.Any(), "some-container", "/cache", gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, _, _ string, content io.Reader, _ types.CopyToContainerOptions) error {
+					tr := tar.NewReader(content)
+					header, err := tr.Next()
+					h.AssertNil(t, err)
+					h.AssertEq(t, header.Name, "some-layer.tar")
+					return nil
+				})
+			mockDockerClient.EXPECT().ContainerRemove(gomock.Any(), "some-container", gomock.Any()).Return(nil)
+
+			h.AssertNil(t, subject.ImportCache(context.TODO(), ImportCacheOptions{
+				CacheVolumeOptions: CacheVolumeOptions{Image: "some/app", Type: "launch"},
+				Path:               path,
+				HelperImage:        "some/helper",
+			}))
+		})
+
+		it("fails on Windows daemons without removing the volume", func() {
+			path := filepath.Join(tmpDir, "cache.tar")
+			h.AssertNil(t, os.WriteFile(path, nil, 0600))
+
+			mockDockerClient.EXPECT().Info(gomock.Any()).Return(system.Info{OSType: "windows"}, nil)
+
+			err := subject.ImportCache(context.TODO(), ImportCacheOptions{
+				CacheVolumeOptions: CacheVolumeOptions{Name: "some-volume"},
+				Path:               path,
+			})
+			h.AssertError(t, err, "not supported with Windows containers")
+		})
+	})
+}
+
+// cacheTar returns a tarball containing an optional root directory and a single file
+func cacheTar(t *testing.T, root, file string) io.Reader {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+	if root != "" {
+		h.AssertNil(t, tw.WriteHeader(&tar.Header{Name: root, Typeflag: tar.TypeDir, Mode: 0755}))
+	}
+	content := []byte("some-content")
+	h.AssertNil(t, tw.WriteHeader(&tar.Header{Name: file, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(content))}))
+	_, err := tw.Write(content)
+	h.AssertNil(t, err)
+	h.AssertNil(t, tw.Close())
+	return buf
+}
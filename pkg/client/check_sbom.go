@@ -0,0 +1,33 @@
+package client
+
+import (
+	"github.com/buildpacks/pack/pkg/sbom"
+)
+
+type CheckSBOMOptions struct {
+	Daemon bool
+
+	// Path to the policy file listing the packages, licenses and advisories that are not allowed.
+	Policy string
+}
+
+// CheckSBOM checks the packages in the SBOM layer of an image against a policy. Violations are listed in the
+// returned report; an error is only returned when the policy or the SBOM cannot be read.
+func (c *Client) CheckSBOM(name string, options CheckSBOMOptions) (*sbom.Report, error) {
+	policy, err := sbom.ReadPolicy(options.Policy)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.checkSBOM(name, options.Daemon, policy)
+}
+
+func (c *Client) checkSBOM(name string, daemon bool, policy sbom.Policy) (*sbom.Report, error) {
+	packages, err := c.ListSBOM(name, ListSBOMOptions{Daemon: daemon})
+	if err != nil {
+		return nil, err
+	}
+
+	report := sbom.Check(packages, policy)
+	return &report, nil
+}
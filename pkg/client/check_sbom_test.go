@@ -0,0 +1,82 @@
+package client
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/sbom"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCheckSBOM(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "CheckSBOM", testCheckSBOM, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testCheckSBOM(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		mockImageFetcher *testmocks.MockImageFetcher
+		mockController   *gomock.Controller
+		policyPath       string
+		out              bytes.Buffer
+		assert           = h.NewAssertionManager(t)
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockImageFetcher = testmocks.NewMockImageFetcher(mockController)
+
+		var err error
+		subject, err = NewClient(WithLogger(logging.NewLogWithWriters(&out, &out)), WithFetcher(mockImageFetcher))
+		assert.Nil(err)
+
+		policyPath = filepath.Join(t.TempDir(), "policy.toml")
+		assert.Nil(os.WriteFile(policyPath, []byte(`
+[[deny.packages]]
+name = "some-package"
+versions = "< 2.0.0"
+`), 0600))
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	it("checks the packages in the SBOM layer against the policy", func() {
+		img := imageWithSBOM(t, "some/image", map[string]string{
+			"/layers/sbom/launch/some-buildpack/sbom.syft.json": `{"artifacts": [{"name": "some-package", "version": "1.0.0"}, {"name": "other-package", "version": "1.0.0"}]}`,
+		})
+		mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/image", image.FetchOptions{Daemon: true, PullPolicy: image.PullNever}).Return(img, nil)
+
+		result, err := subject.CheckSBOM("some/image", CheckSBOMOptions{Daemon: true, Policy: policyPath})
+		assert.Nil(err)
+
+		assert.Equal(result, &sbom.Report{
+			Packages: 2,
+			Violations: []sbom.Violation{{
+				Rule:    sbom.RuleDeniedPackage,
+				Package: sbom.Package{Name: "some-package", Version: "1.0.0", Buildpack: "some-buildpack", Scope: "launch", Format: sbom.Syft},
+				Details: "package is denied",
+			}},
+		})
+	})
+
+	when("the policy cannot be read", func() {
+		it("returns an error without fetching the image", func() {
+			_, err := subject.CheckSBOM("some/image", CheckSBOMOptions{Daemon: true, Policy: filepath.Join(t.TempDir(), "missing.toml")})
+			assert.ErrorContains(err, "reading policy")
+		})
+	})
+}
@@ -16,6 +16,7 @@ package client
 
 import (
 	"context"
+	"net/url"
 	"os"
 	"path/filepath"
 
@@ -29,10 +30,12 @@ import (
 	"github.com/buildpacks/pack"
 	"github.com/buildpacks/pack/internal/build"
 	iconfig "github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/sshdialer"
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/blob"
 	"github.com/buildpacks/pack/pkg/buildpack"
 	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/index"
 	"github.com/buildpacks/pack/pkg/logging"
 )
 
@@ -87,6 +90,21 @@ type AccessChecker interface {
 	Check(repo string) bool
 }
 
+// IndexFactory is an interface representing the ability to create, load and fetch image indexes.
+type IndexFactory interface {
+	// Exists reports whether an index is present in local storage.
+	Exists(repoName string) bool
+	// CreateIndex initializes a new, empty index.
+	CreateIndex(repoName string, opts index.Options) (*index.Index, error)
+	// LoadIndex reads an index from local storage.
+	LoadIndex(repoName string) (*index.Index, error)
+	// FetchIndex reads an index from a registry.
+	FetchIndex(ctx context.Context, repoName string, insecure bool) (*index.Index, error)
+}
+
+// RemoteShellDialer connects to the host that a build is shipped to with BuildOptions.RemoteHost.
+type RemoteShellDialer func(host *url.URL) (build.RemoteShell, error)
+
 // Client is an orchestration object, it contains all parameters needed to
 // build an app image using Cloud Native Buildpacks.
 // All settings on this object should be changed through ClientOption functions.
@@ -96,15 +114,18 @@ type Client struct {
 
 	keychain            authn.Keychain
 	imageFactory        ImageFactory
+	indexFactory        IndexFactory
 	imageFetcher        ImageFetcher
 	accessChecker       AccessChecker
 	downloader          BlobDownloader
 	lifecycleExecutor   LifecycleExecutor
 	buildpackDownloader BuildpackDownloader
+	remoteShellDialer   RemoteShellDialer
 
-	experimental    bool
-	registryMirrors map[string]string
-	version         string
+	experimental         bool
+	registryMirrors      map[string]string
+	verificationPolicies []image.VerificationPolicy
+	version              string
 }
 
 // Option is a type of function that mutate settings on the client.
@@ -125,6 +146,13 @@ func WithImageFactory(f ImageFactory) Option {
 	}
 }
 
+// WithIndexFactory supply your own index factory.
+func WithIndexFactory(f IndexFactory) Option {
+	return func(c *Client) {
+		c.indexFactory = f
+	}
+}
+
 // WithFetcher supply your own Fetcher.
 // A Fetcher retrieves both local and remote images to make them available.
 func WithFetcher(f ImageFetcher) Option {
@@ -187,6 +215,14 @@ func WithRegistryMirrors(registryMirrors map[string]string) Option {
 	}
 }
 
+// WithVerificationPolicies sets the policies that builder, buildpack and other images must satisfy to be used.
+// Images that are unsigned or don't match the policy for their repository are refused.
+func WithVerificationPolicies(policies []image.VerificationPolicy) Option {
+	return func(c *Client) {
+		c.verificationPolicies = policies
+	}
+}
+
 // WithKeychain sets keychain of credentials to image registries
 func WithKeychain(keychain authn.Keychain) Option {
 	return func(c *Client) {
@@ -194,6 +230,13 @@ func WithKeychain(keychain authn.Keychain) Option {
 	}
 }
 
+// WithRemoteShellDialer supply your own dialer for remote builds, e.g. to prompt for SSH credentials.
+func WithRemoteShellDialer(dialer RemoteShellDialer) Option {
+	return func(c *Client) {
+		c.remoteShellDialer = dialer
+	}
+}
+
 const DockerAPIVersion = "1.38"
 
 // NewClient allocates and returns a Client configured with the specified options.
@@ -230,8 +273,16 @@ func NewClient(opts ...Option) (*Client, error) {
 		client.downloader = blob.NewDownloader(client.logger, filepath.Join(packHome, "download-cache"))
 	}
 
+	if client.indexFactory == nil {
+		packHome, err := iconfig.PackHome()
+		if err != nil {
+			return nil, errors.Wrap(err, "getting pack home")
+		}
+		client.indexFactory = index.NewFactory(filepath.Join(packHome, "manifests"), client.keychain)
+	}
+
 	if client.imageFetcher == nil {
-		client.imageFetcher = image.NewFetcher(client.logger, client.docker, image.WithRegistryMirrors(client.registryMirrors), image.WithKeychain(client.keychain))
+		client.imageFetcher = image.NewFetcher(client.logger, client.docker, image.WithRegistryMirrors(client.registryMirrors), image.WithKeychain(client.keychain), image.WithVerificationPolicies(client.verificationPolicies))
 	}
 
 	if client.imageFactory == nil {
@@ -251,22 +302,37 @@ func NewClient(opts ...Option) (*Client, error) {
 			client.imageFetcher,
 			client.downloader,
 			&registryResolver{
-				logger: client.logger,
+				logger:   client.logger,
+				keychain: client.keychain,
 			},
+			buildpack.WithVerificationPolicies(client.verificationPolicies),
 		)
 	}
 
+	if client.remoteShellDialer == nil {
+		client.remoteShellDialer = dialSSHShell
+	}
+
 	client.lifecycleExecutor = build.NewLifecycleExecutor(client.logger, client.docker)
 
 	return client, nil
 }
 
+func dialSSHShell(host *url.URL) (build.RemoteShell, error) {
+	sshClient, err := sshdialer.NewClient(host, sshdialer.Config{})
+	if err != nil {
+		return nil, err
+	}
+	return build.NewSSHShell(sshClient), nil
+}
+
 type registryResolver struct {
-	logger logging.Logger
+	logger   logging.Logger
+	keychain authn.Keychain
 }
 
 func (r *registryResolver) Resolve(registryName, bpName string) (string, error) {
-	cache, err := getRegistry(r.logger, registryName)
+	cache, err := getRegistryIndex(r.logger, r.keychain, registryName)
 	if err != nil {
 		return "", errors.Wrapf(err, "lookup registry %s", style.Symbol(registryName))
 	}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/name"
 
 	"github.com/buildpacks/pack/internal/builder"
@@ -11,6 +12,7 @@ import (
 	"github.com/buildpacks/pack/internal/registry"
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/logging"
+	registryTypes "github.com/buildpacks/pack/registry"
 )
 
 func (c *Client) parseTagReference(imageName string) (name.Reference, error) {
@@ -86,6 +88,28 @@ func getRegistry(logger logging.Logger, registryName string) (registry.Cache, er
 	return registry.Cache{}, fmt.Errorf("registry %s is not defined in your config file", style.Symbol(registryName))
 }
 
+// getRegistryIndex returns the index to locate buildpacks in, which is stored in an image registry for registries
+// of type oci, or else is a local clone of a git index
+func getRegistryIndex(logger logging.Logger, keychain authn.Keychain, registryName string) (registry.Index, error) {
+	if registryName != "" {
+		cfg, err := getConfig()
+		if err != nil {
+			return nil, err
+		}
+		for _, reg := range config.GetRegistries(cfg) {
+			if reg.Name == registryName && reg.Type == registryTypes.TypeOCI {
+				return registry.NewOCIIndex(logger, reg.URL, keychain)
+			}
+		}
+	}
+
+	registryCache, err := getRegistry(logger, registryName)
+	if err != nil {
+		return nil, err
+	}
+	return &registryCache, nil
+}
+
 func getConfig() (config.Config, error) {
 	path, err := config.DefaultConfigPath()
 	if err != nil {
@@ -8,6 +8,7 @@ import (
 
 	"github.com/Masterminds/semver"
 	"github.com/buildpacks/imgutil"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/pkg/errors"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
@@ -17,7 +18,9 @@ import (
 	"github.com/buildpacks/pack/internal/paths"
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/index"
 )
 
 // CreateBuilderOptions is a configuration object used to change the behavior of
@@ -54,22 +57,65 @@ type CreateBuilderOptions struct {
 
 // CreateBuilder creates and saves a builder image to a registry with the provided options.
 // If any configuration is invalid, it will error and exit without creating any images.
+//
+// When the builder config declares targets, a builder image is created for each of them
+// and, when publishing, the images are combined into an image index named BuilderName.
+// Without publishing only the target matching the daemon's platform is created.
 func (c *Client) CreateBuilder(ctx context.Context, opts CreateBuilderOptions) error {
-	if err := c.validateConfig(ctx, opts); err != nil {
+	targets := opts.Config.Targets
+	if len(targets) == 0 {
+		_, err := c.createBuilderTarget(ctx, opts, nil)
 		return err
 	}
 
-	bldr, err := c.createBaseBuilder(ctx, opts)
+	if !opts.Publish {
+		target, err := c.daemonTarget(ctx, targets)
+		if err != nil {
+			return err
+		}
+		_, err = c.createBuilderTarget(ctx, opts, &target)
+		return err
+	}
+
+	var digests []string
+	for i := range targets {
+		digest, err := c.createBuilderTarget(ctx, opts, &targets[i])
+		if err != nil {
+			return err
+		}
+		digests = append(digests, digest)
+	}
+
+	return c.createImageIndex(ctx, opts.BuilderName, digests)
+}
+
+// createBuilderTarget creates the builder image for a single target, or for the platform of
+// the build image when target is nil, and returns the reference it was saved as.
+func (c *Client) createBuilderTarget(ctx context.Context, opts CreateBuilderOptions, target *dist.Target) (string, error) {
+	platform := targetPlatform(target)
+	if platform != "" {
+		c.logger.Infof("Creating builder %s for platform %s", style.Symbol(opts.BuilderName), style.Symbol(platform))
+	}
+
+	if err := c.validateConfig(ctx, opts, platform); err != nil {
+		return "", err
+	}
+
+	bldr, err := c.createBaseBuilder(ctx, opts, platform)
 	if err != nil {
-		return errors.Wrap(err, "failed to create builder")
+		return "", errors.Wrap(err, "failed to create builder")
+	}
+
+	if err := validateBuilderTarget(bldr, target); err != nil {
+		return "", errors.Wrap(err, "failed to create builder")
 	}
 
 	if err := c.addBuildpacksToBuilder(ctx, opts, bldr); err != nil {
-		return errors.Wrap(err, "failed to add buildpacks to builder")
+		return "", errors.Wrap(err, "failed to add buildpacks to builder")
 	}
 
 	if err := c.addExtensionsToBuilder(ctx, opts, bldr); err != nil {
-		return errors.Wrap(err, "failed to add extensions to builder")
+		return "", errors.Wrap(err, "failed to add extensions to builder")
 	}
 
 	bldr.SetOrder(opts.Config.Order)
@@ -81,27 +127,122 @@ func (c *Client) CreateBuilder(ctx context.Context, opts CreateBuilderOptions) e
 	bldr.SetRunImage(opts.Config.Run)
 	bldr.SetBuildConfigEnv(opts.BuildConfigEnv)
 
-	return bldr.Save(c.logger, builder.CreatorMetadata{Version: c.version})
+	if err := bldr.Save(c.logger, builder.CreatorMetadata{Version: c.version}); err != nil {
+		return "", err
+	}
+
+	if target == nil || !opts.Publish {
+		return opts.BuilderName, nil
+	}
+
+	identifier, err := bldr.Image().Identifier()
+	if err != nil {
+		return "", errors.Wrapf(err, "getting identifier of builder for platform %s", style.Symbol(platform))
+	}
+	return identifier.String(), nil
+}
+
+// daemonTarget returns the target matching the platform of the docker daemon.
+func (c *Client) daemonTarget(ctx context.Context, targets []dist.Target) (dist.Target, error) {
+	version, err := c.docker.ServerVersion(ctx)
+	if err != nil {
+		return dist.Target{}, errors.Wrap(err, "getting daemon version")
+	}
+
+	for _, t := range targets {
+		if t.OS == version.Os && t.Arch == version.Arch {
+			return t, nil
+		}
+	}
+	return dist.Target{}, errors.Errorf(
+		"builder config has no target for the daemon platform %s; use %s to create builders for other platforms",
+		style.Symbol(version.Os+"/"+version.Arch),
+		style.Symbol("--publish"),
+	)
+}
+
+// createImageIndex pushes an image index named indexName referencing the given manifests.
+func (c *Client) createImageIndex(ctx context.Context, indexName string, digests []string) error {
+	if c.indexFactory.Exists(indexName) {
+		stale, err := c.indexFactory.LoadIndex(indexName)
+		if err != nil {
+			return err
+		}
+		c.logger.Debugf("Replacing local image index %s", style.Symbol(indexName))
+		if err := stale.Delete(); err != nil {
+			return err
+		}
+	}
+
+	idx, err := c.indexFactory.CreateIndex(indexName, index.Options{})
+	if err != nil {
+		return errors.Wrapf(err, "creating index %s", style.Symbol(indexName))
+	}
+
+	for _, digest := range digests {
+		if _, err := idx.Add(ctx, digest, v1.Platform{}); err != nil {
+			return errors.Wrapf(err, "adding %s to index", style.Symbol(digest))
+		}
+	}
+
+	return c.pushIndex(ctx, idx, true)
 }
 
-func (c *Client) validateConfig(ctx context.Context, opts CreateBuilderOptions) error {
+func validateBuilderTarget(bldr *builder.Builder, target *dist.Target) error {
+	if target == nil {
+		return nil
+	}
+
+	os, err := bldr.Image().OS()
+	if err != nil {
+		return errors.Wrap(err, "lookup image OS")
+	}
+	arch, err := bldr.Image().Architecture()
+	if err != nil {
+		return errors.Wrap(err, "lookup image Architecture")
+	}
+
+	if os != target.OS || arch != target.Arch {
+		return errors.Errorf(
+			"build image %s is %s, expected %s",
+			style.Symbol(bldr.BaseImageName()),
+			style.Symbol(os+"/"+arch),
+			style.Symbol(targetPlatform(target)),
+		)
+	}
+	return nil
+}
+
+func targetPlatform(target *dist.Target) string {
+	if target == nil {
+		return ""
+	}
+
+	platform := target.OS + "/" + target.Arch
+	if target.ArchVariant != "" {
+		platform += "/" + target.ArchVariant
+	}
+	return platform
+}
+
+func (c *Client) validateConfig(ctx context.Context, opts CreateBuilderOptions, platform string) error {
 	if err := pubbldr.ValidateConfig(opts.Config); err != nil {
 		return errors.Wrap(err, "invalid builder config")
 	}
 
-	if err := c.validateRunImageConfig(ctx, opts); err != nil {
+	if err := c.validateRunImageConfig(ctx, opts, platform); err != nil {
 		return errors.Wrap(err, "invalid run image config")
 	}
 
 	return nil
 }
 
-func (c *Client) validateRunImageConfig(ctx context.Context, opts CreateBuilderOptions) error {
+func (c *Client) validateRunImageConfig(ctx context.Context, opts CreateBuilderOptions, platform string) error {
 	var runImages []imgutil.Image
 	for _, r := range opts.Config.Run.Images {
 		for _, i := range append([]string{r.Image}, r.Mirrors...) {
 			if !opts.Publish {
-				img, err := c.imageFetcher.Fetch(ctx, i, image.FetchOptions{Daemon: true, PullPolicy: opts.PullPolicy})
+				img, err := c.imageFetcher.Fetch(ctx, i, image.FetchOptions{Daemon: true, Platform: platform, PullPolicy: opts.PullPolicy})
 				if err != nil {
 					if errors.Cause(err) != image.ErrNotFound {
 						return errors.Wrap(err, "failed to fetch image")
@@ -112,7 +253,7 @@ func (c *Client) validateRunImageConfig(ctx context.Context, opts CreateBuilderO
 				}
 			}
 
-			img, err := c.imageFetcher.Fetch(ctx, i, image.FetchOptions{Daemon: false, PullPolicy: opts.PullPolicy})
+			img, err := c.imageFetcher.Fetch(ctx, i, image.FetchOptions{Daemon: false, Platform: platform, PullPolicy: opts.PullPolicy})
 			if err != nil {
 				if errors.Cause(err) != image.ErrNotFound {
 					return errors.Wrap(err, "failed to fetch image")
@@ -145,8 +286,8 @@ func (c *Client) validateRunImageConfig(ctx context.Context, opts CreateBuilderO
 	return nil
 }
 
-func (c *Client) createBaseBuilder(ctx context.Context, opts CreateBuilderOptions) (*builder.Builder, error) {
-	baseImage, err := c.imageFetcher.Fetch(ctx, opts.Config.Build.Image, image.FetchOptions{Daemon: !opts.Publish, PullPolicy: opts.PullPolicy})
+func (c *Client) createBaseBuilder(ctx context.Context, opts CreateBuilderOptions, platform string) (*builder.Builder, error) {
+	baseImage, err := c.imageFetcher.Fetch(ctx, opts.Config.Build.Image, image.FetchOptions{Daemon: !opts.Publish, Platform: platform, PullPolicy: opts.PullPolicy})
 	if err != nil {
 		return nil, errors.Wrap(err, "fetch build image")
 	}
@@ -335,7 +476,17 @@ func validateModule(kind string, module buildpack.BuildModule, source, expectedI
 		)
 	}
 
-	if expectedVersion != "" && info.Version != expectedVersion {
+	if buildpack.IsVersionRange(expectedVersion) {
+		if _, found := buildpack.ResolveVersionRange(expectedVersion, []string{info.Version}); !found {
+			return fmt.Errorf(
+				"%s from URI %s has version %s which does not match version range %s from builder config",
+				kind,
+				style.Symbol(source),
+				style.Symbol(info.Version),
+				style.Symbol(expectedVersion),
+			)
+		}
+	} else if expectedVersion != "" && info.Version != expectedVersion {
 		return fmt.Errorf(
 			"%s from URI %s has version %s which does not match version %s from builder config",
 			kind,
@@ -355,7 +506,8 @@ func uriFromLifecycleVersion(version semver.Version, os string, architecture str
 		return fmt.Sprintf("https://github.com/buildpacks/lifecycle/releases/download/v%s/lifecycle-v%s+windows.%s.tgz", version.String(), version.String(), arch)
 	}
 
-	if architecture == "arm64" {
+	switch architecture {
+	case "arm64", "ppc64le", "s390x":
 		arch = architecture
 	}
 
@@ -4,15 +4,25 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/buildpacks/imgutil/fakes"
+	"github.com/buildpacks/imgutil/remote"
 	"github.com/buildpacks/lifecycle/api"
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/system"
 	"github.com/golang/mock/gomock"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	ggcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/heroku/color"
 	"github.com/pkg/errors"
 	"github.com/sclevine/spec"
@@ -30,6 +40,7 @@ import (
 	"github.com/buildpacks/pack/pkg/client"
 	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/index"
 	"github.com/buildpacks/pack/pkg/logging"
 	"github.com/buildpacks/pack/pkg/testmocks"
 	h "github.com/buildpacks/pack/testhelpers"
@@ -321,6 +332,16 @@ func testCreateBuilder(t *testing.T, when spec.G, it spec.S) {
 				h.AssertError(t, err, "buildpack from URI 'https://example.fake/bp-one.tgz' has version '1.2.3' which does not match version '0.0.0' from builder config")
 			})
 
+			it("should fail when buildpack version does not match the version range from builder config", func() {
+				prepareFetcherWithBuildImage()
+				prepareFetcherWithRunImages()
+				opts.Config.Buildpacks[0].Version = "^2.0"
+
+				err := subject.CreateBuilder(context.TODO(), opts)
+
+				h.AssertError(t, err, "buildpack from URI 'https://example.fake/bp-one.tgz' has version '1.2.3' which does not match version range '^2.0' from builder config")
+			})
+
 			it("should fail when extension ID does not match downloaded extension", func() {
 				prepareFetcherWithBuildImage()
 				prepareFetcherWithRunImages()
@@ -419,6 +440,122 @@ func testCreateBuilder(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("builder config has targets", func() {
+			it.Before(func() {
+				opts.Config.Targets = []dist.Target{
+					{OS: "linux", Arch: "amd64"},
+					{OS: "linux", Arch: "arm64"},
+				}
+			})
+
+			when("publish is false", func() {
+				it("creates the builder for the daemon platform", func() {
+					mockDockerClient.EXPECT().ServerVersion(gomock.Any()).Return(types.Version{Os: "linux", Arch: "amd64"}, nil)
+					mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/build-image", image.FetchOptions{Daemon: true, Platform: "linux/amd64", PullPolicy: image.PullAlways}).Return(fakeBuildImage, nil)
+					prepareFetcherWithRunImages()
+
+					bldr := successfullyCreateBuilder()
+					h.AssertEq(t, bldr.Name(), "some/builder")
+				})
+
+				it("fails when no target matches the daemon platform", func() {
+					mockDockerClient.EXPECT().ServerVersion(gomock.Any()).Return(types.Version{Os: "linux", Arch: "s390x"}, nil)
+
+					err := subject.CreateBuilder(context.TODO(), opts)
+					h.AssertError(t, err, "builder config has no target for the daemon platform 'linux/s390x'")
+				})
+
+				it("fails when the build image does not match the target", func() {
+					mockDockerClient.EXPECT().ServerVersion(gomock.Any()).Return(types.Version{Os: "linux", Arch: "arm64"}, nil)
+					mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/build-image", gomock.Any()).Return(fakeBuildImage, nil)
+					prepareFetcherWithRunImages()
+
+					err := subject.CreateBuilder(context.TODO(), opts)
+					h.AssertError(t, err, "build image 'some/build-image' is 'linux/amd64', expected 'linux/arm64'")
+				})
+			})
+
+			when("publish is true", func() {
+				var (
+					server *httptest.Server
+					host   string
+				)
+
+				var pushPlatformImage = func(arch string) name.Digest {
+					img, err := random.Image(1024, 1)
+					h.AssertNil(t, err)
+					cfg, err := img.ConfigFile()
+					h.AssertNil(t, err)
+					cfg.OS = "linux"
+					cfg.Architecture = arch
+					img, err = mutate.ConfigFile(img, cfg)
+					h.AssertNil(t, err)
+
+					ref, err := name.ParseReference(host + "/some/builder:" + arch)
+					h.AssertNil(t, err)
+					h.AssertNil(t, ggcrremote.Write(ref, img))
+					digest, err := img.Digest()
+					h.AssertNil(t, err)
+					return ref.Context().Digest(digest.String())
+				}
+
+				it.Before(func() {
+					server = httptest.NewServer(registry.New())
+					u, err := url.Parse(server.URL)
+					h.AssertNil(t, err)
+					host = u.Host
+
+					subject, err = client.NewClient(
+						client.WithLogger(logger),
+						client.WithDownloader(mockDownloader),
+						client.WithImageFactory(mockImageFactory),
+						client.WithFetcher(mockImageFetcher),
+						client.WithDockerClient(mockDockerClient),
+						client.WithBuildpackDownloader(mockBuildpackDownloader),
+						client.WithIndexFactory(index.NewFactory(tmpDir, authn.DefaultKeychain)),
+					)
+					h.AssertNil(t, err)
+
+					opts.Publish = true
+					opts.BuilderName = host + "/some/builder"
+				})
+
+				it.After(func() {
+					server.Close()
+				})
+
+				it("creates a builder per target and pushes an image index", func() {
+					amd64BuildImage := fakes.NewImage("some/build-image", "", remote.DigestIdentifier{Digest: pushPlatformImage("amd64")})
+					arm64BuildImage := fakes.NewImage("some/build-image", "", remote.DigestIdentifier{Digest: pushPlatformImage("arm64")})
+					h.AssertNil(t, arm64BuildImage.SetArchitecture("arm64"))
+					for _, img := range []*fakes.Image{amd64BuildImage, arm64BuildImage} {
+						h.AssertNil(t, img.SetLabel("io.buildpacks.stack.id", "some.stack.id"))
+						h.AssertNil(t, img.SetLabel("io.buildpacks.stack.mixins", `["mixinX", "build:mixinY"]`))
+						h.AssertNil(t, img.SetEnv("CNB_USER_ID", "1234"))
+						h.AssertNil(t, img.SetEnv("CNB_GROUP_ID", "4321"))
+					}
+
+					mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/build-image", image.FetchOptions{Platform: "linux/amd64", PullPolicy: image.PullAlways}).Return(amd64BuildImage, nil)
+					mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/build-image", image.FetchOptions{Platform: "linux/arm64", PullPolicy: image.PullAlways}).Return(arm64BuildImage, nil)
+					prepareFetcherWithRunImages()
+
+					h.AssertNil(t, subject.CreateBuilder(context.TODO(), opts))
+					h.AssertTrue(t, amd64BuildImage.IsSaved())
+					h.AssertTrue(t, arm64BuildImage.IsSaved())
+
+					ref, err := name.ParseReference(opts.BuilderName)
+					h.AssertNil(t, err)
+					idx, err := ggcrremote.Index(ref)
+					h.AssertNil(t, err)
+					manifest, err := idx.IndexManifest()
+					h.AssertNil(t, err)
+					h.AssertEq(t, len(manifest.Manifests), 2)
+					h.AssertEq(t, manifest.Manifests[0].Platform.Architecture, "amd64")
+					h.AssertEq(t, manifest.Manifests[1].Platform.Architecture, "arm64")
+				})
+			})
+		})
+
 		when("creating the base builder", func() {
 			when("build image not found", func() {
 				it("should fail", func() {
@@ -0,0 +1,257 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	pubbldr "github.com/buildpacks/pack/builder"
+	"github.com/buildpacks/pack/internal/builder"
+	"github.com/buildpacks/pack/pkg/dist"
+)
+
+// BuilderDiff describes what changed between two builders, as returned by DiffBuilders.
+type BuilderDiff struct {
+	Lifecycle       LifecycleDiff    `json:"lifecycle" yaml:"lifecycle" toml:"lifecycle"`
+	Stack           *ValueChange     `json:"stack,omitempty" yaml:"stack,omitempty" toml:"stack,omitempty"`
+	Mixins          ListDiff         `json:"mixins" yaml:"mixins" toml:"mixins"`
+	RunImages       ListDiff         `json:"run_images" yaml:"run_images" toml:"run_images"`
+	RunImageMirrors ListDiff         `json:"run_image_mirrors" yaml:"run_image_mirrors" toml:"run_image_mirrors"`
+	Buildpacks      ModuleDiff       `json:"buildpacks" yaml:"buildpacks" toml:"buildpacks"`
+	Order           []OrderGroupDiff `json:"detection_order,omitempty" yaml:"detection_order,omitempty" toml:"detection_order,omitempty"`
+	Extensions      ModuleDiff       `json:"extensions" yaml:"extensions" toml:"extensions"`
+	OrderExtensions []OrderGroupDiff `json:"order_extensions,omitempty" yaml:"order_extensions,omitempty" toml:"order_extensions,omitempty"`
+}
+
+// LifecycleDiff describes changes to the lifecycle of a builder and the APIs it supports.
+type LifecycleDiff struct {
+	Version                 *ValueChange `json:"version,omitempty" yaml:"version,omitempty" toml:"version,omitempty"`
+	BuildpackAPIs           ListDiff     `json:"buildpack_apis" yaml:"buildpack_apis" toml:"buildpack_apis"`
+	DeprecatedBuildpackAPIs ListDiff     `json:"deprecated_buildpack_apis" yaml:"deprecated_buildpack_apis" toml:"deprecated_buildpack_apis"`
+	PlatformAPIs            ListDiff     `json:"platform_apis" yaml:"platform_apis" toml:"platform_apis"`
+	DeprecatedPlatformAPIs  ListDiff     `json:"deprecated_platform_apis" yaml:"deprecated_platform_apis" toml:"deprecated_platform_apis"`
+}
+
+// ValueChange records a value that differs between two builders.
+type ValueChange struct {
+	Old string `json:"old" yaml:"old" toml:"old"`
+	New string `json:"new" yaml:"new" toml:"new"`
+}
+
+// ListDiff records the entries of a list that were only present in one of two builders.
+type ListDiff struct {
+	Added   []string `json:"added,omitempty" yaml:"added,omitempty" toml:"added,omitempty"`
+	Removed []string `json:"removed,omitempty" yaml:"removed,omitempty" toml:"removed,omitempty"`
+}
+
+// ModuleDiff records the buildpacks or extensions added to or removed from a builder, and those whose
+// version changed. A module is only reported as changed when both builders contain a single version of it.
+type ModuleDiff struct {
+	Added   []dist.ModuleInfo `json:"added,omitempty" yaml:"added,omitempty" toml:"added,omitempty"`
+	Removed []dist.ModuleInfo `json:"removed,omitempty" yaml:"removed,omitempty" toml:"removed,omitempty"`
+	Changed []ModuleChange    `json:"changed,omitempty" yaml:"changed,omitempty" toml:"changed,omitempty"`
+}
+
+// ModuleChange records the versions of a buildpack or extension in two builders.
+type ModuleChange struct {
+	ID         string `json:"id" yaml:"id" toml:"id"`
+	OldVersion string `json:"old_version" yaml:"old_version" toml:"old_version"`
+	NewVersion string `json:"new_version" yaml:"new_version" toml:"new_version"`
+}
+
+// OrderGroupDiff records a detection order group that differs between two builders. Groups are numbered from 1,
+// and Old or New is empty when only one of the builders has the group.
+type OrderGroupDiff struct {
+	Group int      `json:"group" yaml:"group" toml:"group"`
+	Old   []string `json:"old,omitempty" yaml:"old,omitempty" toml:"old,omitempty"`
+	New   []string `json:"new,omitempty" yaml:"new,omitempty" toml:"new,omitempty"`
+}
+
+// Empty reports whether no differences were found.
+func (d ListDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0
+}
+
+// Empty reports whether no differences were found.
+func (d ModuleDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Empty reports whether no differences were found.
+func (d LifecycleDiff) Empty() bool {
+	return d.Version == nil &&
+		d.BuildpackAPIs.Empty() && d.DeprecatedBuildpackAPIs.Empty() &&
+		d.PlatformAPIs.Empty() && d.DeprecatedPlatformAPIs.Empty()
+}
+
+// Empty reports whether no differences were found.
+func (d BuilderDiff) Empty() bool {
+	return d.Lifecycle.Empty() && d.Stack == nil && d.Mixins.Empty() &&
+		d.RunImages.Empty() && d.RunImageMirrors.Empty() &&
+		d.Buildpacks.Empty() && len(d.Order) == 0 &&
+		d.Extensions.Empty() && len(d.OrderExtensions) == 0
+}
+
+// DiffBuilders compares the builders described by oldInfo and newInfo, as returned by InspectBuilder.
+// Detection orders are compared group by group, as calculated for the depth the builders were inspected with.
+func DiffBuilders(oldInfo, newInfo *BuilderInfo) *BuilderDiff {
+	return &BuilderDiff{
+		Lifecycle: LifecycleDiff{
+			Version:                 diffValue(lifecycleVersion(oldInfo.Lifecycle), lifecycleVersion(newInfo.Lifecycle)),
+			BuildpackAPIs:           diffList(oldInfo.Lifecycle.APIs.Buildpack.Supported.AsStrings(), newInfo.Lifecycle.APIs.Buildpack.Supported.AsStrings()),
+			DeprecatedBuildpackAPIs: diffList(oldInfo.Lifecycle.APIs.Buildpack.Deprecated.AsStrings(), newInfo.Lifecycle.APIs.Buildpack.Deprecated.AsStrings()),
+			PlatformAPIs:            diffList(oldInfo.Lifecycle.APIs.Platform.Supported.AsStrings(), newInfo.Lifecycle.APIs.Platform.Supported.AsStrings()),
+			DeprecatedPlatformAPIs:  diffList(oldInfo.Lifecycle.APIs.Platform.Deprecated.AsStrings(), newInfo.Lifecycle.APIs.Platform.Deprecated.AsStrings()),
+		},
+		Stack:           diffValue(oldInfo.Stack, newInfo.Stack),
+		Mixins:          diffList(oldInfo.Mixins, newInfo.Mixins),
+		RunImages:       diffList(runImageNames(oldInfo.RunImages), runImageNames(newInfo.RunImages)),
+		RunImageMirrors: diffList(runImageMirrors(oldInfo.RunImages), runImageMirrors(newInfo.RunImages)),
+		Buildpacks:      diffModules(oldInfo.Buildpacks, newInfo.Buildpacks),
+		Order:           diffOrder(oldInfo.Order, newInfo.Order),
+		Extensions:      diffModules(oldInfo.Extensions, newInfo.Extensions),
+		OrderExtensions: diffOrder(oldInfo.OrderExtensions, newInfo.OrderExtensions),
+	}
+}
+
+func lifecycleVersion(lifecycle builder.LifecycleDescriptor) string {
+	if lifecycle.Info.Version == nil {
+		return ""
+	}
+	return lifecycle.Info.Version.String()
+}
+
+func diffValue(oldValue, newValue string) *ValueChange {
+	if oldValue == newValue {
+		return nil
+	}
+	return &ValueChange{Old: oldValue, New: newValue}
+}
+
+func diffList(oldList, newList []string) ListDiff {
+	var diff ListDiff
+	for _, entry := range newList {
+		if !contains(oldList, entry) {
+			diff.Added = append(diff.Added, entry)
+		}
+	}
+	for _, entry := range oldList {
+		if !contains(newList, entry) {
+			diff.Removed = append(diff.Removed, entry)
+		}
+	}
+	return diff
+}
+
+func runImageNames(runImages []pubbldr.RunImageConfig) []string {
+	var names []string
+	for _, runImage := range runImages {
+		names = append(names, runImage.Image)
+	}
+	return names
+}
+
+func runImageMirrors(runImages []pubbldr.RunImageConfig) []string {
+	var mirrors []string
+	for _, runImage := range runImages {
+		mirrors = append(mirrors, runImage.Mirrors...)
+	}
+	return mirrors
+}
+
+func diffModules(oldModules, newModules []dist.ModuleInfo) ModuleDiff {
+	oldByID, newByID := modulesByID(oldModules), modulesByID(newModules)
+
+	var ids []string
+	for id := range oldByID {
+		ids = append(ids, id)
+	}
+	for id := range newByID {
+		if _, ok := oldByID[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	var diff ModuleDiff
+	for _, id := range ids {
+		oldVersions, newVersions := oldByID[id], newByID[id]
+		if len(oldVersions) == 1 && len(newVersions) == 1 {
+			if oldVersions[0].Version != newVersions[0].Version {
+				diff.Changed = append(diff.Changed, ModuleChange{ID: id, OldVersion: oldVersions[0].Version, NewVersion: newVersions[0].Version})
+			}
+			continue
+		}
+
+		for _, module := range newVersions {
+			if !containsVersion(oldVersions, module.Version) {
+				diff.Added = append(diff.Added, module)
+			}
+		}
+		for _, module := range oldVersions {
+			if !containsVersion(newVersions, module.Version) {
+				diff.Removed = append(diff.Removed, module)
+			}
+		}
+	}
+	return diff
+}
+
+func modulesByID(modules []dist.ModuleInfo) map[string][]dist.ModuleInfo {
+	byID := map[string][]dist.ModuleInfo{}
+	for _, module := range modules {
+		if !containsVersion(byID[module.ID], module.Version) {
+			byID[module.ID] = append(byID[module.ID], module)
+		}
+	}
+	return byID
+}
+
+func containsVersion(modules []dist.ModuleInfo, version string) bool {
+	for _, module := range modules {
+		if module.Version == version {
+			return true
+		}
+	}
+	return false
+}
+
+func diffOrder(oldOrder, newOrder pubbldr.DetectionOrder) []OrderGroupDiff {
+	var diffs []OrderGroupDiff
+	for i := 0; i < len(oldOrder) || i < len(newOrder); i++ {
+		var oldGroup, newGroup []string
+		if i < len(oldOrder) {
+			oldGroup = flattenGroup(oldOrder[i].GroupDetectionOrder, "")
+		}
+		if i < len(newOrder) {
+			newGroup = flattenGroup(newOrder[i].GroupDetectionOrder, "")
+		}
+		if strings.Join(oldGroup, "\n") != strings.Join(newGroup, "\n") {
+			diffs = append(diffs, OrderGroupDiff{Group: i + 1, Old: oldGroup, New: newGroup})
+		}
+	}
+	return diffs
+}
+
+// flattenGroup lists the modules of a detection order group, prefixing the modules of nested groups with
+// the composite buildpack they were expanded from
+func flattenGroup(group pubbldr.DetectionOrder, prefix string) []string {
+	var entries []string
+	for _, entry := range group {
+		if len(entry.GroupDetectionOrder) > 0 {
+			parent := prefix
+			if entry.ID != "" {
+				parent = fmt.Sprintf("%s%s > ", prefix, entry.FullName())
+			}
+			entries = append(entries, flattenGroup(entry.GroupDetectionOrder, parent)...)
+			continue
+		}
+
+		name := prefix + entry.FullName()
+		if entry.Optional {
+			name += " (optional)"
+		}
+		entries = append(entries, name)
+	}
+	return entries
+}
@@ -0,0 +1,185 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/buildpacks/lifecycle/api"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	pubbldr "github.com/buildpacks/pack/builder"
+	"github.com/buildpacks/pack/internal/builder"
+	"github.com/buildpacks/pack/pkg/dist"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestDiffBuilders(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "DiffBuilders", testDiffBuilders, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testDiffBuilders(t *testing.T, when spec.G, it spec.S) {
+	var (
+		oldInfo, newInfo *BuilderInfo
+		assert           = h.NewAssertionManager(t)
+	)
+
+	newBuilderInfo := func() *BuilderInfo {
+		return &BuilderInfo{
+			Stack:  "some.stack.id",
+			Mixins: []string{"mixinA", "build:mixinB"},
+			RunImages: []pubbldr.RunImageConfig{
+				{Image: "some/run-image", Mirrors: []string{"gcr.io/some/run-image"}},
+			},
+			Buildpacks: []dist.ModuleInfo{
+				{ID: "some.bp", Version: "1.0.0"},
+				{ID: "other.bp", Version: "2.0.0"},
+			},
+			Order: pubbldr.DetectionOrder{
+				{GroupDetectionOrder: pubbldr.DetectionOrder{
+					{ModuleRef: dist.ModuleRef{ModuleInfo: dist.ModuleInfo{ID: "some.bp", Version: "1.0.0"}}},
+					{ModuleRef: dist.ModuleRef{ModuleInfo: dist.ModuleInfo{ID: "other.bp", Version: "2.0.0"}, Optional: true}},
+				}},
+			},
+			Lifecycle: builder.LifecycleDescriptor{
+				Info: builder.LifecycleInfo{Version: builder.VersionMustParse("0.17.0")},
+				APIs: builder.LifecycleAPIs{
+					Buildpack: builder.APIVersions{Supported: builder.APISet{api.MustParse("0.9"), api.MustParse("0.10")}},
+					Platform:  builder.APIVersions{Supported: builder.APISet{api.MustParse("0.11"), api.MustParse("0.12")}},
+				},
+			},
+		}
+	}
+
+	it.Before(func() {
+		oldInfo = newBuilderInfo()
+		newInfo = newBuilderInfo()
+	})
+
+	when("the builders are the same", func() {
+		it("reports no differences", func() {
+			diff := DiffBuilders(oldInfo, newInfo)
+
+			assert.Equal(diff.Empty(), true)
+		})
+	})
+
+	when("the lifecycle changed", func() {
+		it("reports the version and API changes", func() {
+			newInfo.Lifecycle.Info.Version = builder.VersionMustParse("0.18.0")
+			newInfo.Lifecycle.APIs.Platform.Supported = builder.APISet{api.MustParse("0.12"), api.MustParse("0.13")}
+
+			diff := DiffBuilders(oldInfo, newInfo)
+
+			assert.Equal(diff.Lifecycle.Version, &ValueChange{Old: "0.17.0", New: "0.18.0"})
+			assert.Equal(diff.Lifecycle.PlatformAPIs, ListDiff{Added: []string{"0.13"}, Removed: []string{"0.11"}})
+			assert.Equal(diff.Lifecycle.BuildpackAPIs.Empty(), true)
+			assert.Equal(diff.Empty(), false)
+		})
+	})
+
+	when("the stack, mixins and run images changed", func() {
+		it("reports the changes", func() {
+			newInfo.Stack = "other.stack.id"
+			newInfo.Mixins = []string{"mixinA", "run:mixinC"}
+			newInfo.RunImages = []pubbldr.RunImageConfig{{Image: "other/run-image"}}
+
+			diff := DiffBuilders(oldInfo, newInfo)
+
+			assert.Equal(diff.Stack, &ValueChange{Old: "some.stack.id", New: "other.stack.id"})
+			assert.Equal(diff.Mixins, ListDiff{Added: []string{"run:mixinC"}, Removed: []string{"build:mixinB"}})
+			assert.Equal(diff.RunImages, ListDiff{Added: []string{"other/run-image"}, Removed: []string{"some/run-image"}})
+			assert.Equal(diff.RunImageMirrors, ListDiff{Removed: []string{"gcr.io/some/run-image"}})
+		})
+	})
+
+	when("buildpacks changed", func() {
+		it("reports added, removed and bumped buildpacks", func() {
+			newInfo.Buildpacks = []dist.ModuleInfo{
+				{ID: "some.bp", Version: "1.1.0"},
+				{ID: "new.bp", Version: "0.1.0"},
+			}
+
+			diff := DiffBuilders(oldInfo, newInfo)
+
+			assert.Equal(diff.Buildpacks, ModuleDiff{
+				Added:   []dist.ModuleInfo{{ID: "new.bp", Version: "0.1.0"}},
+				Removed: []dist.ModuleInfo{{ID: "other.bp", Version: "2.0.0"}},
+				Changed: []ModuleChange{{ID: "some.bp", OldVersion: "1.0.0", NewVersion: "1.1.0"}},
+			})
+		})
+
+		when("a builder has several versions of a buildpack", func() {
+			it("reports the versions added and removed", func() {
+				oldInfo.Buildpacks = append(oldInfo.Buildpacks, dist.ModuleInfo{ID: "some.bp", Version: "0.9.0"})
+				newInfo.Buildpacks = []dist.ModuleInfo{
+					{ID: "some.bp", Version: "1.0.0"},
+					{ID: "some.bp", Version: "1.1.0"},
+					{ID: "other.bp", Version: "2.0.0"},
+				}
+
+				diff := DiffBuilders(oldInfo, newInfo)
+
+				assert.Equal(diff.Buildpacks, ModuleDiff{
+					Added:   []dist.ModuleInfo{{ID: "some.bp", Version: "1.1.0"}},
+					Removed: []dist.ModuleInfo{{ID: "some.bp", Version: "0.9.0"}},
+				})
+			})
+		})
+	})
+
+	when("the detection order changed", func() {
+		it("reports the groups that differ", func() {
+			newInfo.Order = pubbldr.DetectionOrder{
+				oldInfo.Order[0],
+				{GroupDetectionOrder: pubbldr.DetectionOrder{
+					{
+						ModuleRef: dist.ModuleRef{ModuleInfo: dist.ModuleInfo{ID: "composite.bp", Version: "3.0.0"}},
+						GroupDetectionOrder: pubbldr.DetectionOrder{
+							{ModuleRef: dist.ModuleRef{ModuleInfo: dist.ModuleInfo{ID: "some.bp", Version: "1.0.0"}}},
+						},
+					},
+				}},
+			}
+
+			diff := DiffBuilders(oldInfo, newInfo)
+
+			assert.Equal(diff.Order, []OrderGroupDiff{
+				{Group: 2, New: []string{"composite.bp@3.0.0 > some.bp@1.0.0"}},
+			})
+		})
+
+		it("includes whether a buildpack is optional", func() {
+			newInfo.Order[0].GroupDetectionOrder = pubbldr.DetectionOrder{
+				{ModuleRef: dist.ModuleRef{ModuleInfo: dist.ModuleInfo{ID: "some.bp", Version: "1.0.0"}}},
+				{ModuleRef: dist.ModuleRef{ModuleInfo: dist.ModuleInfo{ID: "other.bp", Version: "2.0.0"}}},
+			}
+
+			diff := DiffBuilders(oldInfo, newInfo)
+
+			assert.Equal(diff.Order, []OrderGroupDiff{{
+				Group: 1,
+				Old:   []string{"some.bp@1.0.0", "other.bp@2.0.0 (optional)"},
+				New:   []string{"some.bp@1.0.0", "other.bp@2.0.0"},
+			}})
+		})
+	})
+
+	when("extensions changed", func() {
+		it("reports the extensions and their order", func() {
+			newInfo.Extensions = []dist.ModuleInfo{{ID: "some.ext", Version: "1.0.0"}}
+			newInfo.OrderExtensions = pubbldr.DetectionOrder{
+				{GroupDetectionOrder: pubbldr.DetectionOrder{
+					{ModuleRef: dist.ModuleRef{ModuleInfo: dist.ModuleInfo{ID: "some.ext", Version: "1.0.0"}}},
+				}},
+			}
+
+			diff := DiffBuilders(oldInfo, newInfo)
+
+			assert.Equal(diff.Extensions, ModuleDiff{Added: []dist.ModuleInfo{{ID: "some.ext", Version: "1.0.0"}}})
+			assert.Equal(diff.OrderExtensions, []OrderGroupDiff{{Group: 1, New: []string{"some.ext@1.0.0"}}})
+		})
+	})
+}
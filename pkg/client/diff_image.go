@@ -0,0 +1,397 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/buildpacks/imgutil"
+	"github.com/buildpacks/imgutil/layout"
+	"github.com/buildpacks/lifecycle/buildpack"
+	"github.com/buildpacks/lifecycle/launch"
+	"github.com/buildpacks/lifecycle/platform"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/sbom"
+)
+
+// ImageDiff describes what changed between two app images, as returned by DiffImages.
+type ImageDiff struct {
+	Buildpacks ModuleDiff    `json:"buildpacks" yaml:"buildpacks" toml:"buildpacks"`
+	Extensions ModuleDiff    `json:"extensions" yaml:"extensions" toml:"extensions"`
+	RunImage   *RunImageDiff `json:"run_image,omitempty" yaml:"run_image,omitempty" toml:"run_image,omitempty"`
+	Processes  ProcessDiff   `json:"processes" yaml:"processes" toml:"processes"`
+	BOM        PackageDiff   `json:"bom" yaml:"bom" toml:"bom"`
+	// SBOM records the changes of the packages listed in the SBOM layers of the images, as by DiffSBOM.
+	SBOM   PackageDiff `json:"sbom" yaml:"sbom" toml:"sbom"`
+	Layers LayerDiff   `json:"layers" yaml:"layers" toml:"layers"`
+}
+
+// RunImageDiff describes a change of run image between two app images. Rebased is true when the app layers of
+// both images are the same, as after `pack rebase`, and false when the app was rebuilt on the new run image.
+type RunImageDiff struct {
+	Image     *ValueChange `json:"image,omitempty" yaml:"image,omitempty" toml:"image,omitempty"`
+	Reference *ValueChange `json:"reference,omitempty" yaml:"reference,omitempty" toml:"reference,omitempty"`
+	TopLayer  *ValueChange `json:"top_layer,omitempty" yaml:"top_layer,omitempty" toml:"top_layer,omitempty"`
+	Rebased   bool         `json:"rebased" yaml:"rebased" toml:"rebased"`
+}
+
+// ProcessDiff records the process types added to or removed from an app image, those whose command changed, and
+// a change of default process.
+type ProcessDiff struct {
+	Default *ValueChange    `json:"default,omitempty" yaml:"default,omitempty" toml:"default,omitempty"`
+	Added   []string        `json:"added,omitempty" yaml:"added,omitempty" toml:"added,omitempty"`
+	Removed []string        `json:"removed,omitempty" yaml:"removed,omitempty" toml:"removed,omitempty"`
+	Changed []ProcessChange `json:"changed,omitempty" yaml:"changed,omitempty" toml:"changed,omitempty"`
+}
+
+// ProcessChange records the commands, including their arguments, of a process type in two app images.
+type ProcessChange struct {
+	Type       string `json:"type" yaml:"type" toml:"type"`
+	OldCommand string `json:"old_command" yaml:"old_command" toml:"old_command"`
+	NewCommand string `json:"new_command" yaml:"new_command" toml:"new_command"`
+}
+
+// PackageDiff records the packages added to or removed from an image, and those whose version changed.
+// A package is only reported as changed when both images contain a single version of it.
+type PackageDiff struct {
+	Added   []Package       `json:"added,omitempty" yaml:"added,omitempty" toml:"added,omitempty"`
+	Removed []Package       `json:"removed,omitempty" yaml:"removed,omitempty" toml:"removed,omitempty"`
+	Changed []PackageChange `json:"changed,omitempty" yaml:"changed,omitempty" toml:"changed,omitempty"`
+}
+
+// Package is an entry of a bill of materials.
+type Package struct {
+	Name    string `json:"name" yaml:"name" toml:"name"`
+	Version string `json:"version,omitempty" yaml:"version,omitempty" toml:"version,omitempty"`
+}
+
+// PackageChange records the versions of a package in two images.
+type PackageChange struct {
+	Name       string `json:"name" yaml:"name" toml:"name"`
+	OldVersion string `json:"old_version" yaml:"old_version" toml:"old_version"`
+	NewVersion string `json:"new_version" yaml:"new_version" toml:"new_version"`
+}
+
+// LayerDiff records the diffIDs of the layers only present in one of two app images, split between the layers
+// that belong to the run image and those added by the build.
+type LayerDiff struct {
+	RunImage ListDiff `json:"run_image" yaml:"run_image" toml:"run_image"`
+	App      ListDiff `json:"app" yaml:"app" toml:"app"`
+}
+
+// Empty reports whether no differences were found.
+func (d ProcessDiff) Empty() bool {
+	return d.Default == nil && len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Empty reports whether no differences were found.
+func (d PackageDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Empty reports whether no differences were found.
+func (d LayerDiff) Empty() bool {
+	return d.RunImage.Empty() && d.App.Empty()
+}
+
+// Empty reports whether no differences were found.
+func (d ImageDiff) Empty() bool {
+	return d.Buildpacks.Empty() && d.Extensions.Empty() && d.RunImage == nil &&
+		d.Processes.Empty() && d.BOM.Empty() && d.SBOM.Empty() && d.Layers.Empty()
+}
+
+// DiffImages compares two app images built by Cloud Native Buildpacks. Each image is looked up in the daemon
+// first, then in the registry; names prefixed with `oci:` refer to an image in an OCI layout on disk.
+func (c *Client) DiffImages(ctx context.Context, oldName, newName string) (*ImageDiff, error) {
+	oldImage, err := c.fetchImageToDiff(ctx, oldName)
+	if err != nil {
+		return nil, err
+	}
+	newImage, err := c.fetchImageToDiff(ctx, newName)
+	if err != nil {
+		return nil, err
+	}
+
+	oldInfo, err := imageInfo(oldImage)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading metadata of image %s", style.Symbol(oldName))
+	}
+	newInfo, err := imageInfo(newImage)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading metadata of image %s", style.Symbol(newName))
+	}
+
+	oldLayers, err := c.layerDiffIDs(ctx, oldImage)
+	if err != nil {
+		return nil, err
+	}
+	newLayers, err := c.layerDiffIDs(ctx, newImage)
+	if err != nil {
+		return nil, err
+	}
+
+	var oldSBOM, newSBOM sbomMetadata
+	if _, err := dist.GetLabel(oldImage, platform.LifecycleMetadataLabel, &oldSBOM); err != nil {
+		return nil, err
+	}
+	if _, err := dist.GetLabel(newImage, platform.LifecycleMetadataLabel, &newSBOM); err != nil {
+		return nil, err
+	}
+	sbomDiff, err := diffSBOMLayers(oldImage, oldSBOM, newImage, newSBOM)
+	if err != nil {
+		return nil, err
+	}
+
+	oldRunLayers, oldAppLayers := splitLayers(oldLayers, oldInfo.Base.TopLayer)
+	newRunLayers, newAppLayers := splitLayers(newLayers, newInfo.Base.TopLayer)
+	diff := &ImageDiff{
+		Buildpacks: diffModules(groupModules(oldInfo.Buildpacks), groupModules(newInfo.Buildpacks)),
+		Extensions: diffModules(groupModules(oldInfo.Extensions), groupModules(newInfo.Extensions)),
+		Processes:  diffProcesses(oldInfo.Processes, newInfo.Processes),
+		BOM:        diffPackages(bomPackages(oldInfo.BOM), bomPackages(newInfo.BOM)),
+		SBOM:       sbomDiff,
+		Layers: LayerDiff{
+			RunImage: diffList(oldRunLayers, newRunLayers),
+			App:      diffList(oldAppLayers, newAppLayers),
+		},
+	}
+
+	runImage := RunImageDiff{
+		Image:     diffValue(oldInfo.Base.Image, newInfo.Base.Image),
+		Reference: diffValue(oldInfo.Base.Reference, newInfo.Base.Reference),
+		TopLayer:  diffValue(oldInfo.Base.TopLayer, newInfo.Base.TopLayer),
+		Rebased:   diff.Layers.App.Empty(),
+	}
+	if runImage.Image != nil || runImage.Reference != nil || runImage.TopLayer != nil {
+		diff.RunImage = &runImage
+	}
+
+	return diff, nil
+}
+
+func (c *Client) fetchImageToDiff(ctx context.Context, name string) (imgutil.Image, error) {
+	if ref := ParseInputImageReference(name); ref.Layout() {
+		path, err := ref.FullName()
+		if err != nil {
+			return nil, err
+		}
+		img, err := layout.NewImage(path, layout.FromBaseImagePath(path))
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading image %s", style.Symbol(name))
+		}
+		if !img.Found() {
+			return nil, errors.Errorf("unable to find image %s in OCI layout", style.Symbol(name))
+		}
+		return img, nil
+	}
+
+	img, err := c.imageFetcher.Fetch(ctx, name, image.FetchOptions{Daemon: true, PullPolicy: image.PullNever})
+	if err == nil || !errors.Is(err, image.ErrNotFound) {
+		return img, err
+	}
+
+	img, err = c.imageFetcher.Fetch(ctx, name, image.FetchOptions{Daemon: false, PullPolicy: image.PullNever})
+	if errors.Is(err, image.ErrNotFound) {
+		return nil, errors.Errorf("unable to find image %s locally or remotely", style.Symbol(name))
+	}
+	return img, err
+}
+
+// layerDiffIDs lists the layers of an image. Daemon images don't expose their underlying image, so their layers
+// are read from the daemon.
+func (c *Client) layerDiffIDs(ctx context.Context, img imgutil.Image) ([]string, error) {
+	if v1Image := img.UnderlyingImage(); v1Image != nil {
+		configFile, err := v1Image.ConfigFile()
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading config of image %s", style.Symbol(img.Name()))
+		}
+		var diffIDs []string
+		for _, diffID := range configFile.RootFS.DiffIDs {
+			diffIDs = append(diffIDs, diffID.String())
+		}
+		return diffIDs, nil
+	}
+
+	inspect, _, err := c.docker.ImageInspectWithRaw(ctx, img.Name())
+	if err != nil {
+		return nil, errors.Wrapf(err, "inspecting image %s", style.Symbol(img.Name()))
+	}
+	return inspect.RootFS.Layers, nil
+}
+
+// splitLayers splits the layers of an app image into those of the run image, up to and including topLayer, and
+// those added by the build. All layers are considered app layers when topLayer can't be found.
+func splitLayers(layers []string, topLayer string) (runImageLayers, appLayers []string) {
+	for i, layer := range layers {
+		if layer == topLayer {
+			return layers[:i+1], layers[i+1:]
+		}
+	}
+	return nil, layers
+}
+
+func groupModules(group []buildpack.GroupElement) []dist.ModuleInfo {
+	var modules []dist.ModuleInfo
+	for _, element := range group {
+		modules = append(modules, dist.ModuleInfo{ID: element.ID, Version: element.Version, Homepage: element.Homepage})
+	}
+	return modules
+}
+
+// diffSBOMLayers compares the packages in the SBOM layers of two images. The layers are only read when they differ.
+func diffSBOMLayers(oldImage imgutil.Image, oldMD sbomMetadata, newImage imgutil.Image, newMD sbomMetadata) (PackageDiff, error) {
+	if sbomLayer(oldMD) == sbomLayer(newMD) {
+		return PackageDiff{}, nil
+	}
+
+	oldPackages, err := sbomLayerPackages(oldImage, oldMD)
+	if err != nil {
+		return PackageDiff{}, err
+	}
+	newPackages, err := sbomLayerPackages(newImage, newMD)
+	if err != nil {
+		return PackageDiff{}, err
+	}
+	return diffPackages(sbomPackages(oldPackages), sbomPackages(newPackages)), nil
+}
+
+// sbomLayerPackages lists the packages in the SBOM layer of an image, if any
+func sbomLayerPackages(img imgutil.Image, md sbomMetadata) ([]sbom.Package, error) {
+	if md.isMissing() {
+		return nil, nil
+	}
+
+	rc, err := img.GetLayer(md.BOM.SHA)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading SBOM layer of image %s", style.Symbol(img.Name()))
+	}
+	defer rc.Close()
+
+	packages, err := sbom.Read(rc)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading SBOM of image %s", style.Symbol(img.Name()))
+	}
+	return packages, nil
+}
+
+func sbomLayer(md sbomMetadata) string {
+	if md.isMissing() {
+		return ""
+	}
+	return md.BOM.SHA
+}
+
+func diffProcesses(oldDetails, newDetails ProcessDetails) ProcessDiff {
+	oldProcesses, newProcesses := processCommands(oldDetails), processCommands(newDetails)
+
+	var diff ProcessDiff
+	diff.Default = diffValue(defaultProcessType(oldDetails), defaultProcessType(newDetails))
+	for _, processType := range sortedKeys(newProcesses) {
+		oldCommand, ok := oldProcesses[processType]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, processType)
+		case oldCommand != newProcesses[processType]:
+			diff.Changed = append(diff.Changed, ProcessChange{Type: processType, OldCommand: oldCommand, NewCommand: newProcesses[processType]})
+		}
+	}
+	for _, processType := range sortedKeys(oldProcesses) {
+		if _, ok := newProcesses[processType]; !ok {
+			diff.Removed = append(diff.Removed, processType)
+		}
+	}
+	return diff
+}
+
+func processCommands(details ProcessDetails) map[string]string {
+	processes := details.OtherProcesses
+	if details.DefaultProcess != nil {
+		processes = append([]launch.Process{*details.DefaultProcess}, processes...)
+	}
+
+	commands := map[string]string{}
+	for _, process := range processes {
+		commands[process.Type] = strings.Join(append(append([]string{}, process.Command.Entries...), process.Args...), " ")
+	}
+	return commands
+}
+
+func defaultProcessType(details ProcessDetails) string {
+	if details.DefaultProcess == nil {
+		return ""
+	}
+	return details.DefaultProcess.Type
+}
+
+func bomPackages(bom []buildpack.BOMEntry) []Package {
+	var packages []Package
+	for _, entry := range bom {
+		version := entry.Version
+		if version == "" {
+			if metadataVersion, ok := entry.Metadata["version"]; ok {
+				version = fmt.Sprintf("%v", metadataVersion)
+			}
+		}
+		packages = append(packages, Package{Name: entry.Name, Version: version})
+	}
+	return packages
+}
+
+func diffPackages(oldPackages, newPackages []Package) PackageDiff {
+	oldByName, newByName := packagesByName(oldPackages), packagesByName(newPackages)
+
+	names := sortedKeys(newByName)
+	for _, name := range sortedKeys(oldByName) {
+		if _, ok := newByName[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var diff PackageDiff
+	for _, name := range names {
+		oldVersions, newVersions := oldByName[name], newByName[name]
+		if len(oldVersions) == 1 && len(newVersions) == 1 {
+			if oldVersions[0] != newVersions[0] {
+				diff.Changed = append(diff.Changed, PackageChange{Name: name, OldVersion: oldVersions[0], NewVersion: newVersions[0]})
+			}
+			continue
+		}
+
+		for _, version := range newVersions {
+			if !contains(oldVersions, version) {
+				diff.Added = append(diff.Added, Package{Name: name, Version: version})
+			}
+		}
+		for _, version := range oldVersions {
+			if !contains(newVersions, version) {
+				diff.Removed = append(diff.Removed, Package{Name: name, Version: version})
+			}
+		}
+	}
+	return diff
+}
+
+func packagesByName(packages []Package) map[string][]string {
+	byName := map[string][]string{}
+	for _, pkg := range packages {
+		if !contains(byName[pkg.Name], pkg.Version) {
+			byName[pkg.Name] = append(byName[pkg.Name], pkg.Version)
+		}
+	}
+	return byName
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
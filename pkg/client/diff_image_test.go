@@ -0,0 +1,275 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/buildpacks/imgutil"
+	"github.com/buildpacks/imgutil/layout"
+	"github.com/docker/docker/api/types"
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestDiffImages(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "DiffImages", testDiffImages, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testDiffImages(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		mockImageFetcher *testmocks.MockImageFetcher
+		mockDockerClient *testmocks.MockCommonAPIClient
+		mockController   *gomock.Controller
+		oldImage         *testmocks.MockImage
+		newImage         *testmocks.MockImage
+		out              bytes.Buffer
+		assert           = h.NewAssertionManager(t)
+	)
+
+	const oldBuildMetadata = `{
+  "bom": [
+    {"name": "openssl", "version": "3.0.1"},
+    {"name": "zlib", "metadata": {"version": "1.2.11"}}
+  ],
+  "buildpacks": [
+    {"id": "some-buildpack", "version": "1.0.0"},
+    {"id": "other-buildpack", "version": "2.0.0"}
+  ],
+  "processes": [
+    {"type": "web", "command": "/start/web", "args": ["-p", "8080"], "direct": true},
+    {"type": "worker", "command": "/start/worker", "direct": true}
+  ],
+  "launcher": {"version": "0.5.0"}
+}`
+
+	setMetadata := func(img imgutil.Image, topLayer, reference, sbomLayer, buildMetadata string) {
+		h.AssertNil(t, img.SetLabel("io.buildpacks.stack.id", "some.stack.id"))
+		h.AssertNil(t, img.SetLabel("io.buildpacks.lifecycle.metadata", fmt.Sprintf(`{
+  "runImage": {"topLayer": %q, "reference": %q, "image": "some/run-image"},
+  "sbom": {"sha": %q}
+}`, topLayer, reference, sbomLayer)))
+		h.AssertNil(t, img.SetLabel("io.buildpacks.build.metadata", buildMetadata))
+	}
+
+	expectLayers := func(name string, layers ...string) {
+		mockDockerClient.EXPECT().
+			ImageInspectWithRaw(gomock.Any(), name).
+			Return(types.ImageInspect{RootFS: types.RootFS{Layers: layers}}, nil, nil).
+			AnyTimes()
+	}
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockImageFetcher = testmocks.NewMockImageFetcher(mockController)
+		mockDockerClient = testmocks.NewMockCommonAPIClient(mockController)
+
+		var err error
+		subject, err = NewClient(WithLogger(logging.NewLogWithWriters(&out, &out)), WithFetcher(mockImageFetcher), WithDockerClient(mockDockerClient))
+		h.AssertNil(t, err)
+
+		oldImage = testmocks.NewImage("some/app:v1", "", nil)
+		setMetadata(oldImage, "sha256:run-2", "some/run-image@sha256:aaa", "sha256:sbom-1", oldBuildMetadata)
+		expectLayers("some/app:v1", "sha256:run-1", "sha256:run-2", "sha256:app-1", "sha256:app-2")
+
+		newImage = testmocks.NewImage("some/app:v2", "", nil)
+
+		mockImageFetcher.EXPECT().
+			Fetch(gomock.Any(), "some/app:v1", image.FetchOptions{Daemon: true, PullPolicy: image.PullNever}).
+			Return(oldImage, nil).
+			AnyTimes()
+		mockImageFetcher.EXPECT().
+			Fetch(gomock.Any(), "some/app:v2", image.FetchOptions{Daemon: true, PullPolicy: image.PullNever}).
+			Return(newImage, nil).
+			AnyTimes()
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("the images are the same", func() {
+		it("reports no differences", func() {
+			setMetadata(newImage, "sha256:run-2", "some/run-image@sha256:aaa", "sha256:sbom-1", oldBuildMetadata)
+			expectLayers("some/app:v2", "sha256:run-1", "sha256:run-2", "sha256:app-1", "sha256:app-2")
+
+			diff, err := subject.DiffImages(context.TODO(), "some/app:v1", "some/app:v2")
+			assert.Nil(err)
+
+			assert.Equal(diff.Empty(), true)
+		})
+	})
+
+	when("the image was rebased", func() {
+		it("reports the run image change as a rebase", func() {
+			setMetadata(newImage, "sha256:run-3", "some/run-image@sha256:bbb", "sha256:sbom-1", oldBuildMetadata)
+			expectLayers("some/app:v2", "sha256:run-1", "sha256:run-3", "sha256:app-1", "sha256:app-2")
+
+			diff, err := subject.DiffImages(context.TODO(), "some/app:v1", "some/app:v2")
+			assert.Nil(err)
+
+			assert.Equal(diff.RunImage, &RunImageDiff{
+				Reference: &ValueChange{Old: "some/run-image@sha256:aaa", New: "some/run-image@sha256:bbb"},
+				TopLayer:  &ValueChange{Old: "sha256:run-2", New: "sha256:run-3"},
+				Rebased:   true,
+			})
+			assert.Equal(diff.Layers, LayerDiff{
+				RunImage: ListDiff{Added: []string{"sha256:run-3"}, Removed: []string{"sha256:run-2"}},
+			})
+		})
+	})
+
+	when("the image was rebuilt", func() {
+		it.Before(func() {
+			setMetadata(newImage, "sha256:run-3", "some/run-image@sha256:bbb", "sha256:sbom-2", `{
+  "bom": [
+    {"name": "openssl", "version": "3.0.2"},
+    {"name": "curl", "version": "8.0.0"}
+  ],
+  "buildpacks": [
+    {"id": "some-buildpack", "version": "1.1.0"},
+    {"id": "new-buildpack", "version": "0.1.0"}
+  ],
+  "processes": [
+    {"type": "web", "command": "/start/web", "args": ["-p", "9090"], "direct": true},
+    {"type": "cron", "command": "/start/cron", "direct": true}
+  ],
+  "launcher": {"version": "0.5.0"}
+}`)
+			expectLayers("some/app:v2", "sha256:run-1", "sha256:run-3", "sha256:app-1", "sha256:app-3")
+
+			h.AssertNil(t, oldImage.AddLayerWithDiffID(sbomLayerFile(t, map[string]string{
+				"/layers/sbom/launch/some-buildpack/sbom.syft.json": `{"artifacts": [{"name": "openssl", "version": "3.0.1"}, {"name": "zlib", "version": "1.2.11"}]}`,
+			}), "sha256:sbom-1"))
+			h.AssertNil(t, newImage.AddLayerWithDiffID(sbomLayerFile(t, map[string]string{
+				"/layers/sbom/launch/some-buildpack/sbom.syft.json": `{"artifacts": [{"name": "openssl", "version": "3.0.2"}, {"name": "curl", "version": "8.0.0"}]}`,
+			}), "sha256:sbom-2"))
+		})
+
+		it("reports the run image change as a rebuild", func() {
+			diff, err := subject.DiffImages(context.TODO(), "some/app:v1", "some/app:v2")
+			assert.Nil(err)
+
+			assert.Equal(diff.RunImage.Rebased, false)
+			assert.Equal(diff.Layers.App, ListDiff{Added: []string{"sha256:app-3"}, Removed: []string{"sha256:app-2"}})
+		})
+
+		it("reports buildpack changes", func() {
+			diff, err := subject.DiffImages(context.TODO(), "some/app:v1", "some/app:v2")
+			assert.Nil(err)
+
+			assert.Equal(diff.Buildpacks, ModuleDiff{
+				Added:   []dist.ModuleInfo{{ID: "new-buildpack", Version: "0.1.0"}},
+				Removed: []dist.ModuleInfo{{ID: "other-buildpack", Version: "2.0.0"}},
+				Changed: []ModuleChange{{ID: "some-buildpack", OldVersion: "1.0.0", NewVersion: "1.1.0"}},
+			})
+		})
+
+		it("reports process changes", func() {
+			diff, err := subject.DiffImages(context.TODO(), "some/app:v1", "some/app:v2")
+			assert.Nil(err)
+
+			assert.Equal(diff.Processes, ProcessDiff{
+				Added:   []string{"cron"},
+				Removed: []string{"worker"},
+				Changed: []ProcessChange{{Type: "web", OldCommand: "/start/web -p 8080", NewCommand: "/start/web -p 9090"}},
+			})
+		})
+
+		it("reports BOM and SBOM changes", func() {
+			diff, err := subject.DiffImages(context.TODO(), "some/app:v1", "some/app:v2")
+			assert.Nil(err)
+
+			assert.Equal(diff.BOM, PackageDiff{
+				Added:   []Package{{Name: "curl", Version: "8.0.0"}},
+				Removed: []Package{{Name: "zlib", Version: "1.2.11"}},
+				Changed: []PackageChange{{Name: "openssl", OldVersion: "3.0.1", NewVersion: "3.0.2"}},
+			})
+			assert.Equal(diff.SBOM, PackageDiff{
+				Added:   []Package{{Name: "curl", Version: "8.0.0"}},
+				Removed: []Package{{Name: "zlib", Version: "1.2.11"}},
+				Changed: []PackageChange{{Name: "openssl", OldVersion: "3.0.1", NewVersion: "3.0.2"}},
+			})
+		})
+
+		when("the SBOM layer cannot be read", func() {
+			it("returns an error", func() {
+				setMetadata(newImage, "sha256:run-3", "some/run-image@sha256:bbb", "sha256:missing-sbom", oldBuildMetadata)
+
+				_, err := subject.DiffImages(context.TODO(), "some/app:v1", "some/app:v2")
+				assert.ErrorContains(err, "reading SBOM layer of image 'some/app:v2'")
+			})
+		})
+	})
+
+	when("an image is not in the daemon", func() {
+		it("looks for it in the registry", func() {
+			remoteImage := testmocks.NewImage("some/app:remote", "", nil)
+			setMetadata(remoteImage, "sha256:run-2", "some/run-image@sha256:aaa", "sha256:sbom-1", oldBuildMetadata)
+			expectLayers("some/app:remote", "sha256:run-1", "sha256:run-2", "sha256:app-1", "sha256:app-2")
+			mockImageFetcher.EXPECT().
+				Fetch(gomock.Any(), "some/app:remote", image.FetchOptions{Daemon: true, PullPolicy: image.PullNever}).
+				Return(nil, errors.Wrap(image.ErrNotFound, "some-error"))
+			mockImageFetcher.EXPECT().
+				Fetch(gomock.Any(), "some/app:remote", image.FetchOptions{Daemon: false, PullPolicy: image.PullNever}).
+				Return(remoteImage, nil)
+
+			diff, err := subject.DiffImages(context.TODO(), "some/app:v1", "some/app:remote")
+			assert.Nil(err)
+
+			assert.Equal(diff.Empty(), true)
+		})
+
+		when("the image is not in the registry either", func() {
+			it("returns an error", func() {
+				mockImageFetcher.EXPECT().
+					Fetch(gomock.Any(), "some/missing", gomock.Any()).
+					Return(nil, errors.Wrap(image.ErrNotFound, "some-error")).
+					Times(2)
+
+				_, err := subject.DiffImages(context.TODO(), "some/app:v1", "some/missing")
+				assert.ErrorWithMessage(err, "unable to find image 'some/missing' locally or remotely")
+			})
+		})
+	})
+
+	when("the images are in OCI layouts", func() {
+		it("reads their layers from the layouts", func() {
+			oldPath, newPath := filepath.Join(t.TempDir(), "old"), filepath.Join(t.TempDir(), "new")
+			for _, path := range []string{oldPath, newPath} {
+				img, err := layout.NewImage(path)
+				assert.Nil(err)
+				setMetadata(img, "", "", "sha256:sbom-1", oldBuildMetadata)
+				assert.Nil(img.Save())
+			}
+
+			diff, err := subject.DiffImages(context.TODO(), "oci:"+oldPath, "oci:"+newPath)
+			assert.Nil(err)
+
+			assert.Equal(diff.Empty(), true)
+		})
+
+		when("the layout does not exist", func() {
+			it("returns an error", func() {
+				missingPath := filepath.Join(t.TempDir(), "missing")
+
+				_, err := subject.DiffImages(context.TODO(), "oci:"+missingPath, "some/app:v1")
+				assert.ErrorWithMessage(err, fmt.Sprintf("unable to find image 'oci:%s' in OCI layout", missingPath))
+			})
+		})
+	})
+}
@@ -0,0 +1,33 @@
+package client
+
+import (
+	"github.com/buildpacks/pack/pkg/sbom"
+)
+
+type DiffSBOMOptions struct {
+	Daemon bool
+}
+
+// DiffSBOM compares the packages in the SBOM layers of two images, as listed by ListSBOM.
+// Packages are compared by name, whichever buildpack contributed them.
+func (c *Client) DiffSBOM(oldName, newName string, options DiffSBOMOptions) (*PackageDiff, error) {
+	oldPackages, err := c.ListSBOM(oldName, ListSBOMOptions{Daemon: options.Daemon})
+	if err != nil {
+		return nil, err
+	}
+	newPackages, err := c.ListSBOM(newName, ListSBOMOptions{Daemon: options.Daemon})
+	if err != nil {
+		return nil, err
+	}
+
+	diff := diffPackages(sbomPackages(oldPackages), sbomPackages(newPackages))
+	return &diff, nil
+}
+
+func sbomPackages(packages []sbom.Package) []Package {
+	var result []Package
+	for _, pkg := range packages {
+		result = append(result, Package{Name: pkg.Name, Version: pkg.Version})
+	}
+	return result
+}
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestDiffSBOM(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "DiffSBOM", testDiffSBOM, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testDiffSBOM(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		mockImageFetcher *testmocks.MockImageFetcher
+		mockController   *gomock.Controller
+		out              bytes.Buffer
+		assert           = h.NewAssertionManager(t)
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockImageFetcher = testmocks.NewMockImageFetcher(mockController)
+
+		var err error
+		subject, err = NewClient(WithLogger(logging.NewLogWithWriters(&out, &out)), WithFetcher(mockImageFetcher))
+		assert.Nil(err)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	it("compares the packages of both images, whatever the SBOM format", func() {
+		oldImage := imageWithSBOM(t, "some/app:v1", map[string]string{
+			"/layers/sbom/launch/some-buildpack/sbom.syft.json": `{"artifacts": [
+  {"name": "openssl", "version": "3.0.1"},
+  {"name": "zlib", "version": "1.2.11"}
+]}`,
+		})
+		newImage := imageWithSBOM(t, "some/app:v2", map[string]string{
+			"/layers/sbom/launch/some-buildpack/sbom.cdx.json": `{"components": [
+  {"name": "openssl", "version": "3.0.2"},
+  {"name": "curl", "version": "8.0.0"}
+]}`,
+		})
+		mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/app:v1", image.FetchOptions{Daemon: true, PullPolicy: image.PullNever}).Return(oldImage, nil)
+		mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/app:v2", image.FetchOptions{Daemon: true, PullPolicy: image.PullNever}).Return(newImage, nil)
+
+		diff, err := subject.DiffSBOM("some/app:v1", "some/app:v2", DiffSBOMOptions{Daemon: true})
+		assert.Nil(err)
+
+		assert.Equal(diff, &PackageDiff{
+			Added:   []Package{{Name: "curl", Version: "8.0.0"}},
+			Removed: []Package{{Name: "zlib", Version: "1.2.11"}},
+			Changed: []PackageChange{{Name: "openssl", OldVersion: "3.0.1", NewVersion: "3.0.2"}},
+		})
+	})
+}
@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"io"
 
 	"github.com/buildpacks/lifecycle/layers"
 	"github.com/buildpacks/lifecycle/platform"
@@ -37,29 +38,36 @@ const (
 // It reads the SBOM metadata of an image then
 // pulls the corresponding diffId, if it exists
 func (c *Client) DownloadSBOM(name string, options DownloadSBOMOptions) error {
-	img, err := c.imageFetcher.Fetch(context.Background(), name, image.FetchOptions{Daemon: options.Daemon, PullPolicy: image.PullNever})
+	rc, err := c.sbomLayer(name, options.Daemon)
 	if err != nil {
 		if errors.Cause(err) == image.ErrNotFound {
 			c.logger.Warnf("if the image is saved on a registry run with the flag '--remote', for example: 'pack sbom download --remote %s'", name)
-			return errors.Wrapf(image.ErrNotFound, "image '%s' cannot be found", name)
 		}
 		return err
 	}
+	defer rc.Close()
+
+	return layers.Extract(rc, options.DestinationDir)
+}
+
+// sbomLayer returns the uncompressed contents of the SBOM layer of an image
+func (c *Client) sbomLayer(name string, daemon bool) (io.ReadCloser, error) {
+	img, err := c.imageFetcher.Fetch(context.Background(), name, image.FetchOptions{Daemon: daemon, PullPolicy: image.PullNever})
+	if err != nil {
+		if errors.Cause(err) == image.ErrNotFound {
+			return nil, errors.Wrapf(image.ErrNotFound, "image '%s' cannot be found", name)
+		}
+		return nil, err
+	}
 
 	var sbomMD sbomMetadata
 	if _, err := dist.GetLabel(img, platform.LifecycleMetadataLabel, &sbomMD); err != nil {
-		return err
+		return nil, err
 	}
 
 	if sbomMD.isMissing() {
-		return errors.Errorf("could not find SBoM information on '%s'", name)
-	}
-
-	rc, err := img.GetLayer(sbomMD.BOM.SHA)
-	if err != nil {
-		return err
+		return nil, errors.Errorf("could not find SBoM information on '%s'", name)
 	}
-	defer rc.Close()
 
-	return layers.Extract(rc, options.DestinationDir)
+	return img.GetLayer(sbomMD.BOM.SHA)
 }
@@ -67,7 +67,7 @@ func (c *Client) InspectBuildpack(opts InspectBuildpackOptions) (*BuildpackInfo,
 }
 
 func metadataFromRegistry(client *Client, name, registry string) (buildpackMd buildpack.Metadata, layersMd dist.ModuleLayers, err error) {
-	registryCache, err := getRegistry(client.logger, registry)
+	registryCache, err := getRegistryIndex(client.logger, client.keychain, registry)
 	if err != nil {
 		return buildpack.Metadata{}, dist.ModuleLayers{}, fmt.Errorf("invalid registry %s: %q", registry, err)
 	}
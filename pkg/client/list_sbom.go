@@ -0,0 +1,21 @@
+package client
+
+import (
+	"github.com/buildpacks/pack/pkg/sbom"
+)
+
+type ListSBOMOptions struct {
+	Daemon bool
+}
+
+// ListSBOM lists the packages in the SBOM layer of an image, as parsed from the
+// CycloneDX, SPDX and Syft files contributed by buildpacks.
+func (c *Client) ListSBOM(name string, options ListSBOMOptions) ([]sbom.Package, error) {
+	rc, err := c.sbomLayer(name, options.Daemon)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	return sbom.Read(rc)
+}
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/sbom"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestListSBOM(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ListSBOM", testListSBOM, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testListSBOM(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		mockImageFetcher *testmocks.MockImageFetcher
+		mockController   *gomock.Controller
+		out              bytes.Buffer
+		assert           = h.NewAssertionManager(t)
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockImageFetcher = testmocks.NewMockImageFetcher(mockController)
+
+		var err error
+		subject, err = NewClient(WithLogger(logging.NewLogWithWriters(&out, &out)), WithFetcher(mockImageFetcher))
+		assert.Nil(err)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	it("lists the packages in the SBOM layer", func() {
+		img := imageWithSBOM(t, "some/image", map[string]string{
+			"/layers/sbom/launch/some-buildpack/sbom.syft.json": `{"artifacts": [{"name": "some-package", "version": "1.0.0", "licenses": ["MIT"]}]}`,
+		})
+		mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/image", image.FetchOptions{Daemon: false, PullPolicy: image.PullNever}).Return(img, nil)
+
+		packages, err := subject.ListSBOM("some/image", ListSBOMOptions{Daemon: false})
+		assert.Nil(err)
+
+		assert.Equal(packages, []sbom.Package{
+			{Name: "some-package", Version: "1.0.0", Licenses: []string{"MIT"}, Buildpack: "some-buildpack", Scope: "launch", Format: sbom.Syft},
+		})
+	})
+
+	when("the image does not have an SBOM", func() {
+		it("returns an error", func() {
+			mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/image", gomock.Any()).Return(testmocks.NewImage("some/image", "", nil), nil)
+
+			_, err := subject.ListSBOM("some/image", ListSBOMOptions{Daemon: true})
+			assert.ErrorWithMessage(err, "could not find SBoM information on 'some/image'")
+		})
+	})
+
+	when("the image cannot be found", func() {
+		it("returns an error", func() {
+			mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/image", gomock.Any()).Return(nil, errors.Wrap(image.ErrNotFound, "some-error"))
+
+			_, err := subject.ListSBOM("some/image", ListSBOMOptions{Daemon: true})
+			assert.ErrorWithMessage(err, "image 'some/image' cannot be found: not found")
+		})
+	})
+}
+
+// imageWithSBOM returns an image whose SBOM layer contains files
+func imageWithSBOM(t *testing.T, name string, files map[string]string) *testmocks.MockImage {
+	img := testmocks.NewImage(name, "", nil)
+	h.AssertNil(t, img.AddLayerWithDiffID(sbomLayerFile(t, files), "sha256:some-sbom-layer"))
+	h.AssertNil(t, img.SetLabel("io.buildpacks.lifecycle.metadata", `{"sbom": {"sha": "sha256:some-sbom-layer"}}`))
+	return img
+}
+
+// sbomLayerFile writes an SBOM layer holding files, keyed by path
+func sbomLayerFile(t *testing.T, files map[string]string) string {
+	layerPath := filepath.Join(t.TempDir(), "sbom.tar")
+	f, err := os.Create(layerPath)
+	h.AssertNil(t, err)
+	tw := tar.NewWriter(f)
+	for fileName, contents := range files {
+		h.AssertNil(t, tw.WriteHeader(&tar.Header{Name: fileName, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(contents))}))
+		_, err := tw.Write([]byte(contents))
+		h.AssertNil(t, err)
+	}
+	h.AssertNil(t, tw.Close())
+	h.AssertNil(t, f.Close())
+	return layerPath
+}
@@ -0,0 +1,273 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/index"
+)
+
+// CreateManifestOptions defines configuration for creating an image index.
+type CreateManifestOptions struct {
+	// Name of the image index to create.
+	IndexRepoName string
+
+	// Images or image indexes whose manifests are added to the new index.
+	RepoNames []string
+
+	// Media type of the index, either 'oci' (default) or 'docker'.
+	Format string
+
+	// Allow the index and its manifests to use insecure registries.
+	Insecure bool
+
+	// Push the index to its registry once created.
+	Publish bool
+}
+
+// AddManifestOptions defines configuration for adding a manifest to an image index.
+type AddManifestOptions struct {
+	// Name of the local image index.
+	IndexRepoName string
+
+	// Image or image index whose manifests are added.
+	RepoName string
+
+	// When RepoName refers to an image index, only manifests matching these values are added.
+	OS, Arch, Variant string
+}
+
+// AnnotateManifestOptions defines configuration for annotating a manifest in an image index.
+type AnnotateManifestOptions struct {
+	// Name of the local image index.
+	IndexRepoName string
+
+	// Image, by tag or digest, whose manifest is annotated.
+	RepoName string
+
+	// Platform values to set on the manifest descriptor. Empty values are left unchanged.
+	OS, OSVersion, Arch, Variant string
+	Features                     []string
+
+	// Annotations to add to the manifest descriptor.
+	Annotations map[string]string
+}
+
+// RemoveManifestOptions defines configuration for removing a manifest from an image index.
+type RemoveManifestOptions struct {
+	// Name of the local image index.
+	IndexRepoName string
+
+	// Image, by tag or digest, whose manifest is removed.
+	RepoName string
+}
+
+// PushManifestOptions defines configuration for pushing an image index.
+type PushManifestOptions struct {
+	// Name of the local image index.
+	IndexRepoName string
+
+	// Media type to push the index as, 'oci' or 'docker'. Defaults to the format the index was created with.
+	Format string
+
+	// Allow pushing to an insecure registry.
+	Insecure bool
+
+	// Delete the local index once pushed.
+	Purge bool
+}
+
+// CreateManifest creates an image index in local storage containing the manifests of the given images.
+func (c *Client) CreateManifest(ctx context.Context, opts CreateManifestOptions) error {
+	format, err := parseIndexFormat(opts.Format)
+	if err != nil {
+		return err
+	}
+
+	idx, err := c.indexFactory.CreateIndex(opts.IndexRepoName, index.Options{Format: format, Insecure: opts.Insecure})
+	if err != nil {
+		return errors.Wrapf(err, "creating index %s", style.Symbol(opts.IndexRepoName))
+	}
+
+	for _, repoName := range opts.RepoNames {
+		if _, err := idx.Add(ctx, repoName, v1.Platform{}); err != nil {
+			return errors.Wrapf(err, "adding %s to index", style.Symbol(repoName))
+		}
+	}
+
+	if err := idx.Save(); err != nil {
+		return errors.Wrapf(err, "saving index %s", style.Symbol(opts.IndexRepoName))
+	}
+	c.logger.Infof("Successfully created manifest list %s", style.Symbol(opts.IndexRepoName))
+
+	if !opts.Publish {
+		return nil
+	}
+	return c.pushIndex(ctx, idx, false)
+}
+
+// AddManifest adds the manifest(s) of an image or image index to a local image index.
+func (c *Client) AddManifest(ctx context.Context, opts AddManifestOptions) error {
+	idx, err := c.indexFactory.LoadIndex(opts.IndexRepoName)
+	if err != nil {
+		return err
+	}
+
+	added, err := idx.Add(ctx, opts.RepoName, v1.Platform{OS: opts.OS, Architecture: opts.Arch, Variant: opts.Variant})
+	if err != nil {
+		return errors.Wrapf(err, "adding %s to index", style.Symbol(opts.RepoName))
+	}
+
+	if err := idx.Save(); err != nil {
+		return errors.Wrapf(err, "saving index %s", style.Symbol(opts.IndexRepoName))
+	}
+
+	for _, desc := range added {
+		c.logger.Infof("Successfully added manifest %s to %s", style.Symbol(desc.Digest.String()), style.Symbol(opts.IndexRepoName))
+	}
+	return nil
+}
+
+// AnnotateManifest sets platform values and annotations on a manifest of a local image index.
+func (c *Client) AnnotateManifest(ctx context.Context, opts AnnotateManifestOptions) error {
+	idx, err := c.indexFactory.LoadIndex(opts.IndexRepoName)
+	if err != nil {
+		return err
+	}
+
+	digest, err := idx.Digest(ctx, opts.RepoName)
+	if err != nil {
+		return err
+	}
+
+	if err := idx.Annotate(digest, index.Platform{
+		OS:          opts.OS,
+		Arch:        opts.Arch,
+		Variant:     opts.Variant,
+		OSVersion:   opts.OSVersion,
+		Features:    opts.Features,
+		Annotations: opts.Annotations,
+	}); err != nil {
+		return err
+	}
+
+	if err := idx.Save(); err != nil {
+		return errors.Wrapf(err, "saving index %s", style.Symbol(opts.IndexRepoName))
+	}
+	c.logger.Infof("Successfully annotated manifest %s in %s", style.Symbol(digest.String()), style.Symbol(opts.IndexRepoName))
+	return nil
+}
+
+// RemoveManifest removes a manifest from a local image index.
+func (c *Client) RemoveManifest(ctx context.Context, opts RemoveManifestOptions) error {
+	idx, err := c.indexFactory.LoadIndex(opts.IndexRepoName)
+	if err != nil {
+		return err
+	}
+
+	digest, err := idx.Digest(ctx, opts.RepoName)
+	if err != nil {
+		return err
+	}
+
+	if err := idx.Remove(digest); err != nil {
+		return err
+	}
+
+	if err := idx.Save(); err != nil {
+		return errors.Wrapf(err, "saving index %s", style.Symbol(opts.IndexRepoName))
+	}
+	c.logger.Infof("Successfully removed manifest %s from %s", style.Symbol(digest.String()), style.Symbol(opts.IndexRepoName))
+	return nil
+}
+
+// DeleteManifest deletes image indexes from local storage. Every index is attempted
+// and the errors encountered are combined.
+func (c *Client) DeleteManifest(indexRepoNames []string) error {
+	var failures []string
+	for _, repoName := range indexRepoNames {
+		idx, err := c.indexFactory.LoadIndex(repoName)
+		if err == nil {
+			err = idx.Delete()
+		}
+		if err != nil {
+			failures = append(failures, err.Error())
+			continue
+		}
+		c.logger.Infof("Successfully deleted manifest list %s from local storage", style.Symbol(repoName))
+	}
+
+	if len(failures) > 0 {
+		return errors.New(strings.Join(failures, "\n"))
+	}
+	return nil
+}
+
+// InspectManifest prints the image index from local storage or, when it is not found locally, from its registry.
+func (c *Client) InspectManifest(ctx context.Context, indexRepoName string) error {
+	idx, err := c.indexFactory.LoadIndex(indexRepoName)
+	if errors.Is(err, index.ErrNotFound) {
+		idx, err = c.indexFactory.FetchIndex(ctx, indexRepoName, false)
+	}
+	if err != nil {
+		return err
+	}
+
+	raw, err := idx.RawManifest()
+	if err != nil {
+		return err
+	}
+	c.logger.Info(string(raw))
+	return nil
+}
+
+// PushManifest pushes a local image index, and the manifests it references, to its registry.
+func (c *Client) PushManifest(ctx context.Context, opts PushManifestOptions) error {
+	idx, err := c.indexFactory.LoadIndex(opts.IndexRepoName)
+	if err != nil {
+		return err
+	}
+
+	if opts.Format != "" {
+		format, err := parseIndexFormat(opts.Format)
+		if err != nil {
+			return err
+		}
+		idx.SetMediaType(format)
+	}
+	if opts.Insecure {
+		idx.SetInsecure(true)
+	}
+
+	return c.pushIndex(ctx, idx, opts.Purge)
+}
+
+func (c *Client) pushIndex(ctx context.Context, idx *index.Index, purge bool) error {
+	digest, err := idx.Push(ctx)
+	if err != nil {
+		return err
+	}
+	c.logger.Infof("Successfully pushed manifest list %s@%s", style.Symbol(idx.Name()), digest.String())
+
+	if purge {
+		return idx.Delete()
+	}
+	return nil
+}
+
+func parseIndexFormat(format string) (types.MediaType, error) {
+	switch strings.ToLower(format) {
+	case "", "oci":
+		return types.OCIImageIndex, nil
+	case "docker", "v2s2":
+		return types.DockerManifestList, nil
+	default:
+		return "", fmt.Errorf("unsupported media type format %s, must be one of 'oci' or 'docker'", style.Symbol(format))
+	}
+}
@@ -0,0 +1,172 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/index"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestManifest(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "manifest", testManifest, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testManifest(t *testing.T, when spec.G, it spec.S) {
+	var (
+		server   *httptest.Server
+		host     string
+		tmpDir   string
+		factory  *index.Factory
+		subject  *Client
+		out      bytes.Buffer
+		ctx      = context.Background()
+		imageRef string
+	)
+
+	it.Before(func() {
+		server = httptest.NewServer(registry.New())
+		u, err := url.Parse(server.URL)
+		h.AssertNil(t, err)
+		host = u.Host
+
+		img, err := random.Image(1024, 1)
+		h.AssertNil(t, err)
+		imageRef = host + "/some-image:latest"
+		ref, err := name.ParseReference(imageRef)
+		h.AssertNil(t, err)
+		h.AssertNil(t, remote.Write(ref, img))
+
+		tmpDir, err = os.MkdirTemp("", "manifest-test")
+		h.AssertNil(t, err)
+		factory = index.NewFactory(tmpDir, authn.DefaultKeychain)
+
+		subject, err = NewClient(
+			WithLogger(logging.NewLogWithWriters(&out, &out)),
+			WithIndexFactory(factory),
+		)
+		h.AssertNil(t, err)
+	})
+
+	it.After(func() {
+		server.Close()
+		h.AssertNil(t, os.RemoveAll(tmpDir))
+	})
+
+	when("#CreateManifest", func() {
+		it("saves the index locally", func() {
+			h.AssertNil(t, subject.CreateManifest(ctx, CreateManifestOptions{
+				IndexRepoName: host + "/some-index",
+				RepoNames:     []string{imageRef},
+				Format:        "docker",
+			}))
+
+			idx, err := factory.LoadIndex(host + "/some-index")
+			h.AssertNil(t, err)
+			h.AssertEq(t, idx.MediaType(), types.DockerManifestList)
+			h.AssertEq(t, len(idx.Manifests()), 1)
+			h.AssertContains(t, out.String(), "Successfully created manifest list")
+		})
+
+		it("pushes the index when publishing", func() {
+			h.AssertNil(t, subject.CreateManifest(ctx, CreateManifestOptions{
+				IndexRepoName: host + "/some-index",
+				RepoNames:     []string{imageRef},
+				Publish:       true,
+			}))
+
+			_, err := factory.FetchIndex(ctx, host+"/some-index", false)
+			h.AssertNil(t, err)
+			h.AssertContains(t, out.String(), "Successfully pushed manifest list")
+		})
+
+		it("errors for unknown formats", func() {
+			err := subject.CreateManifest(ctx, CreateManifestOptions{
+				IndexRepoName: host + "/some-index",
+				RepoNames:     []string{imageRef},
+				Format:        "unknown",
+			})
+			h.AssertError(t, err, "unsupported media type format 'unknown'")
+		})
+	})
+
+	when("#RemoveManifest", func() {
+		it("removes the manifest from the index", func() {
+			h.AssertNil(t, subject.CreateManifest(ctx, CreateManifestOptions{
+				IndexRepoName: host + "/some-index",
+				RepoNames:     []string{imageRef},
+			}))
+
+			h.AssertNil(t, subject.RemoveManifest(ctx, RemoveManifestOptions{
+				IndexRepoName: host + "/some-index",
+				RepoName:      imageRef,
+			}))
+
+			idx, err := factory.LoadIndex(host + "/some-index")
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(idx.Manifests()), 0)
+		})
+	})
+
+	when("#DeleteManifest", func() {
+		it("reports indexes that do not exist", func() {
+			h.AssertNil(t, subject.CreateManifest(ctx, CreateManifestOptions{
+				IndexRepoName: host + "/some-index",
+				RepoNames:     []string{imageRef},
+			}))
+
+			err := subject.DeleteManifest([]string{host + "/some-index", host + "/unknown-index"})
+			h.AssertError(t, err, "does not exist in local storage")
+			h.AssertFalse(t, factory.Exists(host+"/some-index"))
+		})
+	})
+
+	when("#PushManifest", func() {
+		it("purges the local index once pushed", func() {
+			h.AssertNil(t, subject.CreateManifest(ctx, CreateManifestOptions{
+				IndexRepoName: host + "/some-index",
+				RepoNames:     []string{imageRef},
+			}))
+
+			h.AssertNil(t, subject.PushManifest(ctx, PushManifestOptions{
+				IndexRepoName: host + "/some-index",
+				Purge:         true,
+			}))
+			h.AssertFalse(t, factory.Exists(host+"/some-index"))
+		})
+	})
+
+	when("#InspectManifest", func() {
+		it("falls back to the registry", func() {
+			h.AssertNil(t, subject.CreateManifest(ctx, CreateManifestOptions{
+				IndexRepoName: host + "/some-index",
+				RepoNames:     []string{imageRef},
+			}))
+			h.AssertNil(t, subject.PushManifest(ctx, PushManifestOptions{
+				IndexRepoName: host + "/some-index",
+				Purge:         true,
+			}))
+
+			out.Reset()
+			h.AssertNil(t, subject.InspectManifest(ctx, host+"/some-index"))
+			h.AssertContains(t, out.String(), `"mediaType": "application/vnd.oci.image.index.v1+json"`)
+		})
+	})
+}
@@ -2,6 +2,10 @@ package client
 
 import (
 	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/pkg/errors"
 
@@ -9,8 +13,10 @@ import (
 	"github.com/buildpacks/pack/internal/layer"
 	"github.com/buildpacks/pack/internal/paths"
 	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/archive"
 	"github.com/buildpacks/pack/pkg/blob"
 	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/image"
 )
 
@@ -59,6 +65,10 @@ type PackageBuildpackOptions struct {
 
 	// Map of labels to add to the Buildpack
 	Labels map[string]string
+
+	// Targets to package the buildpack for. When several are given, one buildpackage is created
+	// per target and combined into an image index when publishing, or a single OCI layout file.
+	Targets []dist.Target
 }
 
 // PackageBuildpack packages buildpack(s) into either an image or file.
@@ -67,6 +77,10 @@ func (c *Client) PackageBuildpack(ctx context.Context, opts PackageBuildpackOpti
 		opts.Format = FormatImage
 	}
 
+	if len(opts.Targets) > 0 {
+		return c.packageBuildpackTargets(ctx, opts)
+	}
+
 	if opts.Config.Platform.OS == "windows" && !c.experimental {
 		return NewExperimentError("Windows buildpackage support is currently experimental.")
 	}
@@ -76,9 +90,80 @@ func (c *Client) PackageBuildpack(ctx context.Context, opts PackageBuildpackOpti
 		return err
 	}
 
-	writerFactory, err := layer.NewWriterFactory(opts.Config.Platform.OS)
+	packageBuilder, err := c.newBuildpackPackageBuilder(ctx, opts, dist.Target{OS: opts.Config.Platform.OS})
+	if err != nil {
+		return err
+	}
+
+	switch opts.Format {
+	case FormatFile:
+		return packageBuilder.SaveAsFile(opts.Name, opts.Config.Platform.OS, opts.Labels)
+	case FormatImage:
+		_, err = packageBuilder.SaveAsImage(opts.Name, opts.Publish, opts.Config.Platform.OS, opts.Labels)
+		return errors.Wrapf(err, "saving image")
+	default:
+		return errors.Errorf("unknown format: %s", style.Symbol(opts.Format))
+	}
+}
+
+// packageBuildpackTargets creates a buildpackage for each target. Published images are combined
+// into an image index and files hold an OCI layout referencing every target. Without publishing
+// only the target matching the daemon's platform is saved.
+func (c *Client) packageBuildpackTargets(ctx context.Context, opts PackageBuildpackOptions) error {
+	targets := opts.Targets
+	if opts.Format == FormatImage && !opts.Publish {
+		target, err := c.daemonTarget(ctx, targets)
+		if err != nil {
+			return err
+		}
+		targets = []dist.Target{target}
+	}
+
+	var packages []buildpack.TargetPackage
+	for _, target := range targets {
+		if target.OS == "windows" && !c.experimental {
+			return NewExperimentError("Windows buildpackage support is currently experimental.")
+		}
+
+		packageBuilder, err := c.newBuildpackPackageBuilder(ctx, opts, target)
+		if err != nil {
+			return err
+		}
+		packages = append(packages, buildpack.TargetPackage{Target: target, Builder: packageBuilder})
+	}
+
+	switch opts.Format {
+	case FormatFile:
+		return buildpack.SaveAsMultiPlatformFile(opts.Name, packages, opts.Labels)
+	case FormatImage:
+		if len(packages) == 1 {
+			_, err := packages[0].Builder.SaveAsTargetImage(opts.Name, opts.Publish, packages[0].Target, opts.Labels)
+			return errors.Wrapf(err, "saving image")
+		}
+
+		var digests []string
+		for _, pkg := range packages {
+			img, err := pkg.Builder.SaveAsTargetImage(opts.Name, opts.Publish, pkg.Target, opts.Labels)
+			if err != nil {
+				return errors.Wrapf(err, "saving image for target %s", style.Symbol(targetPlatform(&pkg.Target)))
+			}
+
+			identifier, err := img.Identifier()
+			if err != nil {
+				return errors.Wrapf(err, "getting identifier of image for target %s", style.Symbol(targetPlatform(&pkg.Target)))
+			}
+			digests = append(digests, identifier.String())
+		}
+		return c.createImageIndex(ctx, opts.Name, digests)
+	default:
+		return errors.Errorf("unknown format: %s", style.Symbol(opts.Format))
+	}
+}
+
+func (c *Client) newBuildpackPackageBuilder(ctx context.Context, opts PackageBuildpackOptions, target dist.Target) (*buildpack.PackageBuilder, error) {
+	writerFactory, err := layer.NewWriterFactory(target.OS)
 	if err != nil {
-		return errors.Wrap(err, "creating layer writer factory")
+		return nil, errors.Wrap(err, "creating layer writer factory")
 	}
 
 	var packageBuilderOpts []buildpack.PackageBuilderOption
@@ -90,47 +175,122 @@ func (c *Client) PackageBuildpack(ctx context.Context, opts PackageBuildpackOpti
 
 	bpURI := opts.Config.Buildpack.URI
 	if bpURI == "" {
-		return errors.New("buildpack URI must be provided")
+		return nil, errors.New("buildpack URI must be provided")
 	}
 
 	mainBlob, err := c.downloadBuildpackFromURI(ctx, bpURI, opts.RelativeBaseDir)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	if target.Arch != "" {
+		mainBlob, err = c.targetBuildpackBlob(mainBlob, bpURI, opts.RelativeBaseDir, target, opts.Targets)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	bp, err := buildpack.FromBuildpackRootBlob(mainBlob, writerFactory, c.logger)
 	if err != nil {
-		return errors.Wrapf(err, "creating buildpack from %s", style.Symbol(bpURI))
+		return nil, errors.Wrapf(err, "creating buildpack from %s", style.Symbol(bpURI))
+	}
+
+	if target.Arch != "" && len(bp.Descriptor().Targets()) > 0 {
+		if err := bp.Descriptor().EnsureTargetSupport(target.OS, target.Arch, "", ""); err != nil {
+			return nil, errors.Wrapf(err, "packaging for target %s", style.Symbol(targetPlatform(&target)))
+		}
 	}
 
 	packageBuilder.SetBuildpack(bp)
 
+	platform := ""
+	if target.Arch != "" {
+		platform = targetPlatform(&target)
+	}
+
 	for _, dep := range opts.Config.Dependencies {
 		mainBP, deps, err := c.buildpackDownloader.Download(ctx, dep.URI, buildpack.DownloadOptions{
 			RegistryName:    opts.Registry,
 			RelativeBaseDir: opts.RelativeBaseDir,
-			ImageOS:         opts.Config.Platform.OS,
+			ImageOS:         target.OS,
+			Platform:        platform,
 			ImageName:       dep.ImageName,
 			Daemon:          !opts.Publish,
 			PullPolicy:      opts.PullPolicy,
 		})
 
 		if err != nil {
-			return errors.Wrapf(err, "packaging dependencies (uri=%s,image=%s)", style.Symbol(dep.URI), style.Symbol(dep.ImageName))
+			return nil, errors.Wrapf(err, "packaging dependencies (uri=%s,image=%s)", style.Symbol(dep.URI), style.Symbol(dep.ImageName))
 		}
 
 		packageBuilder.AddDependencies(mainBP, deps)
 	}
 
-	switch opts.Format {
-	case FormatFile:
-		return packageBuilder.SaveAsFile(opts.Name, opts.Config.Platform.OS, opts.Labels)
-	case FormatImage:
-		_, err = packageBuilder.SaveAsImage(opts.Name, opts.Publish, opts.Config.Platform.OS, opts.Labels)
-		return errors.Wrapf(err, "saving image")
-	default:
-		return errors.Errorf("unknown format: %s", style.Symbol(opts.Format))
+	return packageBuilder, nil
+}
+
+// targetBuildpackBlob returns the blob holding the buildpack for target. A local buildpack
+// directory may contain a '<os>/<arch>[/<variant>]' directory per target, overlaid on the
+// buildpack directory itself, so that files shared by every target, such as buildpack.toml,
+// are kept in the buildpack directory.
+func (c *Client) targetBuildpackBlob(mainBlob blob.Blob, uri, relativeBaseDir string, target dist.Target, targets []dist.Target) (blob.Blob, error) {
+	uri, err := paths.FilePathToURI(uri, relativeBaseDir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "making absolute: %s", style.Symbol(uri))
+	}
+	if !strings.HasPrefix(uri, "file://") {
+		return mainBlob, nil
+	}
+
+	rootDir, err := paths.URIToFilePath(uri)
+	if err != nil {
+		return nil, err
+	}
+	if isDir, err := paths.IsDir(rootDir); err != nil || !isDir {
+		return mainBlob, nil
+	}
+
+	candidates := []string{filepath.Join(rootDir, target.OS, target.Arch)}
+	if target.ArchVariant != "" {
+		candidates = append([]string{filepath.Join(rootDir, target.OS, target.Arch, target.ArchVariant)}, candidates...)
 	}
+	for _, dir := range candidates {
+		if isDir, err := paths.IsDir(dir); err == nil && isDir {
+			c.logger.Debugf("Using %s for target %s", style.Symbol(dir), style.Symbol(targetPlatform(&target)))
+			targetOSes := map[string]bool{target.OS: true}
+			for _, t := range targets {
+				targetOSes[t.OS] = true
+			}
+			return &targetBlob{rootDir: rootDir, targetDir: dir, targetOSes: targetOSes}, nil
+		}
+	}
+	return mainBlob, nil
+}
+
+// targetBlob is a buildpack directory for a single target overlaid on the buildpack root directory.
+// Files of the target directory replace those of the root directory, and the '<os>' directories
+// holding targets are left out.
+type targetBlob struct {
+	rootDir    string
+	targetDir  string
+	targetOSes map[string]bool
+}
+
+// Open returns an io.ReadCloser whose contents are in tar archive format
+func (b *targetBlob) Open() (io.ReadCloser, error) {
+	return archive.GenerateTar(func(tw archive.TarWriter) error {
+		if err := archive.WriteDirToTar(tw, b.targetDir, ".", 0, 0, -1, true, false, nil); err != nil {
+			return err
+		}
+
+		return archive.WriteDirToTar(tw, b.rootDir, ".", 0, 0, -1, true, false, func(relPath string) bool {
+			if b.targetOSes[strings.Split(filepath.ToSlash(relPath), "/")[0]] {
+				return false
+			}
+			_, err := os.Lstat(filepath.Join(b.targetDir, relPath))
+			return os.IsNotExist(err)
+		})
+	}), nil
 }
 
 func (c *Client) downloadBuildpackFromURI(ctx context.Context, uri, relativeBaseDir string) (blob.Blob, error) {
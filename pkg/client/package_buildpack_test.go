@@ -1,19 +1,26 @@
 package client_test
 
 import (
+	"archive/tar"
 	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/buildpacks/imgutil"
 	"github.com/buildpacks/imgutil/fakes"
 	"github.com/buildpacks/lifecycle/api"
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/system"
 	"github.com/golang/mock/gomock"
 	"github.com/heroku/color"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/sclevine/spec"
 	"github.com/sclevine/spec/report"
 
@@ -873,6 +880,132 @@ func testPackageBuildpack(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 
+	when("targets are provided", func() {
+		var bpDir string
+
+		it.Before(func() {
+			var err error
+			bpDir, err = os.MkdirTemp("", "package-buildpack-targets")
+			h.AssertNil(t, err)
+
+			h.AssertNil(t, os.WriteFile(filepath.Join(bpDir, "buildpack.toml"), []byte(`
+api = "0.10"
+
+[buildpack]
+id = "bp.one"
+version = "1.0.0"
+
+[[targets]]
+os = "linux"
+arch = "amd64"
+
+[[targets]]
+os = "linux"
+arch = "arm64"
+`), 0644))
+			for _, arch := range []string{"amd64", "arm64"} {
+				binDir := filepath.Join(bpDir, "linux", arch, "bin")
+				h.AssertNil(t, os.MkdirAll(binDir, 0755))
+				h.AssertNil(t, os.WriteFile(filepath.Join(binDir, "build"), []byte(arch+"-build"), 0755))
+				h.AssertNil(t, os.WriteFile(filepath.Join(binDir, "detect"), []byte(arch+"-detect"), 0755))
+			}
+			h.AssertNil(t, os.MkdirAll(filepath.Join(bpDir, "bin"), 0755))
+			h.AssertNil(t, os.WriteFile(filepath.Join(bpDir, "bin", "build"), []byte("root-build"), 0755))
+			h.AssertNil(t, os.MkdirAll(filepath.Join(bpDir, "lib"), 0755))
+			h.AssertNil(t, os.WriteFile(filepath.Join(bpDir, "lib", "shared.sh"), []byte("shared"), 0644))
+
+			mockDownloader.EXPECT().Download(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, uri string) (blob.Blob, error) {
+				path, err := paths.URIToFilePath(uri)
+				h.AssertNil(t, err)
+				return blob.NewBlob(path), nil
+			}).AnyTimes()
+		})
+
+		it.After(func() {
+			h.AssertNil(t, os.RemoveAll(bpDir))
+		})
+
+		it("writes a file holding a buildpackage per target", func() {
+			outputFile := filepath.Join(bpDir, "package.cnb")
+			h.AssertNil(t, subject.PackageBuildpack(context.TODO(), client.PackageBuildpackOptions{
+				Name:    outputFile,
+				Format:  client.FormatFile,
+				Config:  pubbldpkg.Config{Buildpack: dist.BuildpackURI{URI: bpDir}},
+				Targets: []dist.Target{{OS: "linux", Arch: "amd64"}, {OS: "linux", Arch: "arm64"}},
+			}))
+
+			h.AssertOnTarEntry(t, outputFile, "/index.json", func(t *testing.T, _ *tar.Header, data []byte) {
+				index := v1.Index{}
+				h.AssertNil(t, json.Unmarshal(data, &index))
+				h.AssertEq(t, len(index.Manifests), 2)
+				h.AssertEq(t, index.Manifests[1].Platform.Architecture, "arm64")
+
+				h.AssertOnTarEntry(t, outputFile, "/blobs/sha256/"+index.Manifests[1].Digest.Hex(), func(t *testing.T, _ *tar.Header, data []byte) {
+					manifest := v1.Manifest{}
+					h.AssertNil(t, json.Unmarshal(data, &manifest))
+					h.AssertOnTarEntry(t, outputFile, "/blobs/sha256/"+manifest.Layers[0].Digest.Hex(),
+						h.IsGzipped(),
+						h.AssertOnNestedTar("/cnb/buildpacks/bp.one/1.0.0/bin/build", h.ContentEquals("arm64-build")),
+						h.AssertOnNestedTar("/cnb/buildpacks/bp.one/1.0.0/lib/shared.sh", h.ContentEquals("shared")),
+						assertNoNestedEntries("/cnb/buildpacks/bp.one/1.0.0/linux"),
+					)
+				})
+			})
+		})
+
+		it("packages a single buildpackage when no target is provided", func() {
+			outputFile := filepath.Join(bpDir, "package.cnb")
+			h.AssertNil(t, subject.PackageBuildpack(context.TODO(), client.PackageBuildpackOptions{
+				Name:   outputFile,
+				Format: client.FormatFile,
+				Config: pubbldpkg.Config{Buildpack: dist.BuildpackURI{URI: bpDir}, Platform: dist.Platform{OS: "linux"}},
+			}))
+
+			h.AssertOnTarEntry(t, outputFile, "/index.json", func(t *testing.T, _ *tar.Header, data []byte) {
+				index := v1.Index{}
+				h.AssertNil(t, json.Unmarshal(data, &index))
+				h.AssertEq(t, len(index.Manifests), 1)
+
+				h.AssertOnTarEntry(t, outputFile, "/blobs/sha256/"+index.Manifests[0].Digest.Hex(), func(t *testing.T, _ *tar.Header, data []byte) {
+					manifest := v1.Manifest{}
+					h.AssertNil(t, json.Unmarshal(data, &manifest))
+					h.AssertOnTarEntry(t, outputFile, "/blobs/sha256/"+manifest.Layers[0].Digest.Hex(),
+						h.IsGzipped(),
+						h.AssertOnNestedTar("/cnb/buildpacks/bp.one/1.0.0/bin/build", h.ContentEquals("root-build")),
+					)
+				})
+			})
+		})
+
+		it("fails for targets the buildpack does not declare", func() {
+			err := subject.PackageBuildpack(context.TODO(), client.PackageBuildpackOptions{
+				Name:    filepath.Join(bpDir, "package.cnb"),
+				Format:  client.FormatFile,
+				Config:  pubbldpkg.Config{Buildpack: dist.BuildpackURI{URI: bpDir}},
+				Targets: []dist.Target{{OS: "linux", Arch: "s390x"}},
+			})
+			h.AssertError(t, err, "packaging for target 'linux/s390x'")
+		})
+
+		it("saves only the daemon target to the daemon", func() {
+			mockDockerClient.EXPECT().ServerVersion(gomock.Any()).Return(types.Version{Os: "linux", Arch: "arm64"}, nil)
+			fakePackageImage := fakes.NewImage("some/package", "", nil)
+			mockImageFactory.EXPECT().NewImage("some/package", true, "linux").Return(fakePackageImage, nil)
+
+			h.AssertNil(t, subject.PackageBuildpack(context.TODO(), client.PackageBuildpackOptions{
+				Name:    "some/package",
+				Format:  client.FormatImage,
+				Config:  pubbldpkg.Config{Buildpack: dist.BuildpackURI{URI: bpDir}},
+				Targets: []dist.Target{{OS: "linux", Arch: "amd64"}, {OS: "linux", Arch: "arm64"}},
+			}))
+
+			h.AssertTrue(t, fakePackageImage.IsSaved())
+			arch, err := fakePackageImage.Architecture()
+			h.AssertNil(t, err)
+			h.AssertEq(t, arch, "arm64")
+		})
+	})
+
 	when("unknown format is provided", func() {
 		it("should error", func() {
 			mockDockerClient.EXPECT().Info(context.TODO()).Return(system.Info{OSType: "linux"}, nil).AnyTimes()
@@ -896,6 +1029,26 @@ func testPackageBuildpack(t *testing.T, when spec.G, it spec.S) {
 	})
 }
 
+func assertNoNestedEntries(prefix string) h.TarEntryAssertion {
+	return func(t *testing.T, _ *tar.Header, data []byte) {
+		t.Helper()
+
+		gzipReader, err := gzip.NewReader(bytes.NewReader(data))
+		h.AssertNil(t, err)
+		tr := tar.NewReader(gzipReader)
+		for {
+			header, err := tr.Next()
+			if err == io.EOF {
+				return
+			}
+			h.AssertNil(t, err)
+			if strings.HasPrefix(header.Name, prefix) {
+				t.Fatalf("unexpected entry %s", header.Name)
+			}
+		}
+	}
+}
+
 func assertPackageBPFileHasBuildpacks(t *testing.T, path string, descriptors []dist.BuildpackDescriptor) {
 	packageBlob := blob.NewBlob(path)
 	mainBP, depBPs, err := buildpack.BuildpacksFromOCILayoutBlob(packageBlob)
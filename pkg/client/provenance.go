@@ -0,0 +1,253 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/buildpacks/imgutil"
+	"github.com/buildpacks/imgutil/remote"
+	"github.com/buildpacks/lifecycle/buildpack"
+	"github.com/buildpacks/lifecycle/platform"
+	"github.com/buildpacks/lifecycle/platform/files"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/build"
+	"github.com/buildpacks/pack/internal/intoto"
+	strs "github.com/buildpacks/pack/internal/strings"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/project/v02"
+)
+
+const (
+	// ProvenanceBuildType is the SLSA build type of the provenance of images built by pack.
+	ProvenanceBuildType = "https://buildpacks.io/pack/build/v1"
+	// ProvenanceBuilderID identifies pack as the builder in the provenance of images built by pack.
+	ProvenanceBuilderID = "https://buildpacks.io/pack"
+)
+
+// provenanceMaterials are the inputs of a build recorded in its provenance that are not part of the lifecycle options
+type provenanceMaterials struct {
+	builderDigest    string
+	lifecycleVersion string
+	// source is the project source recorded in the image, or else the git repository of the app, if any
+	source *files.ProjectSource
+	// parameters are the build options given by the user
+	parameters map[string]interface{}
+}
+
+// newProvenanceMaterials gathers the inputs of a build to record in its provenance
+func (c *Client) newProvenanceMaterials(ctx context.Context, builderImage imgutil.Image, lifecycleVersion string, opts BuildOptions, env map[string]string, lifecycleOpts build.LifecycleOptions) provenanceMaterials {
+	source := lifecycleOpts.ProjectMetadata.Source
+	if source == nil {
+		source = v02.GitMetadata(lifecycleOpts.AppPath)
+	}
+
+	parameters := map[string]interface{}{
+		"image":      lifecycleOpts.Image.Name(),
+		"builder":    lifecycleOpts.BuilderImage,
+		"publish":    opts.Publish,
+		"clearCache": opts.ClearCache,
+		"pullPolicy": opts.PullPolicy.String(),
+	}
+	if opts.RunImage != "" {
+		parameters["runImage"] = opts.RunImage
+	}
+	if len(opts.Buildpacks) > 0 {
+		parameters["buildpacks"] = opts.Buildpacks
+	}
+	if len(opts.Extensions) > 0 {
+		parameters["extensions"] = opts.Extensions
+	}
+	// env values may hold secrets, and the provenance is published along with the image
+	if len(env) > 0 {
+		parameters["env"] = sortedKeys(env)
+	}
+	if len(opts.AdditionalTags) > 0 {
+		parameters["tags"] = opts.AdditionalTags
+	}
+	if opts.PreviousImage != "" {
+		parameters["previousImage"] = opts.PreviousImage
+	}
+	if opts.Platform != "" {
+		parameters["platform"] = opts.Platform
+	}
+
+	return provenanceMaterials{
+		builderDigest:    c.imageDigest(ctx, builderImage),
+		lifecycleVersion: lifecycleVersion,
+		source:           source,
+		parameters:       parameters,
+	}
+}
+
+// writeProvenance writes the provenance of the built image as an in-toto statement to path. The subject of the
+// statement is identified by its manifest digest when published, or else by its image ID.
+func (c *Client) writeProvenance(ctx context.Context, path string, imageRef name.Reference, publish bool, lifecycleOpts build.LifecycleOptions, materials provenanceMaterials, startedOn, finishedOn time.Time) error {
+	img, err := c.imageFetcher.Fetch(ctx, imageRef.Name(), image.FetchOptions{Daemon: !publish, PullPolicy: image.PullNever})
+	if err != nil {
+		return errors.Wrap(err, "fetching built image")
+	}
+	id, err := img.Identifier()
+	if err != nil {
+		return errors.Wrapf(err, "reading digest of %s", style.Symbol(imageRef.Name()))
+	}
+
+	provenance, err := c.provenance(img, lifecycleOpts, materials, startedOn, finishedOn)
+	if err != nil {
+		return err
+	}
+	subject := intoto.Subject{Name: imageRef.Context().Name(), Digest: digestSet(parseDigestFromImageID(id))}
+	contents, err := json.MarshalIndent(intoto.NewStatement(subject, intoto.ProvenancePredicateType, provenance), "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(contents, '\n'), 0644); err != nil {
+		return errors.Wrap(err, "writing provenance")
+	}
+
+	c.logger.Infof("Wrote provenance of %s to %s", style.Symbol(imageRef.Name()), style.Symbol(path))
+	return nil
+}
+
+// provenance describes the build of an app image, from the builder, run image, buildpacks and project source used
+func (c *Client) provenance(img imgutil.Image, lifecycleOpts build.LifecycleOptions, materials provenanceMaterials, startedOn, finishedOn time.Time) (intoto.Provenance, error) {
+	var buildMD files.BuildMetadata
+	if _, err := dist.GetLabel(img, platform.BuildMetadataLabel, &buildMD); err != nil {
+		return intoto.Provenance{}, err
+	}
+	var layersMD files.LayersMetadata
+	if _, err := dist.GetLabel(img, platform.LifecycleMetadataLabel, &layersMD); err != nil {
+		return intoto.Provenance{}, err
+	}
+
+	dependencies := []intoto.ResourceDescriptor{{
+		Name:   "builder",
+		URI:    lifecycleOpts.BuilderImage,
+		Digest: digestSet(materials.builderDigest),
+	}}
+	if layersMD.RunImage.Reference != "" {
+		dependencies = append(dependencies, intoto.ResourceDescriptor{
+			Name:   "run-image",
+			URI:    strs.ValueOrDefault(layersMD.RunImage.Image, lifecycleOpts.RunImage),
+			Digest: digestSet(layersMD.RunImage.Reference),
+		})
+	}
+	dependencies = append(dependencies, moduleDependencies(buildMD.Buildpacks, "buildpack")...)
+	dependencies = append(dependencies, moduleDependencies(buildMD.Extensions, "extension")...)
+	if source := sourceDependency(materials.source); source != nil {
+		dependencies = append(dependencies, *source)
+	}
+
+	return intoto.Provenance{
+		BuildDefinition: intoto.BuildDefinition{
+			BuildType:            ProvenanceBuildType,
+			ExternalParameters:   materials.parameters,
+			ResolvedDependencies: dependencies,
+		},
+		RunDetails: intoto.RunDetails{
+			Builder: intoto.Builder{
+				ID: ProvenanceBuilderID,
+				Version: map[string]string{
+					"pack":      c.version,
+					"lifecycle": materials.lifecycleVersion,
+				},
+			},
+			Metadata: &intoto.BuildMetadata{
+				StartedOn:  &startedOn,
+				FinishedOn: &finishedOn,
+			},
+		},
+	}, nil
+}
+
+func moduleDependencies(group []buildpack.GroupElement, kind string) []intoto.ResourceDescriptor {
+	var dependencies []intoto.ResourceDescriptor
+	for _, module := range group {
+		annotations := map[string]interface{}{"kind": kind, "version": module.Version}
+		if module.Homepage != "" {
+			annotations["homepage"] = module.Homepage
+		}
+		dependencies = append(dependencies, intoto.ResourceDescriptor{Name: module.ID, Annotations: annotations})
+	}
+	return dependencies
+}
+
+// sourceDependency describes the git repository of the app, as found by v02.GitMetadata
+func sourceDependency(source *files.ProjectSource) *intoto.ResourceDescriptor {
+	if source == nil || source.Type != "git" {
+		return nil
+	}
+
+	dependency := &intoto.ResourceDescriptor{Name: "source", Annotations: map[string]interface{}{}}
+	if url, ok := source.Metadata["url"].(string); ok && url != "" {
+		dependency.URI = "git+" + url
+	}
+	if commit, ok := source.Version["commit"].(string); ok && commit != "" {
+		dependency.Digest = map[string]string{"gitCommit": commit}
+	}
+	if describe, ok := source.Version["describe"].(string); ok && describe != "" {
+		dependency.Annotations["describe"] = describe
+	}
+	if refs, ok := source.Metadata["refs"]; ok {
+		dependency.Annotations["refs"] = refs
+	}
+	return dependency
+}
+
+// digestSet returns the digest of an image, given as a digest or a digest reference, in the form used by in-toto
+func digestSet(digest string) map[string]string {
+	if i := strings.LastIndex(digest, "@"); i >= 0 {
+		digest = digest[i+1:]
+	}
+	algorithm, hex, ok := strings.Cut(digest, ":")
+	if !ok {
+		return nil
+	}
+	return map[string]string{algorithm: hex}
+}
+
+// imageDigest returns the digest of an image in its repository, as by repositoryDigest, or an empty string when it
+// cannot be read.
+func (c *Client) imageDigest(ctx context.Context, img imgutil.Image) string {
+	digest, err := c.repositoryDigest(ctx, img)
+	if err != nil {
+		c.logger.Debugf("Unable to read digest of %s: %s", style.Symbol(img.Name()), err)
+		return ""
+	}
+	return digest
+}
+
+// repositoryDigest returns the digest an image is referred to by in its repository, which for a multi-platform image
+// is the digest of its image index, whether it was fetched from the daemon or the registry. Daemon images that have
+// not been pulled from or pushed to the repository they are named after have no digest, and an empty string is
+// returned.
+func (c *Client) repositoryDigest(ctx context.Context, img imgutil.Image) (string, error) {
+	ref, err := name.ParseReference(img.Name(), name.WeakValidation)
+	if err != nil {
+		return "", err
+	}
+	if id, err := img.Identifier(); err == nil {
+		if digestID, ok := id.(remote.DigestIdentifier); ok {
+			// remote images are identified by the manifest of their platform
+			return image.RepositoryDigest(ctx, c.keychain, ref, digestID.Digest.DigestStr())
+		}
+	}
+
+	// the daemon records the digest images were pulled by
+	inspect, _, err := c.docker.ImageInspectWithRaw(ctx, img.Name())
+	if err != nil {
+		return "", err
+	}
+	for _, repoDigest := range inspect.RepoDigests {
+		if digestRef, err := name.NewDigest(repoDigest, name.WeakValidation); err == nil && digestRef.Context().Name() == ref.Context().Name() {
+			return digestRef.DigestStr(), nil
+		}
+	}
+	return "", nil
+}
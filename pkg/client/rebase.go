@@ -14,6 +14,7 @@ import (
 
 	"github.com/buildpacks/pack/internal/build"
 	"github.com/buildpacks/pack/internal/builder"
+	"github.com/buildpacks/pack/internal/sign"
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/image"
@@ -49,6 +50,9 @@ type RebaseOptions struct {
 
 	// Image reference to use as the previous image for rebase.
 	PreviousImage string
+
+	// Sign the rebased image with a cosign-compatible key. Requires Publish.
+	Sign *SignOptions
 }
 
 // Rebase updates the run image layers in an app image.
@@ -59,6 +63,16 @@ func (c *Client) Rebase(ctx context.Context, opts RebaseOptions) error {
 		return errors.Wrapf(err, "invalid image name '%s'", opts.RepoName)
 	}
 
+	var signer *sign.Signer
+	if opts.Sign != nil {
+		if !opts.Publish {
+			return errors.New("signing requires the image to be published")
+		}
+		if signer, err = c.newSigner(*opts.Sign); err != nil {
+			return err
+		}
+	}
+
 	repoName := opts.RepoName
 
 	if opts.PreviousImage != "" {
@@ -135,6 +149,12 @@ func (c *Client) Rebase(ctx context.Context, opts RebaseOptions) error {
 
 	c.logger.Infof("Rebased Image: %s", style.Symbol(appImageIdentifier.String()))
 
+	if signer != nil {
+		if err := c.signImage(ctx, signer, appImage, imageRef); err != nil {
+			return err
+		}
+	}
+
 	if opts.ReportDestinationDir != "" {
 		reportPath := filepath.Join(opts.ReportDestinationDir, "report.toml")
 		reportFile, err := os.OpenFile(reportPath, os.O_RDWR|os.O_CREATE, 0644)
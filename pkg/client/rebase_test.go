@@ -3,11 +3,19 @@ package client
 import (
 	"bytes"
 	"context"
+	"fmt"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/buildpacks/imgutil/fakes"
+	"github.com/buildpacks/imgutil/remote"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	ggcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/heroku/color"
 	"github.com/sclevine/spec"
 	"github.com/sclevine/spec/report"
@@ -267,6 +275,60 @@ func testRebase(t *testing.T, when spec.G, it spec.S) {
 					})
 				})
 			})
+			when("sign is set", func() {
+				var keyPath string
+
+				it.Before(func() {
+					keyPath = writeSigningKey(t, t.TempDir())
+					subject.keychain = authn.DefaultKeychain
+				})
+
+				it("requires the image to be published", func() {
+					h.AssertError(t, subject.Rebase(context.TODO(), RebaseOptions{
+						RepoName: "some/app",
+						Sign:     &SignOptions{KeyPath: keyPath},
+					}), "signing requires the image to be published")
+				})
+
+				when("the image is published", func() {
+					var (
+						server *httptest.Server
+						repo   string
+					)
+
+					it.Before(func() {
+						server = httptest.NewServer(registry.New())
+						repo = strings.TrimPrefix(server.URL, "http://") + "/some/app"
+
+						digest, err := name.NewDigest(repo + "@sha256:" + strings.Repeat("a", 64))
+						h.AssertNil(t, err)
+						signedAppImage := fakes.NewImage(repo, "", remote.DigestIdentifier{Digest: digest})
+						h.AssertNil(t, signedAppImage.SetLabel("io.buildpacks.lifecycle.metadata", `{"stack":{"runImage":{"image":"some/run"}}}`))
+						h.AssertNil(t, signedAppImage.SetLabel("io.buildpacks.stack.id", "io.buildpacks.stacks.jammy"))
+						fakeImageFetcher.RemoteImages[repo] = signedAppImage
+						fakeImageFetcher.RemoteImages["some/run"] = fakeRunImage
+					})
+
+					it.After(func() {
+						server.Close()
+					})
+
+					it("signs the rebased image", func() {
+						h.AssertNil(t, subject.Rebase(context.TODO(), RebaseOptions{
+							RepoName: repo,
+							Publish:  true,
+							Sign:     &SignOptions{KeyPath: keyPath},
+						}))
+						h.AssertContains(t, out.String(), fmt.Sprintf("Signed '%s@sha256:%s'", repo, strings.Repeat("a", 64)))
+
+						sigTag, err := name.NewTag(repo + ":sha256-" + strings.Repeat("a", 64) + ".sig")
+						h.AssertNil(t, err)
+						_, err = ggcrremote.Image(sigTag)
+						h.AssertNil(t, err)
+					})
+				})
+			})
+
 			when("previous image is provided", func() {
 				it("fetches the image using the previous image name", func() {
 					h.AssertNil(t, subject.Rebase(context.TODO(), RebaseOptions{
@@ -11,6 +11,7 @@ import (
 	"github.com/buildpacks/pack/pkg/buildpack"
 	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/image"
+	registryTypes "github.com/buildpacks/pack/registry"
 )
 
 // RegisterBuildpackOptions is a configuration struct that controls the
@@ -90,6 +91,13 @@ func (c *Client) RegisterBuildpack(ctx context.Context, opts RegisterBuildpackOp
 		if err := registry.GitCommit(buildpack, username, registryCache); err != nil {
 			return err
 		}
+	} else if opts.Type == registryTypes.TypeOCI {
+		index, err := registry.NewOCIIndex(c.logger, opts.URL, c.keychain)
+		if err != nil {
+			return err
+		}
+
+		return index.Register(ctx, buildpack)
 	}
 
 	return nil
@@ -3,9 +3,14 @@ package client
 import (
 	"bytes"
 	"context"
+	"io"
+	"log"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/buildpacks/imgutil/fakes"
+	ggcrregistry "github.com/google/go-containerregistry/pkg/registry"
 	"github.com/heroku/color"
 	"github.com/sclevine/spec"
 	"github.com/sclevine/spec/report"
@@ -133,5 +138,30 @@ func testRegisterBuildpack(t *testing.T, when spec.G, it spec.S) {
 					Name:      registry.DefaultRegistryName,
 				}))
 		})
+
+		it("should add the buildpack to the index (oci)", func() {
+			server := httptest.NewServer(ggcrregistry.New(ggcrregistry.Logger(log.New(io.Discard, "", 0))))
+			defer server.Close()
+			repository := strings.TrimPrefix(server.URL, "http://") + "/buildpacks"
+
+			digest := "example.com/heroku/java-function@sha256:8c27fe111c11b722081701dfed3bd55e039b9ce92865473cf4cdfa918071c566"
+			fakeAppImage = fakes.NewImage("buildpack/image", "", &fakeIdentifier{name: digest})
+			h.AssertNil(t, fakeAppImage.SetLabel("io.buildpacks.buildpackage.metadata", `{"id":"heroku/java-function","version":"1.1.1"}`))
+			fakeImageFetcher.RemoteImages["buildpack/image"] = fakeAppImage
+
+			h.AssertNil(t, subject.RegisterBuildpack(context.TODO(),
+				RegisterBuildpackOptions{
+					ImageName: "buildpack/image",
+					Type:      "oci",
+					URL:       "oci://" + repository,
+					Name:      "internal",
+				}))
+
+			index, err := registry.NewOCIIndex(subject.logger, repository, nil)
+			h.AssertNil(t, err)
+			bp, err := index.LocateBuildpack("heroku/java-function@1.1.1")
+			h.AssertNil(t, err)
+			h.AssertEq(t, bp.Address, digest)
+		})
 	})
 }
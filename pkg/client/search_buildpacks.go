@@ -0,0 +1,94 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/registry"
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// RegistryBuildpack is a version of a buildpack stored in a buildpack registry.
+type RegistryBuildpack struct {
+	ID      string `json:"id"`
+	Version string `json:"version"`
+	Yanked  bool   `json:"yanked"`
+	Address string `json:"address"`
+}
+
+// SearchBuildpacksOptions are options available for SearchBuildpacks
+type SearchBuildpacksOptions struct {
+	// Term to find in the IDs of buildpacks, ignoring case. All buildpacks are found when it is empty.
+	Term string
+	// RegistryName to search for buildpacks in.
+	RegistryName string
+}
+
+// BuildpackVersionsOptions are options available for BuildpackVersions
+type BuildpackVersionsOptions struct {
+	// ID of the buildpack, e.g. example/my-buildpack.
+	ID string
+	// RegistryName to list the versions of the buildpack from.
+	RegistryName string
+}
+
+// SearchBuildpacks returns the latest version of each buildpack of a registry whose ID contains the search term,
+// ordered by ID. The latest version is the highest version that isn't yanked, or the highest version when all
+// versions were yanked.
+func (c *Client) SearchBuildpacks(opts SearchBuildpacksOptions) ([]RegistryBuildpack, error) {
+	index, err := getRegistryIndex(c.logger, c.keychain, opts.RegistryName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid registry '%s'", opts.RegistryName)
+	}
+	registryCache, ok := index.(*registry.Cache)
+	if !ok {
+		return nil, fmt.Errorf("registry %s does not support searching", style.Symbol(opts.RegistryName))
+	}
+
+	entries, err := registryCache.Search(opts.Term)
+	if err != nil {
+		return nil, err
+	}
+
+	var buildpacks []RegistryBuildpack
+	for _, entry := range entries {
+		latest := entry.Buildpacks[0]
+		for _, bp := range entry.Buildpacks {
+			if !bp.Yanked {
+				latest = bp
+				break
+			}
+		}
+		buildpacks = append(buildpacks, toRegistryBuildpack(latest))
+	}
+	return buildpacks, nil
+}
+
+// BuildpackVersions returns the versions of a buildpack stored in a registry, highest first.
+func (c *Client) BuildpackVersions(opts BuildpackVersionsOptions) ([]RegistryBuildpack, error) {
+	index, err := getRegistryIndex(c.logger, c.keychain, opts.RegistryName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid registry '%s'", opts.RegistryName)
+	}
+
+	versions, err := index.Versions(opts.ID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "listing versions of %s", style.Symbol(opts.ID))
+	}
+
+	var buildpacks []RegistryBuildpack
+	for _, bp := range versions {
+		buildpacks = append(buildpacks, toRegistryBuildpack(bp))
+	}
+	return buildpacks, nil
+}
+
+func toRegistryBuildpack(bp registry.Buildpack) RegistryBuildpack {
+	return RegistryBuildpack{
+		ID:      fmt.Sprintf("%s/%s", bp.Namespace, bp.Name),
+		Version: bp.Version,
+		Yanked:  bp.Yanked,
+		Address: bp.Address,
+	}
+}
@@ -0,0 +1,100 @@
+package client_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	cfg "github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestSearchBuildpacks(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "SearchBuildpacks", testSearchBuildpacks, spec.Sequential(), spec.Report(report.Terminal{}))
+}
+
+func testSearchBuildpacks(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject *client.Client
+		tmpDir  string
+		out     bytes.Buffer
+	)
+
+	it.Before(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "search-buildpacks")
+		h.AssertNil(t, err)
+
+		fixtureDir := filepath.Join(tmpDir, "fixture")
+		h.RecursiveCopyNow(t, filepath.Join("testdata", "registry"), fixtureDir)
+		h.AssertNil(t, os.MkdirAll(filepath.Join(fixtureDir, "ja", "va"), 0755))
+		h.AssertNil(t, os.WriteFile(filepath.Join(fixtureDir, "ja", "va", "example_javascript"), []byte(
+			`{"ns":"example","name":"javascript","version":"2.0.0","yanked":false,"addr":"example.com/some/package@sha256:74eb48882e835d8767f62940d453eb96ed2737de3a16573881dcea7dea769df7"}
+{"ns":"example","name":"javascript","version":"2.1.0","yanked":true,"addr":"example.com/some/package@sha256:2560f05307e8de9d830f144d09556e19dd1eb7d928aee900ed02208ae9727e7a"}
+`), 0644))
+		registryFixture := h.CreateRegistryFixture(t, tmpDir, fixtureDir)
+
+		packHome := filepath.Join(tmpDir, ".pack")
+		h.AssertNil(t, os.Setenv("PACK_HOME", packHome))
+		h.AssertNil(t, cfg.Write(cfg.Config{
+			Registries: []cfg.Registry{
+				{
+					Name: "some-registry",
+					Type: "github",
+					URL:  registryFixture,
+				},
+			},
+		}, filepath.Join(packHome, "config.toml")))
+
+		subject, err = client.NewClient(client.WithLogger(logging.NewLogWithWriters(&out, &out)))
+		h.AssertNil(t, err)
+	})
+
+	it.After(func() {
+		h.AssertNil(t, os.Unsetenv("PACK_HOME"))
+		_ = os.RemoveAll(tmpDir)
+	})
+
+	when("#SearchBuildpacks", func() {
+		it("returns the latest version of the buildpacks matching the term", func() {
+			buildpacks, err := subject.SearchBuildpacks(client.SearchBuildpacksOptions{Term: "ja", RegistryName: "some-registry"})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, buildpacks, []client.RegistryBuildpack{
+				{ID: "example/java", Version: "1.0.0", Address: "example.com/some/package@sha256:8c27fe111c11b722081701dfed3bd55e039b9ce92865473cf4cdfa918071c566"},
+				{ID: "example/javascript", Version: "2.0.0", Address: "example.com/some/package@sha256:74eb48882e835d8767f62940d453eb96ed2737de3a16573881dcea7dea769df7"},
+			})
+		})
+
+		it("fails for an unknown registry", func() {
+			_, err := subject.SearchBuildpacks(client.SearchBuildpacksOptions{Term: "ja", RegistryName: "unknown"})
+			h.AssertError(t, err, "registry 'unknown' is not defined in your config file")
+		})
+	})
+
+	when("#BuildpackVersions", func() {
+		it("returns all versions of the buildpack, highest first", func() {
+			buildpacks, err := subject.BuildpackVersions(client.BuildpackVersionsOptions{ID: "example/javascript", RegistryName: "some-registry"})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, buildpacks, []client.RegistryBuildpack{
+				{ID: "example/javascript", Version: "2.1.0", Yanked: true, Address: "example.com/some/package@sha256:2560f05307e8de9d830f144d09556e19dd1eb7d928aee900ed02208ae9727e7a"},
+				{ID: "example/javascript", Version: "2.0.0", Address: "example.com/some/package@sha256:74eb48882e835d8767f62940d453eb96ed2737de3a16573881dcea7dea769df7"},
+			})
+		})
+
+		it("fails for an unknown buildpack", func() {
+			_, err := subject.BuildpackVersions(client.BuildpackVersionsOptions{ID: "example/ruby", RegistryName: "some-registry"})
+			h.AssertError(t, err, "listing versions of 'example/ruby'")
+		})
+	})
+}
@@ -0,0 +1,133 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/buildpacks/imgutil"
+	"github.com/buildpacks/imgutil/remote"
+	"github.com/buildpacks/lifecycle/platform"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/build"
+	"github.com/buildpacks/pack/internal/intoto"
+	"github.com/buildpacks/pack/internal/sign"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/sbom"
+)
+
+// SignOptions configures the signing of published images. Signatures and attestations are stored in the image
+// repository the way cosign stores them, so they can be verified with `cosign verify` and `cosign verify-attestation`.
+type SignOptions struct {
+	// Path to a key created by `cosign generate-key-pair`, or to an unencrypted PEM-encoded PKCS #8 or EC private key.
+	KeyPath string
+
+	// Password of an encrypted cosign key.
+	KeyPassword []byte
+}
+
+func (c *Client) newSigner(opts SignOptions) (*sign.Signer, error) {
+	key, err := sign.LoadPrivateKey(opts.KeyPath, opts.KeyPassword)
+	if err != nil {
+		return nil, err
+	}
+	return sign.NewSigner(key, c.keychain), nil
+}
+
+// signAndAttest signs a published app image and attaches the provenance of its build and its SBOM files as attestations
+func (c *Client) signAndAttest(ctx context.Context, signer *sign.Signer, imageRef name.Reference, lifecycleOpts build.LifecycleOptions, materials provenanceMaterials, startedOn, finishedOn time.Time) error {
+	img, err := c.imageFetcher.Fetch(ctx, imageRef.Name(), image.FetchOptions{Daemon: false, PullPolicy: image.PullNever})
+	if err != nil {
+		return errors.Wrap(err, "fetching built image")
+	}
+	digestRef, err := publishedDigest(img, imageRef)
+	if err != nil {
+		return err
+	}
+
+	if err := signer.SignImage(ctx, digestRef); err != nil {
+		return err
+	}
+
+	subject := intoto.Subject{Name: digestRef.Context().Name(), Digest: map[string]string{"sha256": strings.TrimPrefix(digestRef.DigestStr(), "sha256:")}}
+	provenance, err := c.provenance(img, lifecycleOpts, materials, startedOn, finishedOn)
+	if err != nil {
+		return err
+	}
+	statements := []intoto.Statement{intoto.NewStatement(subject, intoto.ProvenancePredicateType, provenance)}
+
+	sbomStatements, err := sbomStatements(img, subject)
+	if err != nil {
+		return err
+	}
+	statements = append(statements, sbomStatements...)
+
+	if err := signer.Attest(ctx, digestRef, statements...); err != nil {
+		return err
+	}
+
+	c.logger.Infof("Signed %s and attached %d attestation(s)", style.Symbol(digestRef.Name()), len(statements))
+	return nil
+}
+
+// signImage signs a published image
+func (c *Client) signImage(ctx context.Context, signer *sign.Signer, img imgutil.Image, imageRef name.Reference) error {
+	digestRef, err := publishedDigest(img, imageRef)
+	if err != nil {
+		return err
+	}
+	if err := signer.SignImage(ctx, digestRef); err != nil {
+		return err
+	}
+
+	c.logger.Infof("Signed %s", style.Symbol(digestRef.Name()))
+	return nil
+}
+
+func publishedDigest(img imgutil.Image, imageRef name.Reference) (name.Digest, error) {
+	id, err := img.Identifier()
+	if err != nil {
+		return name.Digest{}, errors.Wrapf(err, "reading digest of %s", style.Symbol(imageRef.Name()))
+	}
+	digestID, ok := id.(remote.DigestIdentifier)
+	if !ok {
+		return name.Digest{}, errors.Errorf("image %s has not been published", style.Symbol(imageRef.Name()))
+	}
+	return imageRef.Context().Digest(digestID.Digest.DigestStr()), nil
+}
+
+// sbomStatements returns an attestation statement for each SBOM file in the SBOM layer of an app image, if any
+func sbomStatements(img imgutil.Image, subject intoto.Subject) ([]intoto.Statement, error) {
+	var sbomMD sbomMetadata
+	if _, err := dist.GetLabel(img, platform.LifecycleMetadataLabel, &sbomMD); err != nil {
+		return nil, err
+	}
+	if sbomMD.isMissing() {
+		return nil, nil
+	}
+
+	rc, err := img.GetLayer(sbomMD.BOM.SHA)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading SBOM layer")
+	}
+	defer rc.Close()
+
+	files, err := sbom.ReadFiles(rc)
+	if err != nil {
+		return nil, err
+	}
+
+	var statements []intoto.Statement
+	for _, file := range files {
+		if !json.Valid(file.Contents) {
+			return nil, errors.Errorf("SBOM file %s is not valid JSON", style.Symbol(file.Path))
+		}
+		statements = append(statements, intoto.NewStatement(subject, file.Format.PredicateType(), json.RawMessage(file.Contents)))
+	}
+	return statements, nil
+}
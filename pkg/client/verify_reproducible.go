@@ -0,0 +1,298 @@
+package client
+
+import (
+	"archive/tar"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/buildpacks/imgutil"
+	"github.com/buildpacks/lifecycle/platform"
+	"github.com/buildpacks/lifecycle/platform/files"
+	"github.com/docker/docker/api/types"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/build"
+	strs "github.com/buildpacks/pack/internal/strings"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/cache"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+// LayerDifference is a layer whose diffID differs between two builds of the same app.
+type LayerDifference struct {
+	Index int
+	// Layer describes what the layer holds, e.g. the buildpack and layer name it was exported from.
+	Layer     string
+	OldDiffID string
+	NewDiffID string
+	Files     []FileDifference
+}
+
+// FileDifference is a file whose contents or headers differ between two versions of a layer.
+type FileDifference struct {
+	Path    string
+	Changes []string
+}
+
+type layerFile struct {
+	header tar.Header
+	digest string
+}
+
+// reproducibleBuildOptions returns the lifecycle options of a build to be compared with the previous image. Layers
+// reused from the previous image or restored from the cache would be identical by construction, so the previous
+// image is not passed to the lifecycle and the cache is cleared.
+func (c *Client) reproducibleBuildOptions(lifecycleOpts build.LifecycleOptions, opts BuildOptions) build.LifecycleOptions {
+	if opts.PreviousImage == "" {
+		return lifecycleOpts
+	}
+	c.logger.Debugf("Building without the layers of previous image %s or the cache, to compare with it", style.Symbol(opts.PreviousImage))
+	lifecycleOpts.PreviousImage = ""
+	lifecycleOpts.ClearCache = true
+	return lifecycleOpts
+}
+
+// verifyReproducible compares the built image with the previous image, if any, or with a second build of the app
+// into a throwaway image, and fails when any of their layers differ.
+func (c *Client) verifyReproducible(ctx context.Context, lifecycleOpts build.LifecycleOptions, opts BuildOptions, imageRef name.Reference) error {
+	builtImage, err := c.imageFetcher.Fetch(ctx, imageRef.Name(), image.FetchOptions{Daemon: !opts.Publish, PullPolicy: image.PullNever})
+	if err != nil {
+		return errors.Wrap(err, "fetching built image")
+	}
+
+	var otherImage imgutil.Image
+	if opts.PreviousImage != "" {
+		c.logger.Infof("Comparing %s with previous image %s", style.Symbol(imageRef.Name()), style.Symbol(opts.PreviousImage))
+		otherImage, err = c.imageFetcher.Fetch(ctx, opts.PreviousImage, image.FetchOptions{Daemon: !opts.Publish, PullPolicy: image.PullNever})
+		if err != nil {
+			return errors.Wrapf(err, "fetching previous image %s", style.Symbol(opts.PreviousImage))
+		}
+	} else {
+		c.logger.Infof("Rebuilding %s to verify that the build is reproducible", style.Symbol(imageRef.Name()))
+		verifyRef, err := name.ParseReference(fmt.Sprintf("pack.local/verify-reproducible/%x:latest", randString(10)), name.WeakValidation)
+		if err != nil {
+			return err
+		}
+		defer c.removeVerificationImage(verifyRef)
+
+		verifyOpts := lifecycleOpts
+		verifyOpts.Image = verifyRef
+		verifyOpts.PreviousImage = ""
+		verifyOpts.AdditionalTags = nil
+		verifyOpts.ClearCache = true
+		verifyOpts.Cache = cache.CacheOpts{}
+		verifyOpts.ReportDestinationDir = ""
+		verifyOpts.SBOMDestinationDir = ""
+		if err := c.lifecycleExecutor.Execute(ctx, verifyOpts); err != nil {
+			return fmt.Errorf("rebuilding image: %w", err)
+		}
+
+		otherImage, err = c.imageFetcher.Fetch(ctx, verifyRef.Name(), image.FetchOptions{Daemon: true, PullPolicy: image.PullNever})
+		if err != nil {
+			return errors.Wrap(err, "fetching rebuilt image")
+		}
+	}
+
+	differences, err := c.diffLayers(ctx, otherImage, builtImage)
+	if err != nil {
+		return err
+	}
+	if len(differences) == 0 {
+		c.logger.Infof("Build is reproducible, all layers are identical")
+		return nil
+	}
+
+	for _, difference := range differences {
+		c.logger.Errorf("Layer %d (%s) differs: %s -> %s", difference.Index, difference.Layer, strs.ValueOrDefault(difference.OldDiffID, "(none)"), strs.ValueOrDefault(difference.NewDiffID, "(none)"))
+		for _, file := range difference.Files {
+			c.logger.Errorf("  %s: %s", file.Path, strings.Join(file.Changes, ", "))
+		}
+	}
+	return errors.Errorf("build is not reproducible: %d layer(s) differ", len(differences))
+}
+
+// removeVerificationImage removes the image built to verify reproducibility, along with its cache volumes
+func (c *Client) removeVerificationImage(ref name.Reference) {
+	ctx := context.Background()
+	if _, err := c.docker.ImageRemove(ctx, ref.Name(), types.ImageRemoveOptions{Force: true}); err != nil {
+		c.logger.Debugf("removing image %s: %s", style.Symbol(ref.Name()), err)
+	}
+	for _, cacheType := range []string{"build", "launch", "kaniko"} {
+		volumeCache := cache.NewVolumeCache(ref, cache.CacheInfo{}, cacheType, c.docker)
+		if err := volumeCache.Clear(ctx); err != nil {
+			c.logger.Debugf("removing volume %s: %s", style.Symbol(volumeCache.Name()), err)
+		}
+	}
+}
+
+// diffLayers compares the layers of two builds of an app, by position, reporting the files that differ in
+// each layer whose diffID changed.
+func (c *Client) diffLayers(ctx context.Context, oldImage, newImage imgutil.Image) ([]LayerDifference, error) {
+	oldLayers, err := c.layerDiffIDs(ctx, oldImage)
+	if err != nil {
+		return nil, err
+	}
+	newLayers, err := c.layerDiffIDs(ctx, newImage)
+	if err != nil {
+		return nil, err
+	}
+	descriptions, err := describeLayers(oldImage, newImage)
+	if err != nil {
+		return nil, err
+	}
+
+	var differences []LayerDifference
+	for i := 0; i < len(oldLayers) || i < len(newLayers); i++ {
+		var difference LayerDifference
+		difference.Index = i
+		if i < len(oldLayers) {
+			difference.OldDiffID = oldLayers[i]
+		}
+		if i < len(newLayers) {
+			difference.NewDiffID = newLayers[i]
+		}
+		if difference.OldDiffID == difference.NewDiffID {
+			continue
+		}
+
+		difference.Layer = descriptions[difference.NewDiffID]
+		if difference.Layer == "" {
+			difference.Layer = descriptions[difference.OldDiffID]
+		}
+		if difference.Layer == "" {
+			difference.Layer = "unknown"
+		}
+
+		oldFiles, err := readLayerFiles(oldImage, difference.OldDiffID)
+		if err != nil {
+			return nil, err
+		}
+		newFiles, err := readLayerFiles(newImage, difference.NewDiffID)
+		if err != nil {
+			return nil, err
+		}
+		difference.Files = diffLayerFiles(oldFiles, newFiles)
+
+		differences = append(differences, difference)
+	}
+	return differences, nil
+}
+
+// describeLayers names the layers of app images by what they hold, as recorded in their lifecycle metadata
+func describeLayers(images ...imgutil.Image) (map[string]string, error) {
+	descriptions := map[string]string{}
+	for _, img := range images {
+		var md files.LayersMetadata
+		if _, err := dist.GetLabel(img, platform.LifecycleMetadataLabel, &md); err != nil {
+			return nil, err
+		}
+
+		for i, layer := range md.App {
+			descriptions[layer.SHA] = fmt.Sprintf("app slice %d", i+1)
+		}
+		for _, bp := range md.Buildpacks {
+			for layerName, layer := range bp.Layers {
+				descriptions[layer.SHA] = fmt.Sprintf("buildpack %s, layer %s", style.Symbol(bp.ID), style.Symbol(layerName))
+			}
+		}
+		if md.BOM != nil {
+			descriptions[md.BOM.SHA] = "SBOM"
+		}
+		descriptions[md.Config.SHA] = "config"
+		descriptions[md.Launcher.SHA] = "launcher"
+		descriptions[md.ProcessTypes.SHA] = "process types"
+		delete(descriptions, "")
+	}
+	return descriptions, nil
+}
+
+func readLayerFiles(img imgutil.Image, diffID string) (map[string]layerFile, error) {
+	layerFiles := map[string]layerFile{}
+	if diffID == "" {
+		return layerFiles, nil
+	}
+
+	rc, err := img.GetLayer(diffID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading layer %s of image %s", style.Symbol(diffID), style.Symbol(img.Name()))
+	}
+	defer rc.Close()
+
+	tr := tar.NewReader(rc)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading layer %s of image %s", style.Symbol(diffID), style.Symbol(img.Name()))
+		}
+
+		hash := sha256.New()
+		if _, err := io.Copy(hash, tr); err != nil {
+			return nil, errors.Wrapf(err, "reading %s in layer %s", style.Symbol(header.Name), style.Symbol(diffID))
+		}
+		layerFiles[header.Name] = layerFile{header: *header, digest: fmt.Sprintf("sha256:%x", hash.Sum(nil))}
+	}
+	return layerFiles, nil
+}
+
+func diffLayerFiles(oldFiles, newFiles map[string]layerFile) []FileDifference {
+	paths := map[string]bool{}
+	for path := range oldFiles {
+		paths[path] = true
+	}
+	for path := range newFiles {
+		paths[path] = true
+	}
+
+	var differences []FileDifference
+	for _, path := range sortedKeys(paths) {
+		oldFile, inOld := oldFiles[path]
+		newFile, inNew := newFiles[path]
+
+		var changes []string
+		switch {
+		case !inOld:
+			changes = []string{"added"}
+		case !inNew:
+			changes = []string{"removed"}
+		default:
+			changes = fileChanges(oldFile, newFile)
+		}
+		if len(changes) > 0 {
+			differences = append(differences, FileDifference{Path: path, Changes: changes})
+		}
+	}
+	return differences
+}
+
+func fileChanges(oldFile, newFile layerFile) []string {
+	oldHeader, newHeader := oldFile.header, newFile.header
+
+	var changes []string
+	if oldHeader.Typeflag != newHeader.Typeflag {
+		changes = append(changes, "type differs")
+	}
+	if oldFile.digest != newFile.digest {
+		changes = append(changes, "contents differ")
+	}
+	if oldHeader.Linkname != newHeader.Linkname {
+		changes = append(changes, fmt.Sprintf("link target differs: %s -> %s", oldHeader.Linkname, newHeader.Linkname))
+	}
+	if oldHeader.Mode != newHeader.Mode {
+		changes = append(changes, fmt.Sprintf("mode differs: %#o -> %#o", oldHeader.Mode, newHeader.Mode))
+	}
+	if oldHeader.Uid != newHeader.Uid || oldHeader.Gid != newHeader.Gid {
+		changes = append(changes, fmt.Sprintf("owner differs: %d:%d -> %d:%d", oldHeader.Uid, oldHeader.Gid, newHeader.Uid, newHeader.Gid))
+	}
+	if !oldHeader.ModTime.Equal(newHeader.ModTime) {
+		changes = append(changes, fmt.Sprintf("modification time differs: %s -> %s", oldHeader.ModTime.UTC(), newHeader.ModTime.UTC()))
+	}
+	return changes
+}
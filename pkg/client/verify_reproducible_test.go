@@ -0,0 +1,159 @@
+package client
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/build"
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestVerifyReproducible(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "VerifyReproducible", testVerifyReproducible, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testVerifyReproducible(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		mockImageFetcher *testmocks.MockImageFetcher
+		mockDockerClient *testmocks.MockCommonAPIClient
+		mockController   *gomock.Controller
+		fakeLifecycle    *ifakes.FakeLifecycle
+		builtImage       *testmocks.MockImage
+		imageRef         name.Reference
+		out              bytes.Buffer
+		assert           = h.NewAssertionManager(t)
+		epoch            = time.Date(1980, time.January, 1, 0, 0, 1, 0, time.UTC)
+	)
+
+	// addLayer adds a layer holding files, all modified at modTime, to an image
+	addLayer := func(img *testmocks.MockImage, diffID string, modTime time.Time, files map[string]string) {
+		layerPath := filepath.Join(t.TempDir(), "layer.tar")
+		f, err := os.Create(layerPath)
+		assert.Nil(err)
+		tw := tar.NewWriter(f)
+		for _, fileName := range sortedKeys(files) {
+			assert.Nil(tw.WriteHeader(&tar.Header{Name: fileName, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(files[fileName])), ModTime: modTime}))
+			_, err := tw.Write([]byte(files[fileName]))
+			assert.Nil(err)
+		}
+		assert.Nil(tw.Close())
+		assert.Nil(f.Close())
+		assert.Nil(img.AddLayerWithDiffID(layerPath, diffID))
+	}
+
+	newAppImage := func(name string, layers ...string) *testmocks.MockImage {
+		img := testmocks.NewImage(name, "", nil)
+		assert.Nil(img.SetLabel("io.buildpacks.lifecycle.metadata", `{
+  "app": [{"sha": "sha256:app-1"}],
+  "buildpacks": [{"key": "some-buildpack", "layers": {"some-layer": {"sha": "sha256:layer-1"}}}]
+}`))
+		mockDockerClient.EXPECT().
+			ImageInspectWithRaw(gomock.Any(), name).
+			Return(types.ImageInspect{RootFS: types.RootFS{Layers: layers}}, nil, nil).
+			AnyTimes()
+		return img
+	}
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockImageFetcher = testmocks.NewMockImageFetcher(mockController)
+		mockDockerClient = testmocks.NewMockCommonAPIClient(mockController)
+		fakeLifecycle = &ifakes.FakeLifecycle{}
+
+		var err error
+		subject, err = NewClient(WithLogger(logging.NewLogWithWriters(&out, &out)), WithFetcher(mockImageFetcher), WithDockerClient(mockDockerClient))
+		assert.Nil(err)
+		subject.lifecycleExecutor = fakeLifecycle
+
+		imageRef, err = name.ParseReference("some/app")
+		assert.Nil(err)
+		builtImage = newAppImage("some/app", "sha256:run-1", "sha256:layer-1", "sha256:app-1")
+		addLayer(builtImage, "sha256:layer-1", epoch, map[string]string{"layers/some-buildpack/some-layer/file": "some-contents"})
+		addLayer(builtImage, "sha256:app-1", epoch, map[string]string{"workspace/app.js": "some-app"})
+		mockImageFetcher.EXPECT().
+			Fetch(gomock.Any(), "index.docker.io/some/app:latest", image.FetchOptions{Daemon: true, PullPolicy: image.PullNever}).
+			Return(builtImage, nil)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("a previous image is provided", func() {
+		var previousImage *testmocks.MockImage
+
+		it.Before(func() {
+			previousImage = newAppImage("some/previous-app", "sha256:run-1", "sha256:layer-2", "sha256:app-1")
+			addLayer(previousImage, "sha256:layer-2", epoch.Add(time.Hour), map[string]string{
+				"layers/some-buildpack/some-layer/file":  "other-contents",
+				"layers/some-buildpack/some-layer/cache": "some-cache",
+			})
+			mockImageFetcher.EXPECT().
+				Fetch(gomock.Any(), "some/previous-app", image.FetchOptions{Daemon: true, PullPolicy: image.PullNever}).
+				Return(previousImage, nil)
+		})
+
+		it("reports the layers and files that differ", func() {
+			err := subject.verifyReproducible(context.TODO(), build.LifecycleOptions{}, BuildOptions{PreviousImage: "some/previous-app"}, imageRef)
+			assert.ErrorWithMessage(err, "build is not reproducible: 1 layer(s) differ")
+
+			assert.Contains(out.String(), "Layer 1 (buildpack 'some-buildpack', layer 'some-layer') differs: sha256:layer-2 -> sha256:layer-1")
+			assert.Contains(out.String(), "layers/some-buildpack/some-layer/cache: removed")
+			assert.Contains(out.String(), "layers/some-buildpack/some-layer/file: contents differ, modification time differs: 1980-01-01 01:00:01 +0000 UTC -> 1980-01-01 00:00:01 +0000 UTC")
+			assert.Equal(fakeLifecycle.ExecuteCallCount, 0)
+		})
+
+		it("builds without the previous image and from a clean cache", func() {
+			opts := BuildOptions{PreviousImage: "some/previous-app"}
+			lifecycleOpts := subject.reproducibleBuildOptions(build.LifecycleOptions{Image: imageRef, PreviousImage: "some/previous-app"}, opts)
+			assert.Equal(lifecycleOpts.PreviousImage, "")
+			assert.Equal(lifecycleOpts.ClearCache, true)
+
+			err := subject.verifyReproducible(context.TODO(), lifecycleOpts, opts, imageRef)
+			assert.ErrorWithMessage(err, "build is not reproducible: 1 layer(s) differ")
+		})
+	})
+
+	when("no previous image is provided", func() {
+		it("rebuilds the image from a clean cache and compares it", func() {
+			mockImageFetcher.EXPECT().
+				Fetch(gomock.Any(), gomock.Any(), image.FetchOptions{Daemon: true, PullPolicy: image.PullNever}).
+				DoAndReturn(func(_ context.Context, name string, _ image.FetchOptions) (*testmocks.MockImage, error) {
+					rebuiltImage := newAppImage(name, "sha256:run-1", "sha256:layer-1", "sha256:app-1")
+					addLayer(rebuiltImage, "sha256:layer-1", epoch, map[string]string{"layers/some-buildpack/some-layer/file": "some-contents"})
+					return rebuiltImage, nil
+				})
+			mockDockerClient.EXPECT().ImageRemove(gomock.Any(), gomock.Any(), types.ImageRemoveOptions{Force: true})
+			mockDockerClient.EXPECT().VolumeRemove(gomock.Any(), gomock.Any(), true).Times(3)
+
+			lifecycleOpts := build.LifecycleOptions{Image: imageRef, PreviousImage: "some/app", AdditionalTags: []string{"some/app:v1"}}
+			assert.Nil(subject.verifyReproducible(context.TODO(), lifecycleOpts, BuildOptions{}, imageRef))
+
+			assert.Contains(out.String(), "Build is reproducible, all layers are identical")
+			assert.Equal(fakeLifecycle.ExecuteCallCount, 1)
+			assert.Contains(fakeLifecycle.Opts.Image.Name(), "pack.local/verify-reproducible/")
+			assert.Equal(fakeLifecycle.Opts.PreviousImage, "")
+			assert.Equal(fakeLifecycle.Opts.ClearCache, true)
+			assert.Equal(len(fakeLifecycle.Opts.AdditionalTags), 0)
+		})
+	})
+}
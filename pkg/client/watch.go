@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/internal/watch"
+)
+
+var (
+	// how often the app directory is scanned for changes in watch mode
+	watchPollInterval = 500 * time.Millisecond
+	// how long changes must settle before a rebuild starts, so that saving many files triggers a single build
+	watchDebounce = time.Second
+)
+
+// watchAndRebuild runs build, then runs it again whenever the files in appPath accepted by fileFilter change.
+// Failed builds are reported without stopping the watch, which ends when the context is cancelled.
+func (c *Client) watchAndRebuild(ctx context.Context, appPath string, fileFilter func(string) bool, build func() error) error {
+	// take the snapshot first, so that changes made during the first build trigger a rebuild
+	watcher, err := watch.NewWatcher(appPath, fileFilter, watchPollInterval, watchDebounce)
+	if err != nil {
+		return errors.Wrapf(err, "watching %s", style.Symbol(appPath))
+	}
+
+	for {
+		start := time.Now()
+		if err := build(); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			c.logger.Errorf("Build failed after %s: %s", time.Since(start).Round(time.Millisecond), err)
+		} else {
+			c.logger.Infof("Build succeeded in %s", time.Since(start).Round(time.Millisecond))
+		}
+
+		c.logger.Infof("Watching %s for changes...", style.Symbol(appPath))
+		changed, err := watcher.Next(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return errors.Wrapf(err, "watching %s", style.Symbol(appPath))
+		}
+
+		c.logger.Infof("Detected changes to %s, rebuilding", describeChanges(changed))
+	}
+}
+
+// watchFilter returns fileFilter without the paths within appPath that the build writes to, such as its report and
+// SBOM output directories, so that writing them doesn't start another build.
+func watchFilter(appPath string, fileFilter func(string) bool, outputPaths ...string) func(string) bool {
+	var excluded []string
+	for _, outputPath := range outputPaths {
+		if outputPath == "" {
+			continue
+		}
+		relPath, err := filepath.Rel(appPath, resolvePath(outputPath))
+		if err != nil || relPath == "." || relPath == ".." || strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+			continue
+		}
+		excluded = append(excluded, relPath)
+	}
+
+	return func(path string) bool {
+		for _, relPath := range excluded {
+			if path == relPath || strings.HasPrefix(path, relPath+string(filepath.Separator)) {
+				return false
+			}
+		}
+		return fileFilter == nil || fileFilter(path)
+	}
+}
+
+// resolvePath returns the absolute path of path with symlinks evaluated like the app path, as far as it exists.
+func resolvePath(path string) string {
+	if absPath, err := filepath.Abs(path); err == nil {
+		path = absPath
+	}
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+	if resolvedDir, err := filepath.EvalSymlinks(filepath.Dir(path)); err == nil {
+		return filepath.Join(resolvedDir, filepath.Base(path))
+	}
+	return path
+}
+
+func describeChanges(paths []string) string {
+	const maxListed = 3
+	if len(paths) <= maxListed {
+		return strings.Join(paths, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(paths[:maxListed], ", "), len(paths)-maxListed)
+}
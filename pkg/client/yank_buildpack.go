@@ -1,10 +1,12 @@
 package client
 
 import (
+	"context"
 	"net/url"
 	"runtime"
 
 	"github.com/buildpacks/pack/internal/registry"
+	registryTypes "github.com/buildpacks/pack/registry"
 )
 
 // YankBuildpackOptions is a configuration struct that controls the Yanking a buildpack
@@ -24,10 +26,6 @@ func (c *Client) YankBuildpack(opts YankBuildpackOptions) error {
 	if err != nil {
 		return err
 	}
-	issueURL, err := registry.GetIssueURL(opts.URL)
-	if err != nil {
-		return err
-	}
 
 	buildpack := registry.Buildpack{
 		Namespace: namespace,
@@ -36,6 +34,20 @@ func (c *Client) YankBuildpack(opts YankBuildpackOptions) error {
 		Yanked:    opts.Yank,
 	}
 
+	if opts.Type == registryTypes.TypeOCI {
+		index, err := registry.NewOCIIndex(c.logger, opts.URL, c.keychain)
+		if err != nil {
+			return err
+		}
+
+		return index.Yank(context.Background(), buildpack)
+	}
+
+	issueURL, err := registry.GetIssueURL(opts.URL)
+	if err != nil {
+		return err
+	}
+
 	issue, err := registry.CreateGithubIssue(buildpack)
 	if err != nil {
 		return err
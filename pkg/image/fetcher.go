@@ -54,10 +54,11 @@ type DockerClient interface {
 }
 
 type Fetcher struct {
-	docker          DockerClient
-	logger          logging.Logger
-	registryMirrors map[string]string
-	keychain        authn.Keychain
+	docker               DockerClient
+	logger               logging.Logger
+	registryMirrors      map[string]string
+	keychain             authn.Keychain
+	verificationPolicies []VerificationPolicy
 }
 
 type FetchOptions struct {
@@ -84,17 +85,29 @@ func NewFetcher(logger logging.Logger, docker DockerClient, opts ...FetcherOptio
 var ErrNotFound = errors.New("not found")
 
 func (f *Fetcher) Fetch(ctx context.Context, name string, options FetchOptions) (imgutil.Image, error) {
-	name, err := pname.TranslateRegistry(name, f.registryMirrors, f.logger)
+	translatedName, err := pname.TranslateRegistry(name, f.registryMirrors, f.logger)
 	if err != nil {
 		return nil, err
 	}
 
+	img, err := f.fetch(ctx, translatedName, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := f.verify(ctx, name, translatedName, img); err != nil {
+		return nil, err
+	}
+	return img, nil
+}
+
+func (f *Fetcher) fetch(ctx context.Context, name string, options FetchOptions) (imgutil.Image, error) {
 	if (options.LayoutOption != LayoutOption{}) {
 		return f.fetchLayoutImage(name, options.LayoutOption)
 	}
 
 	if !options.Daemon {
-		return f.fetchRemoteImage(name)
+		return f.fetchRemoteImage(name, options.Platform)
 	}
 
 	switch options.PullPolicy {
@@ -103,13 +116,16 @@ func (f *Fetcher) Fetch(ctx context.Context, name string, options FetchOptions)
 		return img, err
 	case PullIfNotPresent:
 		img, err := f.fetchDaemonImage(name)
-		if err == nil || !errors.Is(err, ErrNotFound) {
+		if err == nil && !matchesPlatform(img, options.Platform) {
+			f.logger.Debugf("Image %s does not match platform %s", style.Symbol(name), style.Symbol(options.Platform))
+		} else if err == nil || !errors.Is(err, ErrNotFound) {
 			return img, err
 		}
 	}
 
 	f.logger.Debugf("Pulling image %s", style.Symbol(name))
-	if err = f.pullImage(ctx, name, options.Platform); err != nil {
+	err := f.pullImage(ctx, name, options.Platform)
+	if err != nil {
 		// sample error from docker engine:
 		// image with reference <image> was found but does not match the specified platform: wanted linux/amd64, actual: linux
 		if strings.Contains(err.Error(), "does not match the specified platform") {
@@ -136,8 +152,40 @@ func (f *Fetcher) fetchDaemonImage(name string) (imgutil.Image, error) {
 	return image, nil
 }
 
-func (f *Fetcher) fetchRemoteImage(name string) (imgutil.Image, error) {
-	image, err := remote.NewImage(name, f.keychain, remote.FromBaseImage(name))
+// matchesPlatform reports whether the image's OS and architecture match the given 'os/arch[/variant]' platform.
+// An empty platform matches every image.
+func matchesPlatform(img imgutil.Image, platform string) bool {
+	if platform == "" {
+		return true
+	}
+
+	os, arch := splitPlatform(platform)
+	imgOS, err := img.OS()
+	if err != nil {
+		return true
+	}
+	imgArch, err := img.Architecture()
+	if err != nil {
+		return true
+	}
+	return imgOS == os && (arch == "" || imgArch == arch)
+}
+
+func splitPlatform(platform string) (os, arch string) {
+	os, arch, _ = strings.Cut(platform, "/")
+	arch, _, _ = strings.Cut(arch, "/")
+	return os, arch
+}
+
+func (f *Fetcher) fetchRemoteImage(name string, platform string) (imgutil.Image, error) {
+	opts := []remote.ImageOption{remote.FromBaseImage(name)}
+	if platform != "" {
+		// when name refers to an image index, the manifest matching the platform is selected
+		os, arch := splitPlatform(platform)
+		opts = append(opts, remote.WithDefaultPlatform(imgutil.Platform{OS: os, Architecture: arch}))
+	}
+
+	image, err := remote.NewImage(name, f.keychain, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,196 @@
+package image
+
+import (
+	"context"
+	"crypto/ecdsa"
+
+	"github.com/buildpacks/imgutil"
+	"github.com/buildpacks/imgutil/layout"
+	"github.com/buildpacks/imgutil/local"
+	"github.com/buildpacks/imgutil/remote"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1remote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/sign"
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// VerificationPolicy restricts the images of a repository that can be fetched, such as the images of a builder or
+// buildpack, to those signed with one of the given keys and to pinned digests. When both are given, images must
+// satisfy both.
+type VerificationPolicy struct {
+	// Image is the repository the policy applies to, whatever the tag or digest, e.g. "paketobuildpacks/builder-jammy-base".
+	Image string
+
+	// URI is the buildpack archive the policy applies to instead of an image, as an absolute URI, e.g.
+	// "https://example.com/my-buildpack.tgz" or "file:///buildpacks/my-buildpack". Archives are verified by the
+	// buildpack downloader, and can only be pinned to digests.
+	URI string
+
+	// PublicKeys are paths to PEM-encoded ECDSA public keys, one of which must have made a cosign signature of the image.
+	PublicKeys []string
+
+	// Digests are the digests the image is allowed to have in its repository, which for multi-platform images is
+	// the digest of the image index. For a buildpack archive, they are the SHA-256 digests of its uncompressed tar,
+	// e.g. "sha256:<hex>".
+	Digests []string
+}
+
+// WithVerificationPolicies refuses images that don't satisfy the policy for their repository, if any.
+func WithVerificationPolicies(policies []VerificationPolicy) FetcherOption {
+	return func(c *Fetcher) {
+		c.verificationPolicies = policies
+	}
+}
+
+// verify checks a fetched image against the verification policy for the repository it was fetched by, if any.
+// Its signatures are looked up where it was fetched from, which is a mirror when translatedName differs from name.
+func (f *Fetcher) verify(ctx context.Context, name, translatedName string, img imgutil.Image) error {
+	policy, err := f.verificationPolicy(name)
+	if err != nil || policy == nil {
+		return err
+	}
+
+	ref, err := f.imageDigest(ctx, translatedName, img)
+	if err != nil {
+		return err
+	}
+	if ref == nil {
+		return errors.Errorf("image %s cannot be verified as it has no digest, it must be pulled from a registry", style.Symbol(name))
+	}
+
+	if len(policy.Digests) > 0 && !contains(policy.Digests, ref.DigestStr()) {
+		return errors.Errorf("image %s has digest %s, which is not pinned by its verification policy", style.Symbol(name), style.Symbol(ref.DigestStr()))
+	}
+
+	if len(policy.PublicKeys) > 0 {
+		var keys []*ecdsa.PublicKey
+		for _, path := range policy.PublicKeys {
+			key, err := sign.LoadPublicKey(path)
+			if err != nil {
+				return errors.Wrapf(err, "loading verification policy for %s", style.Symbol(policy.Image))
+			}
+			keys = append(keys, key)
+		}
+		if err := sign.VerifyImage(ctx, *ref, keys, f.keychain); err != nil {
+			return errors.Wrapf(err, "verifying %s", style.Symbol(name))
+		}
+	}
+
+	f.logger.Debugf("Verified %s as %s", style.Symbol(name), style.Symbol(ref.DigestStr()))
+	return nil
+}
+
+// verificationPolicy returns the policy for the repository of an image, if any
+func (f *Fetcher) verificationPolicy(imageName string) (*VerificationPolicy, error) {
+	if len(f.verificationPolicies) == 0 {
+		return nil, nil
+	}
+
+	ref, err := name.ParseReference(imageName, name.WeakValidation)
+	if err != nil {
+		return nil, err
+	}
+	for i, policy := range f.verificationPolicies {
+		if policy.Image == "" {
+			continue
+		}
+		repo, err := name.NewRepository(policy.Image, name.WeakValidation)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid image %s in verification policy", style.Symbol(policy.Image))
+		}
+		if repo.Name() == ref.Context().Name() {
+			if len(policy.PublicKeys) == 0 && len(policy.Digests) == 0 {
+				return nil, errors.Errorf("verification policy for %s requires public keys or digests", style.Symbol(policy.Image))
+			}
+			return &f.verificationPolicies[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// imageDigest returns the digest that name refers to in its repository, which is the digest of an image index for
+// multi-platform images, as signed by cosign and pinned by policies. It is nil for daemon images that were never
+// pulled from or pushed to the repository they are named after.
+func (f *Fetcher) imageDigest(ctx context.Context, imageName string, img imgutil.Image) (*name.Digest, error) {
+	ref, err := name.ParseReference(imageName, name.WeakValidation)
+	if err != nil {
+		return nil, err
+	}
+	id, err := img.Identifier()
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading digest of %s", style.Symbol(imageName))
+	}
+
+	var digest string
+	switch id := id.(type) {
+	case remote.DigestIdentifier:
+		// remote images are identified by the manifest of their platform
+		if digest, err = RepositoryDigest(ctx, f.keychain, ref, id.Digest.DigestStr()); err != nil {
+			return nil, err
+		}
+	case layout.Identifier:
+		digest = id.Digest
+	case local.IDIdentifier:
+		// the daemon records the digest images were pulled by
+		inspect, _, err := f.docker.ImageInspectWithRaw(ctx, imageName)
+		if err != nil {
+			return nil, errors.Wrapf(err, "inspecting %s", style.Symbol(imageName))
+		}
+		for _, repoDigest := range inspect.RepoDigests {
+			if digestRef, err := name.NewDigest(repoDigest, name.WeakValidation); err == nil && digestRef.Context().Name() == ref.Context().Name() {
+				digest = digestRef.DigestStr()
+			}
+		}
+	}
+	if digest == "" {
+		return nil, nil
+	}
+
+	digestRef := ref.Context().Digest(digest)
+	return &digestRef, nil
+}
+
+// RepositoryDigest returns the digest that ref refers to in its registry, given the digest of the manifest of an
+// image fetched by ref. For a multi-platform image, this is the digest of the image index listing the manifest. An
+// error is returned when ref no longer refers to the manifest, e.g. because its tag moved after the image was fetched.
+func RepositoryDigest(ctx context.Context, keychain authn.Keychain, ref name.Reference, manifestDigest string) (string, error) {
+	if ref.Identifier() == manifestDigest {
+		return manifestDigest, nil
+	}
+
+	desc, err := v1remote.Get(ref, v1remote.WithContext(ctx), v1remote.WithAuthFromKeychain(keychain))
+	if err != nil {
+		return "", errors.Wrapf(err, "resolving digest of %s", style.Symbol(ref.Name()))
+	}
+	if desc.Digest.String() == manifestDigest {
+		return manifestDigest, nil
+	}
+	if desc.MediaType.IsIndex() {
+		index, err := desc.ImageIndex()
+		if err != nil {
+			return "", errors.Wrapf(err, "reading index of %s", style.Symbol(ref.Name()))
+		}
+		indexManifest, err := index.IndexManifest()
+		if err != nil {
+			return "", errors.Wrapf(err, "reading index of %s", style.Symbol(ref.Name()))
+		}
+		for _, manifest := range indexManifest.Manifests {
+			if manifest.Digest.String() == manifestDigest {
+				return desc.Digest.String(), nil
+			}
+		}
+	}
+	return "", errors.Errorf("image %s changed in the registry while being fetched", style.Symbol(ref.Name()))
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,228 @@
+package image_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/sign"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestVerification(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Verification", testVerification, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testVerification(t *testing.T, when spec.G, it spec.S) {
+	var (
+		server    *httptest.Server
+		repoName  string
+		digestRef name.Digest
+		key       *ecdsa.PrivateKey
+		keyPath   string
+		outBuf    bytes.Buffer
+	)
+
+	it.Before(func() {
+		server = httptest.NewServer(registry.New())
+		repoName = strings.TrimPrefix(server.URL, "http://") + "/some/builder:latest"
+
+		img, err := random.Image(10, 1)
+		h.AssertNil(t, err)
+		img, err = mutate.ConfigFile(img, &v1.ConfigFile{OS: "linux", Architecture: "amd64"})
+		h.AssertNil(t, err)
+		tag, err := name.NewTag(repoName)
+		h.AssertNil(t, err)
+		h.AssertNil(t, remote.Write(tag, img))
+		digest, err := img.Digest()
+		h.AssertNil(t, err)
+		digestRef = tag.Context().Digest(digest.String())
+
+		key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		h.AssertNil(t, err)
+		der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+		h.AssertNil(t, err)
+		keyPath = filepath.Join(t.TempDir(), "cosign.pub")
+		h.AssertNil(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600))
+	})
+
+	it.After(func() {
+		server.Close()
+	})
+
+	fetch := func(policies ...image.VerificationPolicy) error {
+		fetcher := image.NewFetcher(logging.NewLogWithWriters(&outBuf, &outBuf), nil, image.WithVerificationPolicies(policies))
+		_, err := fetcher.Fetch(context.TODO(), repoName, image.FetchOptions{Daemon: false, PullPolicy: image.PullAlways})
+		return err
+	}
+
+	when("#Fetch", func() {
+		when("the image has no verification policy", func() {
+			it("returns the image", func() {
+				h.AssertNil(t, fetch(image.VerificationPolicy{Image: "some-other/builder", Digests: []string{"sha256:" + strings.Repeat("a", 64)}}))
+			})
+		})
+
+		when("the policy pins digests", func() {
+			it("returns the image when its digest is pinned", func() {
+				h.AssertNil(t, fetch(image.VerificationPolicy{Image: digestRef.Context().Name(), Digests: []string{digestRef.DigestStr()}}))
+			})
+
+			it("refuses the image when its digest is not pinned", func() {
+				err := fetch(image.VerificationPolicy{Image: digestRef.Context().Name(), Digests: []string{"sha256:" + strings.Repeat("a", 64)}})
+				h.AssertError(t, err, "which is not pinned by its verification policy")
+			})
+		})
+
+		when("the policy requires public keys", func() {
+			it("returns the image when it was signed with one of the keys", func() {
+				h.AssertNil(t, sign.NewSigner(key, authn.DefaultKeychain).SignImage(context.TODO(), digestRef))
+
+				h.AssertNil(t, fetch(image.VerificationPolicy{Image: digestRef.Context().Name(), PublicKeys: []string{keyPath}}))
+			})
+
+			it("refuses the image when it is not signed", func() {
+				err := fetch(image.VerificationPolicy{Image: digestRef.Context().Name(), PublicKeys: []string{keyPath}})
+				h.AssertError(t, err, "is not signed")
+			})
+
+			it("refuses the image when it was signed with another key", func() {
+				otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+				h.AssertNil(t, err)
+				h.AssertNil(t, sign.NewSigner(otherKey, authn.DefaultKeychain).SignImage(context.TODO(), digestRef))
+
+				err = fetch(image.VerificationPolicy{Image: digestRef.Context().Name(), PublicKeys: []string{keyPath}})
+				h.AssertError(t, err, "has no valid signature for the given public keys")
+			})
+		})
+
+		when("the image is multi-platform", func() {
+			var (
+				indexName   string
+				indexRef    name.Digest
+				manifestRef name.Digest
+			)
+
+			it.Before(func() {
+				indexName = strings.TrimPrefix(server.URL, "http://") + "/some/builder:multi-platform"
+
+				img, err := random.Image(10, 1)
+				h.AssertNil(t, err)
+				img, err = mutate.ConfigFile(img, &v1.ConfigFile{OS: "linux", Architecture: "amd64"})
+				h.AssertNil(t, err)
+				index := mutate.AppendManifests(empty.Index, mutate.IndexAddendum{
+					Add:        img,
+					Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "linux", Architecture: "amd64"}},
+				})
+				tag, err := name.NewTag(indexName)
+				h.AssertNil(t, err)
+				h.AssertNil(t, remote.WriteIndex(tag, index))
+
+				indexDigest, err := index.Digest()
+				h.AssertNil(t, err)
+				indexRef = tag.Context().Digest(indexDigest.String())
+				manifestDigest, err := img.Digest()
+				h.AssertNil(t, err)
+				manifestRef = tag.Context().Digest(manifestDigest.String())
+			})
+
+			fetchIndex := func(policies ...image.VerificationPolicy) error {
+				fetcher := image.NewFetcher(logging.NewLogWithWriters(&outBuf, &outBuf), nil, image.WithVerificationPolicies(policies))
+				_, err := fetcher.Fetch(context.TODO(), indexName, image.FetchOptions{Daemon: false, PullPolicy: image.PullAlways, Platform: "linux/amd64"})
+				return err
+			}
+
+			it("checks the digest of the index", func() {
+				h.AssertNil(t, fetchIndex(image.VerificationPolicy{Image: indexRef.Context().Name(), Digests: []string{indexRef.DigestStr()}}))
+
+				err := fetchIndex(image.VerificationPolicy{Image: indexRef.Context().Name(), Digests: []string{manifestRef.DigestStr()}})
+				h.AssertError(t, err, "which is not pinned by its verification policy")
+			})
+
+			it("checks the signature of the index", func() {
+				h.AssertNil(t, sign.NewSigner(key, authn.DefaultKeychain).SignImage(context.TODO(), indexRef))
+
+				h.AssertNil(t, fetchIndex(image.VerificationPolicy{Image: indexRef.Context().Name(), PublicKeys: []string{keyPath}}))
+			})
+		})
+
+		when("the image is in the daemon", func() {
+			var (
+				mockController   *gomock.Controller
+				mockDockerClient *testmocks.MockCommonAPIClient
+			)
+
+			it.Before(func() {
+				mockController = gomock.NewController(t)
+				mockDockerClient = testmocks.NewMockCommonAPIClient(mockController)
+				mockDockerClient.EXPECT().ServerVersion(gomock.Any()).Return(types.Version{Os: "linux", Arch: "amd64"}, nil).AnyTimes()
+				mockDockerClient.EXPECT().ImageHistory(gomock.Any(), repoName).Return(nil, nil).AnyTimes()
+			})
+
+			it.After(func() {
+				mockController.Finish()
+			})
+
+			fetchDaemon := func(repoDigests []string, policies ...image.VerificationPolicy) error {
+				mockDockerClient.EXPECT().
+					ImageInspectWithRaw(gomock.Any(), repoName).
+					Return(types.ImageInspect{ID: "sha256:" + strings.Repeat("b", 64), Os: "linux", Architecture: "amd64", RepoDigests: repoDigests}, nil, nil).
+					AnyTimes()
+
+				fetcher := image.NewFetcher(logging.NewLogWithWriters(&outBuf, &outBuf), mockDockerClient, image.WithVerificationPolicies(policies))
+				_, err := fetcher.Fetch(context.TODO(), repoName, image.FetchOptions{Daemon: true, PullPolicy: image.PullNever})
+				return err
+			}
+
+			it("checks the digest the image was pulled by", func() {
+				h.AssertNil(t, sign.NewSigner(key, authn.DefaultKeychain).SignImage(context.TODO(), digestRef))
+
+				h.AssertNil(t, fetchDaemon([]string{digestRef.String()}, image.VerificationPolicy{
+					Image:      digestRef.Context().Name(),
+					Digests:    []string{digestRef.DigestStr()},
+					PublicKeys: []string{keyPath},
+				}))
+			})
+
+			it("refuses images that were never pulled", func() {
+				err := fetchDaemon(nil, image.VerificationPolicy{Image: digestRef.Context().Name(), Digests: []string{digestRef.DigestStr()}})
+				h.AssertError(t, err, "cannot be verified as it has no digest")
+			})
+		})
+
+		when("the policy has neither public keys nor digests", func() {
+			it("returns an error", func() {
+				err := fetch(image.VerificationPolicy{Image: digestRef.Context().Name()})
+				h.AssertError(t, err, "requires public keys or digests")
+			})
+		})
+	})
+}
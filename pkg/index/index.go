@@ -0,0 +1,453 @@
+package index
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/buildpacks/imgutil/layout"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+const indexFileName = "index.json"
+
+// ErrNotFound is returned when an index is not present in local storage.
+var ErrNotFound = errors.New("not found")
+
+// Options are the settings used when creating a new index.
+type Options struct {
+	// Format is the media type of the index, either an OCI image index or a Docker manifest list.
+	Format types.MediaType
+
+	// Insecure allows the index and its manifests to be fetched from and pushed to insecure registries.
+	Insecure bool
+}
+
+// Platform holds the fields of a manifest descriptor that can be annotated.
+type Platform struct {
+	OS          string
+	Arch        string
+	Variant     string
+	OSVersion   string
+	Features    []string
+	Annotations map[string]string
+}
+
+// Index is an OCI image index or Docker manifest list persisted locally so it can be
+// built up across several invocations before being pushed to a registry.
+type Index struct {
+	name     string
+	path     string
+	keychain authn.Keychain
+	record   record
+}
+
+// record is the on-disk representation of an Index.
+type record struct {
+	Insecure bool              `json:"insecure,omitempty"`
+	Manifest v1.IndexManifest  `json:"manifest"`
+	Sources  map[string]string `json:"sources,omitempty"` // digest -> repository the manifest was added from
+}
+
+// Factory creates, loads and fetches indexes stored below a root directory.
+type Factory struct {
+	root     string
+	keychain authn.Keychain
+}
+
+// NewFactory returns a Factory storing indexes below root.
+func NewFactory(root string, keychain authn.Keychain) *Factory {
+	if keychain == nil {
+		keychain = authn.DefaultKeychain
+	}
+	return &Factory{root: root, keychain: keychain}
+}
+
+// Exists reports whether an index named repoName is present in local storage.
+func (f *Factory) Exists(repoName string) bool {
+	path, err := f.pathFor(repoName)
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(filepath.Join(path, indexFileName))
+	return err == nil
+}
+
+// CreateIndex initializes an empty index named repoName. It is persisted once Save is called.
+func (f *Factory) CreateIndex(repoName string, opts Options) (*Index, error) {
+	if f.Exists(repoName) {
+		return nil, errors.Errorf("index %s already exists", style.Symbol(repoName))
+	}
+
+	path, err := f.pathFor(repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	format := opts.Format
+	if format == "" {
+		format = types.OCIImageIndex
+	}
+	if !format.IsIndex() {
+		return nil, errors.Errorf("unsupported index media type %s", style.Symbol(string(format)))
+	}
+
+	return &Index{
+		name:     repoName,
+		path:     path,
+		keychain: f.keychain,
+		record: record{
+			Insecure: opts.Insecure,
+			Manifest: v1.IndexManifest{
+				SchemaVersion: 2,
+				MediaType:     format,
+				Manifests:     []v1.Descriptor{},
+			},
+			Sources: map[string]string{},
+		},
+	}, nil
+}
+
+// LoadIndex reads the index named repoName from local storage.
+func (f *Factory) LoadIndex(repoName string) (*Index, error) {
+	path, err := f.pathFor(repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	contents, err := os.ReadFile(filepath.Join(path, indexFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.Wrapf(ErrNotFound, "index %s does not exist in local storage", style.Symbol(repoName))
+		}
+		return nil, errors.Wrapf(err, "reading index %s", style.Symbol(repoName))
+	}
+
+	idx := &Index{name: repoName, path: path, keychain: f.keychain}
+	if err := json.Unmarshal(contents, &idx.record); err != nil {
+		return nil, errors.Wrapf(err, "parsing index %s", style.Symbol(repoName))
+	}
+	if idx.record.Sources == nil {
+		idx.record.Sources = map[string]string{}
+	}
+	return idx, nil
+}
+
+// FetchIndex reads the index named repoName from its registry without persisting it.
+func (f *Factory) FetchIndex(ctx context.Context, repoName string, insecure bool) (*Index, error) {
+	ref, err := name.ParseReference(repoName, nameOptions(insecure)...)
+	if err != nil {
+		return nil, err
+	}
+
+	desc, err := remote.Get(ref, remote.WithAuthFromKeychain(f.keychain), remote.WithContext(ctx))
+	if err != nil {
+		return nil, errors.Wrapf(err, "fetching %s", style.Symbol(repoName))
+	}
+	if !desc.MediaType.IsIndex() {
+		return nil, errors.Errorf("%s is not an image index", style.Symbol(repoName))
+	}
+
+	manifest, err := v1.ParseIndexManifest(bytes.NewReader(desc.Manifest))
+	if err != nil {
+		return nil, err
+	}
+
+	path, err := f.pathFor(repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	sources := map[string]string{}
+	for _, m := range manifest.Manifests {
+		sources[m.Digest.String()] = ref.Context().Name()
+	}
+
+	return &Index{
+		name:     repoName,
+		path:     path,
+		keychain: f.keychain,
+		record:   record{Insecure: insecure, Manifest: *manifest, Sources: sources},
+	}, nil
+}
+
+func (f *Factory) pathFor(repoName string) (string, error) {
+	relPath, err := layout.ParseRefToPath(repoName)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid index name %s", style.Symbol(repoName))
+	}
+	return filepath.Join(f.root, relPath), nil
+}
+
+// Name returns the reference the index will be pushed to.
+func (i *Index) Name() string {
+	return i.name
+}
+
+// MediaType returns the media type of the index.
+func (i *Index) MediaType() types.MediaType {
+	return i.record.Manifest.MediaType
+}
+
+// SetMediaType changes the media type the index is pushed as.
+func (i *Index) SetMediaType(format types.MediaType) {
+	i.record.Manifest.MediaType = format
+}
+
+// SetInsecure sets whether the index may be pushed to an insecure registry.
+func (i *Index) SetInsecure(insecure bool) {
+	i.record.Insecure = insecure
+}
+
+// Manifests returns the descriptors of the manifests referenced by the index.
+func (i *Index) Manifests() []v1.Descriptor {
+	return i.record.Manifest.Manifests
+}
+
+// IndexManifest returns the manifest of the index as it would be pushed.
+func (i *Index) IndexManifest() v1.IndexManifest {
+	return i.record.Manifest
+}
+
+// RawManifest returns the indented JSON encoding of the index manifest.
+func (i *Index) RawManifest() ([]byte, error) {
+	return json.MarshalIndent(i.record.Manifest, "", "  ")
+}
+
+// Add resolves repoName in its registry and adds it to the index. When repoName
+// refers to an index, every manifest it contains matching platform is added;
+// a zero platform matches all of them. The added descriptors are returned.
+func (i *Index) Add(ctx context.Context, repoName string, platform v1.Platform) ([]v1.Descriptor, error) {
+	ref, err := name.ParseReference(repoName, nameOptions(i.record.Insecure)...)
+	if err != nil {
+		return nil, err
+	}
+
+	desc, err := remote.Get(ref, remote.WithAuthFromKeychain(i.keychain), remote.WithContext(ctx))
+	if err != nil {
+		return nil, errors.Wrapf(err, "fetching %s", style.Symbol(repoName))
+	}
+
+	var added []v1.Descriptor
+	if desc.MediaType.IsIndex() {
+		manifest, err := v1.ParseIndexManifest(bytes.NewReader(desc.Manifest))
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range manifest.Manifests {
+			if m.Platform != nil && !m.Platform.Satisfies(platform) {
+				continue
+			}
+			added = append(added, m)
+		}
+		if len(added) == 0 {
+			return nil, errors.Errorf("no manifest in %s matches the requested platform", style.Symbol(repoName))
+		}
+	} else {
+		img, err := desc.Image()
+		if err != nil {
+			return nil, err
+		}
+		cfg, err := img.ConfigFile()
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading config of %s", style.Symbol(repoName))
+		}
+		imgDesc := desc.Descriptor
+		imgDesc.Platform = cfg.Platform()
+		added = append(added, imgDesc)
+	}
+
+	for _, d := range added {
+		i.remove(d.Digest)
+		i.record.Manifest.Manifests = append(i.record.Manifest.Manifests, d)
+		i.record.Sources[d.Digest.String()] = ref.Context().Name()
+	}
+	return added, nil
+}
+
+// Annotate updates the platform and annotations of the manifest with the given digest.
+// Empty fields are left unchanged.
+func (i *Index) Annotate(digest v1.Hash, platform Platform) error {
+	for idx := range i.record.Manifest.Manifests {
+		desc := &i.record.Manifest.Manifests[idx]
+		if desc.Digest != digest {
+			continue
+		}
+
+		if desc.Platform == nil {
+			desc.Platform = &v1.Platform{}
+		}
+		if platform.OS != "" {
+			desc.Platform.OS = platform.OS
+		}
+		if platform.Arch != "" {
+			desc.Platform.Architecture = platform.Arch
+		}
+		if platform.Variant != "" {
+			desc.Platform.Variant = platform.Variant
+		}
+		if platform.OSVersion != "" {
+			desc.Platform.OSVersion = platform.OSVersion
+		}
+		if len(platform.Features) > 0 {
+			desc.Platform.OSFeatures = platform.Features
+		}
+		if len(platform.Annotations) > 0 {
+			if desc.Annotations == nil {
+				desc.Annotations = map[string]string{}
+			}
+			for k, v := range platform.Annotations {
+				desc.Annotations[k] = v
+			}
+		}
+		return nil
+	}
+	return errors.Errorf("manifest %s is not part of index %s", style.Symbol(digest.String()), style.Symbol(i.name))
+}
+
+// Remove deletes the manifest with the given digest from the index.
+func (i *Index) Remove(digest v1.Hash) error {
+	if !i.remove(digest) {
+		return errors.Errorf("manifest %s is not part of index %s", style.Symbol(digest.String()), style.Symbol(i.name))
+	}
+	return nil
+}
+
+func (i *Index) remove(digest v1.Hash) bool {
+	var (
+		kept  []v1.Descriptor
+		found bool
+	)
+	for _, desc := range i.record.Manifest.Manifests {
+		if desc.Digest == digest {
+			found = true
+			continue
+		}
+		kept = append(kept, desc)
+	}
+	if kept == nil {
+		kept = []v1.Descriptor{}
+	}
+	i.record.Manifest.Manifests = kept
+	delete(i.record.Sources, digest.String())
+	return found
+}
+
+// Digest resolves repoName to the digest of a manifest in the index. Digest references
+// are used as is, tags are resolved against their registry.
+func (i *Index) Digest(ctx context.Context, repoName string) (v1.Hash, error) {
+	ref, err := name.ParseReference(repoName, nameOptions(i.record.Insecure)...)
+	if err != nil {
+		return v1.Hash{}, err
+	}
+
+	if digestRef, ok := ref.(name.Digest); ok {
+		return v1.NewHash(digestRef.DigestStr())
+	}
+
+	desc, err := remote.Head(ref, remote.WithAuthFromKeychain(i.keychain), remote.WithContext(ctx))
+	if err != nil {
+		return v1.Hash{}, errors.Wrapf(err, "resolving digest of %s", style.Symbol(repoName))
+	}
+	return desc.Digest, nil
+}
+
+// Save persists the index to local storage.
+func (i *Index) Save() error {
+	sort.SliceStable(i.record.Manifest.Manifests, func(a, b int) bool {
+		return platformString(i.record.Manifest.Manifests[a].Platform) < platformString(i.record.Manifest.Manifests[b].Platform)
+	})
+
+	contents, err := json.MarshalIndent(i.record, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(i.path, 0750); err != nil {
+		return errors.Wrapf(err, "creating directory for index %s", style.Symbol(i.name))
+	}
+	return os.WriteFile(filepath.Join(i.path, indexFileName), contents, 0600)
+}
+
+// Delete removes the index from local storage.
+func (i *Index) Delete() error {
+	if err := os.RemoveAll(i.path); err != nil {
+		return errors.Wrapf(err, "deleting index %s", style.Symbol(i.name))
+	}
+	return nil
+}
+
+// Push writes the index, and any manifests it references that are missing from the
+// target repository, to the registry. The digest of the pushed index is returned.
+func (i *Index) Push(ctx context.Context) (v1.Hash, error) {
+	ref, err := name.ParseReference(i.name, nameOptions(i.record.Insecure)...)
+	if err != nil {
+		return v1.Hash{}, err
+	}
+
+	remoteOpts := []remote.Option{remote.WithAuthFromKeychain(i.keychain), remote.WithContext(ctx)}
+
+	var addenda []mutate.IndexAddendum
+	for _, desc := range i.record.Manifest.Manifests {
+		source, ok := i.record.Sources[desc.Digest.String()]
+		if !ok {
+			source = ref.Context().Name()
+		}
+		childRef, err := name.NewDigest(source+"@"+desc.Digest.String(), nameOptions(i.record.Insecure)...)
+		if err != nil {
+			return v1.Hash{}, err
+		}
+
+		childDesc, err := remote.Get(childRef, remoteOpts...)
+		if err != nil {
+			return v1.Hash{}, errors.Wrapf(err, "fetching manifest %s", style.Symbol(childRef.String()))
+		}
+
+		var add mutate.Appendable
+		if childDesc.MediaType.IsIndex() {
+			add, err = childDesc.ImageIndex()
+		} else {
+			add, err = childDesc.Image()
+		}
+		if err != nil {
+			return v1.Hash{}, err
+		}
+		addenda = append(addenda, mutate.IndexAddendum{Add: add, Descriptor: desc})
+	}
+
+	idx := mutate.AppendManifests(mutate.IndexMediaType(empty.Index, i.record.Manifest.MediaType), addenda...)
+	if len(i.record.Manifest.Annotations) > 0 {
+		idx = mutate.Annotations(idx, i.record.Manifest.Annotations).(v1.ImageIndex)
+	}
+
+	if err := remote.WriteIndex(ref, idx, remoteOpts...); err != nil {
+		return v1.Hash{}, errors.Wrapf(err, "pushing index %s", style.Symbol(i.name))
+	}
+	return idx.Digest()
+}
+
+func nameOptions(insecure bool) []name.Option {
+	opts := []name.Option{name.WeakValidation}
+	if insecure {
+		opts = append(opts, name.Insecure)
+	}
+	return opts
+}
+
+func platformString(p *v1.Platform) string {
+	if p == nil {
+		return ""
+	}
+	return p.String()
+}
@@ -0,0 +1,236 @@
+package index_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/index"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestIndex(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Index", testIndex, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testIndex(t *testing.T, when spec.G, it spec.S) {
+	var (
+		server   *httptest.Server
+		host     string
+		tmpDir   string
+		factory  *index.Factory
+		ctx      = context.Background()
+		amd64Ref string
+		arm64Ref string
+	)
+
+	pushImage := func(repoName, os, arch string) v1.Image {
+		img, err := random.Image(1024, 1)
+		h.AssertNil(t, err)
+		cfg, err := img.ConfigFile()
+		h.AssertNil(t, err)
+		cfg.OS = os
+		cfg.Architecture = arch
+		img, err = mutate.ConfigFile(img, cfg)
+		h.AssertNil(t, err)
+
+		ref, err := name.ParseReference(repoName)
+		h.AssertNil(t, err)
+		h.AssertNil(t, remote.Write(ref, img))
+		return img
+	}
+
+	it.Before(func() {
+		server = httptest.NewServer(registry.New())
+		u, err := url.Parse(server.URL)
+		h.AssertNil(t, err)
+		host = u.Host
+
+		tmpDir, err = os.MkdirTemp("", "index-test")
+		h.AssertNil(t, err)
+		factory = index.NewFactory(tmpDir, authn.DefaultKeychain)
+
+		amd64Ref = host + "/some-image:amd64"
+		arm64Ref = host + "/some-image:arm64"
+		pushImage(amd64Ref, "linux", "amd64")
+		pushImage(arm64Ref, "linux", "arm64")
+	})
+
+	it.After(func() {
+		server.Close()
+		h.AssertNil(t, os.RemoveAll(tmpDir))
+	})
+
+	when("#CreateIndex", func() {
+		it("defaults to an OCI image index", func() {
+			idx, err := factory.CreateIndex(host+"/some-index", index.Options{})
+			h.AssertNil(t, err)
+			h.AssertEq(t, idx.MediaType(), types.OCIImageIndex)
+			h.AssertEq(t, len(idx.Manifests()), 0)
+		})
+
+		it("errors for non index media types", func() {
+			_, err := factory.CreateIndex(host+"/some-index", index.Options{Format: types.OCIManifestSchema1})
+			h.AssertError(t, err, "unsupported index media type")
+		})
+
+		it("errors when the index already exists", func() {
+			idx, err := factory.CreateIndex(host+"/some-index", index.Options{})
+			h.AssertNil(t, err)
+			h.AssertNil(t, idx.Save())
+
+			_, err = factory.CreateIndex(host+"/some-index", index.Options{})
+			h.AssertError(t, err, "already exists")
+		})
+	})
+
+	when("#LoadIndex", func() {
+		it("returns ErrNotFound for unknown indexes", func() {
+			_, err := factory.LoadIndex(host + "/unknown-index")
+			h.AssertTrue(t, errors.Is(err, index.ErrNotFound))
+		})
+
+		it("reads back a saved index", func() {
+			idx, err := factory.CreateIndex(host+"/some-index", index.Options{Format: types.DockerManifestList})
+			h.AssertNil(t, err)
+			_, err = idx.Add(ctx, amd64Ref, v1.Platform{})
+			h.AssertNil(t, err)
+			h.AssertNil(t, idx.Save())
+			h.AssertTrue(t, factory.Exists(host+"/some-index"))
+
+			loaded, err := factory.LoadIndex(host + "/some-index")
+			h.AssertNil(t, err)
+			h.AssertEq(t, loaded.MediaType(), types.DockerManifestList)
+			h.AssertEq(t, len(loaded.Manifests()), 1)
+			h.AssertEq(t, loaded.Manifests()[0].Platform.Architecture, "amd64")
+		})
+	})
+
+	when("#Add", func() {
+		it("records the platform of an image", func() {
+			idx, err := factory.CreateIndex(host+"/some-index", index.Options{})
+			h.AssertNil(t, err)
+
+			added, err := idx.Add(ctx, arm64Ref, v1.Platform{})
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(added), 1)
+			h.AssertEq(t, added[0].Platform.OS, "linux")
+			h.AssertEq(t, added[0].Platform.Architecture, "arm64")
+		})
+
+		it("adds the manifests of an index matching the platform", func() {
+			source, err := factory.CreateIndex(host+"/source-index", index.Options{})
+			h.AssertNil(t, err)
+			_, err = source.Add(ctx, amd64Ref, v1.Platform{})
+			h.AssertNil(t, err)
+			_, err = source.Add(ctx, arm64Ref, v1.Platform{})
+			h.AssertNil(t, err)
+			_, err = source.Push(ctx)
+			h.AssertNil(t, err)
+
+			idx, err := factory.CreateIndex(host+"/some-index", index.Options{})
+			h.AssertNil(t, err)
+			added, err := idx.Add(ctx, host+"/source-index", v1.Platform{Architecture: "arm64"})
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(added), 1)
+			h.AssertEq(t, added[0].Platform.Architecture, "arm64")
+		})
+	})
+
+	when("#Annotate", func() {
+		it("updates the descriptor", func() {
+			idx, err := factory.CreateIndex(host+"/some-index", index.Options{})
+			h.AssertNil(t, err)
+			added, err := idx.Add(ctx, amd64Ref, v1.Platform{})
+			h.AssertNil(t, err)
+
+			h.AssertNil(t, idx.Annotate(added[0].Digest, index.Platform{
+				OSVersion:   "some-os-version",
+				Variant:     "v2",
+				Annotations: map[string]string{"some-key": "some-value"},
+			}))
+
+			desc := idx.Manifests()[0]
+			h.AssertEq(t, desc.Platform.Architecture, "amd64")
+			h.AssertEq(t, desc.Platform.Variant, "v2")
+			h.AssertEq(t, desc.Platform.OSVersion, "some-os-version")
+			h.AssertEq(t, desc.Annotations["some-key"], "some-value")
+		})
+
+		it("errors for unknown manifests", func() {
+			idx, err := factory.CreateIndex(host+"/some-index", index.Options{})
+			h.AssertNil(t, err)
+			err = idx.Annotate(v1.Hash{Algorithm: "sha256", Hex: "abc"}, index.Platform{OS: "linux"})
+			h.AssertError(t, err, "is not part of index")
+		})
+	})
+
+	when("#Remove", func() {
+		it("removes the manifest resolved from a tag", func() {
+			idx, err := factory.CreateIndex(host+"/some-index", index.Options{})
+			h.AssertNil(t, err)
+			_, err = idx.Add(ctx, amd64Ref, v1.Platform{})
+			h.AssertNil(t, err)
+			_, err = idx.Add(ctx, arm64Ref, v1.Platform{})
+			h.AssertNil(t, err)
+
+			digest, err := idx.Digest(ctx, amd64Ref)
+			h.AssertNil(t, err)
+			h.AssertNil(t, idx.Remove(digest))
+			h.AssertEq(t, len(idx.Manifests()), 1)
+			h.AssertEq(t, idx.Manifests()[0].Platform.Architecture, "arm64")
+		})
+	})
+
+	when("#Push", func() {
+		it("pushes the index to its registry", func() {
+			idx, err := factory.CreateIndex(host+"/other-repo/some-index", index.Options{})
+			h.AssertNil(t, err)
+			_, err = idx.Add(ctx, amd64Ref, v1.Platform{})
+			h.AssertNil(t, err)
+			_, err = idx.Add(ctx, arm64Ref, v1.Platform{})
+			h.AssertNil(t, err)
+
+			digest, err := idx.Push(ctx)
+			h.AssertNil(t, err)
+
+			fetched, err := factory.FetchIndex(ctx, host+"/other-repo/some-index", false)
+			h.AssertNil(t, err)
+			h.AssertEq(t, fetched.MediaType(), types.OCIImageIndex)
+			h.AssertEq(t, len(fetched.Manifests()), 2)
+
+			ref, err := name.ParseReference(host + "/other-repo/some-index")
+			h.AssertNil(t, err)
+			desc, err := remote.Head(ref)
+			h.AssertNil(t, err)
+			h.AssertEq(t, desc.Digest, digest)
+		})
+	})
+
+	when("#Delete", func() {
+		it("removes the index from local storage", func() {
+			idx, err := factory.CreateIndex(host+"/some-index", index.Options{})
+			h.AssertNil(t, err)
+			h.AssertNil(t, idx.Save())
+			h.AssertNil(t, idx.Delete())
+			h.AssertFalse(t, factory.Exists(host+"/some-index"))
+		})
+	})
+}
@@ -0,0 +1,167 @@
+package logging
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// EventType identifies a structured build event.
+type EventType string
+
+const (
+	// EventLog carries a log message, emitted in place of text output when structured logging is on
+	EventLog EventType = "log"
+	// EventBuildStarted is emitted once the build has been configured and the lifecycle is about to run
+	EventBuildStarted EventType = "build_started"
+	// EventBuildFinished is emitted when the build completes, successfully or not
+	EventBuildFinished EventType = "build_finished"
+	// EventCache names a cache used by the build
+	EventCache EventType = "cache"
+	// EventPhaseStarted is emitted when a lifecycle phase container is created
+	EventPhaseStarted EventType = "phase_started"
+	// EventPhaseFinished is emitted when a lifecycle phase container exits
+	EventPhaseFinished EventType = "phase_finished"
+	// EventGroupSelected lists the buildpacks selected during detection
+	EventGroupSelected EventType = "group_selected"
+	// EventLayerAdded is emitted for each layer added to the app image or cache
+	EventLayerAdded EventType = "layer_added"
+	// EventLayerReused is emitted for each layer reused from the previous image or cache
+	EventLayerReused EventType = "layer_reused"
+	// EventImageExported carries the reference and digest of the exported app image
+	EventImageExported EventType = "image_exported"
+	// EventBuildPlan carries the build plan resolved by a dry run
+	EventBuildPlan EventType = "build_plan"
+)
+
+// Event is a structured, machine-readable build event. Only the fields relevant to its Type are set.
+type Event struct {
+	Time       time.Time `json:"time"`
+	Type       EventType `json:"type"`
+	Level      string    `json:"level,omitempty"`
+	Message    string    `json:"message,omitempty"`
+	Phase      string    `json:"phase,omitempty"`
+	DurationMS *int64    `json:"duration_ms,omitempty"`
+	ExitCode   *int64    `json:"exit_code,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	Image      string    `json:"image,omitempty"`
+	Builder    string    `json:"builder,omitempty"`
+	RunImage   string    `json:"run_image,omitempty"`
+	Digest     string    `json:"digest,omitempty"`
+	Cache      string    `json:"cache,omitempty"`
+	CacheType  string    `json:"cache_type,omitempty"`
+	Layer      string    `json:"layer,omitempty"`
+	Buildpacks []string  `json:"buildpacks,omitempty"`
+	// Plan is the build plan of a dry run, encoded as JSON.
+	Plan interface{} `json:"plan,omitempty"`
+}
+
+// WithDuration sets the duration of the event in milliseconds.
+func (e Event) WithDuration(d time.Duration) Event {
+	ms := d.Milliseconds()
+	e.DurationMS = &ms
+	return e
+}
+
+// WithExitCode sets the exit code of the event.
+func (e Event) WithExitCode(code int64) Event {
+	e.ExitCode = &code
+	return e
+}
+
+type isEventLogger interface {
+	EventsEnabled() bool
+	LogEvent(e Event)
+}
+
+// EventsEnabled reports whether the logger emits structured events.
+//
+// See isEventLogger
+func EventsEnabled(logger Logger) bool {
+	if el, ok := logger.(isEventLogger); ok {
+		return el.EventsEnabled()
+	}
+
+	return false
+}
+
+// LogEvent emits a structured event when the logger supports them, and does nothing otherwise.
+func LogEvent(logger Logger, e Event) {
+	if el, ok := logger.(isEventLogger); ok && el.EventsEnabled() {
+		el.LogEvent(e)
+	}
+}
+
+// eventEncoder writes events as JSON lines.
+type eventEncoder struct {
+	sync.Mutex
+	out   io.Writer
+	clock func() time.Time
+}
+
+func (e *eventEncoder) encode(event Event) error {
+	e.Lock()
+	defer e.Unlock()
+
+	if event.Time.IsZero() {
+		event.Time = e.clock()
+	}
+
+	encoder := json.NewEncoder(e.out)
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(event)
+}
+
+// eventWriter is a buffering writer that emits each line written to it as a log event. Close should be called to properly flush the buffer.
+type eventWriter struct {
+	encoder *eventEncoder
+	level   string
+	buf     bytes.Buffer
+}
+
+// Write emits a log event for each complete line
+func (w *eventWriter) Write(data []byte) (int, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Split(ScanLinesKeepNewLine)
+	for scanner.Scan() {
+		newBits := scanner.Bytes()
+		w.buf.Write(bytes.TrimRight(newBits, "\n"))
+		if newBits[len(newBits)-1] != '\n' {
+			continue
+		}
+
+		if err := w.flush(); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+
+	return len(data), nil
+}
+
+// Close emits any pending data in the buffer
+func (w *eventWriter) Close() error {
+	if w.buf.Len() > 0 {
+		return w.flush()
+	}
+
+	return nil
+}
+
+func (w *eventWriter) flush() error {
+	bits := stripColor(w.buf.Bytes())
+	w.buf.Reset()
+
+	// process any CR in message
+	if i := bytes.LastIndexByte(bits, '\r'); i >= 0 {
+		bits = bits[i+1:]
+	}
+
+	return w.encoder.encode(Event{Type: EventLog, Level: w.level, Message: string(bits)})
+}
@@ -37,9 +37,11 @@ type LogWithWriters struct {
 	sync.Mutex
 	log.Logger
 	wantTime bool
+	wantJSON bool
 	clock    func() time.Time
 	out      io.Writer
 	errOut   io.Writer
+	events   *eventEncoder
 }
 
 // NewLogWithWriters creates a logger to be used with pack CLI.
@@ -58,6 +60,7 @@ func NewLogWithWriters(stdout, stderr io.Writer, opts ...func(*LogWithWriters))
 	for _, opt := range opts {
 		opt(lw)
 	}
+	lw.events = &eventEncoder{out: lw.out, clock: lw.clock}
 
 	return lw
 }
@@ -81,6 +84,10 @@ func (lw *LogWithWriters) HandleLog(e *log.Entry) error {
 	lw.Lock()
 	defer lw.Unlock()
 
+	if lw.wantJSON {
+		return lw.events.encode(Event{Type: EventLog, Level: e.Level.String(), Message: string(stripColor([]byte(e.Message)))})
+	}
+
 	writer := lw.WriterForLevel(Level(e.Level))
 	_, err := fmt.Fprint(writer, appendMissingLineFeed(fmt.Sprintf("%s%s", formatLevel(e.Level), e.Message)))
 
@@ -93,6 +100,10 @@ func (lw *LogWithWriters) WriterForLevel(level Level) io.Writer {
 		return io.Discard
 	}
 
+	if lw.wantJSON {
+		return &eventWriter{encoder: lw.events, level: log.Level(level).String()}
+	}
+
 	if level == ErrorLevel {
 		return newLogWriter(lw.errOut, lw.clock, lw.wantTime)
 	}
@@ -100,7 +111,8 @@ func (lw *LogWithWriters) WriterForLevel(level Level) io.Writer {
 	return newLogWriter(lw.out, lw.clock, lw.wantTime)
 }
 
-// Writer returns the base Writer for the LogWithWriters
+// Writer returns the base Writer for the LogWithWriters. It is meant for output requested by the user, such as
+// JSON documents and tables, which is written unchanged even when logging JSON.
 func (lw *LogWithWriters) Writer() io.Writer {
 	return lw.out
 }
@@ -124,6 +136,21 @@ func (lw *LogWithWriters) WantVerbose(f bool) {
 	}
 }
 
+// WantJSON turns log entries into JSON lines and enables structured build events
+func (lw *LogWithWriters) WantJSON(f bool) {
+	lw.wantJSON = f
+}
+
+// EventsEnabled returns whether structured build events are emitted
+func (lw *LogWithWriters) EventsEnabled() bool {
+	return lw.wantJSON
+}
+
+// LogEvent writes a structured build event as a JSON line
+func (lw *LogWithWriters) LogEvent(e Event) {
+	_ = lw.events.encode(e)
+}
+
 // IsVerbose returns whether verbose logging is on
 func (lw *LogWithWriters) IsVerbose() bool {
 	return lw.Level == log.DebugLevel
@@ -210,6 +210,63 @@ func testLogWithWriters(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 
+	when("json is set to true", func() {
+		it.Before(func() {
+			logger.WantJSON(true)
+		})
+
+		it("logs messages as JSON lines without colors", func() {
+			logger.Info(color.HiBlueString("info_"))
+			logger.Error("error_")
+
+			h.AssertEq(t, fOut(),
+				`{"time":"2019-05-15T01:01:01Z","type":"log","level":"info","message":"info_"}`+"\n"+
+					`{"time":"2019-05-15T01:01:01Z","type":"log","level":"error","message":"error_"}`+"\n",
+			)
+			h.AssertEq(t, fErr(), "")
+		})
+
+		it("logs each line written to a writer as a message", func() {
+			writer := logger.WriterForLevel(logging.InfoLevel)
+			_, err := writer.Write([]byte("line 1\nline"))
+			h.AssertNil(t, err)
+			_, err = writer.Write([]byte(" 2\npartial"))
+			h.AssertNil(t, err)
+			h.AssertNil(t, writer.(io.Closer).Close())
+
+			h.AssertEq(t, fOut(),
+				`{"time":"2019-05-15T01:01:01Z","type":"log","level":"info","message":"line 1"}`+"\n"+
+					`{"time":"2019-05-15T01:01:01Z","type":"log","level":"info","message":"line 2"}`+"\n"+
+					`{"time":"2019-05-15T01:01:01Z","type":"log","level":"info","message":"partial"}`+"\n",
+			)
+		})
+
+		it("writes output written to the base writer unchanged", func() {
+			document := "{\n  \"image\": \"some/app\"\n}\n"
+			_, err := logger.Writer().Write([]byte(document))
+			h.AssertNil(t, err)
+			logger.Info("info_")
+
+			h.AssertEq(t, fOut(), document+`{"time":"2019-05-15T01:01:01Z","type":"log","level":"info","message":"info_"}`+"\n")
+		})
+
+		it("logs events", func() {
+			h.AssertTrue(t, logging.EventsEnabled(logger))
+			logging.LogEvent(logger, logging.Event{Type: logging.EventPhaseFinished, Phase: "detector"}.WithDuration(1500*time.Millisecond).WithExitCode(0))
+
+			h.AssertEq(t, fOut(), `{"time":"2019-05-15T01:01:01Z","type":"phase_finished","phase":"detector","duration_ms":1500,"exit_code":0}`+"\n")
+		})
+	})
+
+	when("json is not set", func() {
+		it("does not log events", func() {
+			h.AssertFalse(t, logging.EventsEnabled(logger))
+			logging.LogEvent(logger, logging.Event{Type: logging.EventPhaseStarted, Phase: "detector"})
+
+			h.AssertEq(t, fOut(), "")
+		})
+	})
+
 	it("will convert an empty string to a line feed", func() {
 		logger.Info("")
 		expected := "\n"
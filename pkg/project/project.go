@@ -4,15 +4,18 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/BurntSushi/toml"
 	"github.com/pkg/errors"
 
+	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/logging"
 	"github.com/buildpacks/pack/pkg/project/types"
 	v01 "github.com/buildpacks/pack/pkg/project/v01"
 	v02 "github.com/buildpacks/pack/pkg/project/v02"
+	v03 "github.com/buildpacks/pack/pkg/project/v03"
 )
 
 type Project struct {
@@ -26,6 +29,7 @@ type VersionDescriptor struct {
 var parsers = map[string]func(string) (types.Descriptor, toml.MetaData, error){
 	"0.1": v01.NewDescriptor,
 	"0.2": v02.NewDescriptor,
+	"0.3": v03.NewDescriptor,
 }
 
 func ReadProjectDescriptor(pathToFile string, logger logging.Logger) (types.Descriptor, error) {
@@ -65,6 +69,69 @@ func ReadProjectDescriptor(pathToFile string, logger logging.Logger) (types.Desc
 	return descriptor, validate(descriptor)
 }
 
+// ApplyProfile returns the descriptor with the build configuration of a profile applied. The builder, run image,
+// buildpacks, pre and post groups, and include or exclude lists declared by the profile replace those of the
+// descriptor, and its env vars are added to those of the descriptor, replacing any with the same name.
+func ApplyProfile(descriptor types.Descriptor, name string) (types.Descriptor, error) {
+	profile, ok := descriptor.Profiles[name]
+	if !ok {
+		if len(descriptor.Profiles) == 0 {
+			return types.Descriptor{}, errors.Errorf("profile %s not found, project descriptor declares no profiles", style.Symbol(name))
+		}
+		return types.Descriptor{}, errors.Errorf("profile %s not found, must be one of %s", style.Symbol(name), strings.Join(profileNames(descriptor), ", "))
+	}
+
+	build := descriptor.Build
+	if profile.Include != nil || profile.Exclude != nil {
+		build.Include, build.Exclude = profile.Include, profile.Exclude
+	}
+	if profile.Buildpacks != nil {
+		build.Buildpacks = profile.Buildpacks
+	}
+	if profile.Builder != "" {
+		build.Builder = profile.Builder
+	}
+	if profile.RunImage != "" {
+		build.RunImage = profile.RunImage
+	}
+	if profile.Pre.Buildpacks != nil {
+		build.Pre = profile.Pre
+	}
+	if profile.Post.Buildpacks != nil {
+		build.Post = profile.Post
+	}
+	build.Env = mergeEnv(build.Env, profile.Env)
+
+	descriptor.Build = build
+	return descriptor, nil
+}
+
+func mergeEnv(env, overrides []types.EnvVar) []types.EnvVar {
+	var merged []types.EnvVar
+	for _, envVar := range env {
+		overridden := false
+		for _, override := range overrides {
+			if override.Name == envVar.Name {
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			merged = append(merged, envVar)
+		}
+	}
+	return append(merged, overrides...)
+}
+
+func profileNames(descriptor types.Descriptor) []string {
+	var names []string
+	for name := range descriptor.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 func warnIfTomlContainsKeysNotSupportedBySchema(schemaVersion string, tomlMetaData toml.MetaData, logger logging.Logger) {
 	unsupportedKeys := []string{}
 
@@ -86,10 +153,6 @@ func warnIfTomlContainsKeysNotSupportedBySchema(schemaVersion string, tomlMetaDa
 }
 
 func validate(p types.Descriptor) error {
-	if p.Build.Exclude != nil && p.Build.Include != nil {
-		return errors.New("project.toml: cannot have both include and exclude defined")
-	}
-
 	if len(p.Project.Licenses) > 0 {
 		for _, license := range p.Project.Licenses {
 			if license.Type == "" && license.URI == "" {
@@ -98,7 +161,29 @@ func validate(p types.Descriptor) error {
 		}
 	}
 
-	for _, bp := range p.Build.Buildpacks {
+	if err := validateBuild(p.Build); err != nil {
+		return err
+	}
+
+	for _, name := range profileNames(p) {
+		withProfile, err := ApplyProfile(p, name)
+		if err != nil {
+			return err
+		}
+		if err := validateBuild(withProfile.Build); err != nil {
+			return errors.Wrapf(err, "invalid profile %s", style.Symbol(name))
+		}
+	}
+
+	return nil
+}
+
+func validateBuild(build types.Build) error {
+	if build.Exclude != nil && build.Include != nil {
+		return errors.New("project.toml: cannot have both include and exclude defined")
+	}
+
+	for _, bp := range build.Buildpacks {
 		if bp.ID == "" && bp.URI == "" {
 			return errors.New("project.toml: buildpacks must have an id or url defined")
 		}
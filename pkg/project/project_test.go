@@ -12,6 +12,7 @@ import (
 	"github.com/sclevine/spec/report"
 
 	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/project/types"
 	h "github.com/buildpacks/pack/testhelpers"
 )
 
@@ -175,6 +176,75 @@ value = "-Xmx300m"
 					expected, projectDescriptor.Build.Env[0].Value)
 			}
 		})
+		it("should parse a valid v0.3 project.toml file with profiles", func() {
+			projectToml := `
+[_]
+name = "gallant 0.3"
+schema-version = "0.3"
+[io.buildpacks]
+builder = "example/builder"
+run-image = "example/run"
+exclude = [ "*.jar" ]
+[[io.buildpacks.group]]
+id = "example/lua"
+version = "1.0"
+[[io.buildpacks.build.env]]
+name = "JAVA_OPTS"
+value = "-Xmx300m"
+[io.buildpacks.profiles.dev]
+builder = "example/builder:debug"
+[[io.buildpacks.profiles.dev.build.env]]
+name = "BP_DEBUG"
+value = "true"
+[io.buildpacks.profiles.prod]
+run-image = "example/run:hardened"
+[[io.buildpacks.profiles.prod.post.group]]
+uri = "https://example.com/buildpack/scan"
+`
+			tmpProjectToml, err := createTmpProjectTomlFile(projectToml)
+			h.AssertNil(t, err)
+
+			projectDescriptor, err := ReadProjectDescriptor(tmpProjectToml.Name(), logger)
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, projectDescriptor.SchemaVersion, api.MustParse("0.3"))
+			h.AssertEq(t, projectDescriptor.Project.Name, "gallant 0.3")
+			h.AssertEq(t, projectDescriptor.Build, types.Build{
+				Exclude:    []string{"*.jar"},
+				Buildpacks: []types.Buildpack{{ID: "example/lua", Version: "1.0"}},
+				Env:        []types.EnvVar{{Name: "JAVA_OPTS", Value: "-Xmx300m"}},
+				Builder:    "example/builder",
+				RunImage:   "example/run",
+			})
+			h.AssertEq(t, projectDescriptor.Profiles, map[string]types.Build{
+				"dev": {
+					Builder: "example/builder:debug",
+					Env:     []types.EnvVar{{Name: "BP_DEBUG", Value: "true"}},
+				},
+				"prod": {
+					RunImage: "example/run:hardened",
+					Post:     types.GroupAddition{Buildpacks: []types.Buildpack{{URI: "https://example.com/buildpack/scan"}}},
+				},
+			})
+			h.AssertNotContains(t, readStdout(), "not supported in schema version")
+		})
+
+		it("should validate the build of each profile", func() {
+			projectToml := `
+[_]
+schema-version = "0.3"
+[io.buildpacks.profiles.dev]
+[[io.buildpacks.profiles.dev.group]]
+uri = "https://example.com/buildpack"
+version = "1.2.3"
+`
+			tmpProjectToml, err := createTmpProjectTomlFile(projectToml)
+			h.AssertNil(t, err)
+
+			_, err = ReadProjectDescriptor(tmpProjectToml.Name(), logger)
+			h.AssertError(t, err, "invalid profile 'dev': project.toml: buildpacks cannot have both uri and version defined")
+		})
+
 		it("should parse a valid v0.1 project.toml file", func() {
 			projectToml := `
 [project]
@@ -439,6 +509,68 @@ unsupported-key = "some value"
 			h.AssertContains(t, readStdout(), "Warning: The following keys declared in project.toml are not supported in schema version 0.2:\nWarning: - unsupported-table\nWarning: - unsupported-table.unsupported-key\nWarning: The above keys will be ignored. If this is not intentional, maybe try updating your schema version.\n")
 		})
 	})
+
+	when("#ApplyProfile", func() {
+		var descriptor types.Descriptor
+
+		it.Before(func() {
+			descriptor = types.Descriptor{
+				Build: types.Build{
+					Exclude:    []string{"*.jar"},
+					Buildpacks: []types.Buildpack{{ID: "example/lua", Version: "1.0"}},
+					Env:        []types.EnvVar{{Name: "JAVA_OPTS", Value: "-Xmx300m"}, {Name: "LOG_LEVEL", Value: "info"}},
+					Builder:    "example/builder",
+					RunImage:   "example/run",
+					Pre:        types.GroupAddition{Buildpacks: []types.Buildpack{{URI: "https://example.com/buildpack/pre"}}},
+				},
+				Profiles: map[string]types.Build{
+					"dev": {
+						Include:    []string{"src"},
+						Buildpacks: []types.Buildpack{{ID: "example/lua-debug"}},
+						Env:        []types.EnvVar{{Name: "LOG_LEVEL", Value: "debug"}, {Name: "BP_DEBUG", Value: "true"}},
+						Builder:    "example/builder:debug",
+					},
+					"prod": {
+						RunImage: "example/run:hardened",
+					},
+				},
+			}
+		})
+
+		it("overrides the build with the profile", func() {
+			withProfile, err := ApplyProfile(descriptor, "dev")
+			h.AssertNil(t, err)
+			h.AssertEq(t, withProfile.Build, types.Build{
+				Include:    []string{"src"},
+				Buildpacks: []types.Buildpack{{ID: "example/lua-debug"}},
+				Env: []types.EnvVar{
+					{Name: "JAVA_OPTS", Value: "-Xmx300m"},
+					{Name: "LOG_LEVEL", Value: "debug"},
+					{Name: "BP_DEBUG", Value: "true"},
+				},
+				Builder:  "example/builder:debug",
+				RunImage: "example/run",
+				Pre:      types.GroupAddition{Buildpacks: []types.Buildpack{{URI: "https://example.com/buildpack/pre"}}},
+			})
+		})
+
+		it("keeps the build configuration the profile doesn't declare", func() {
+			withProfile, err := ApplyProfile(descriptor, "prod")
+			h.AssertNil(t, err)
+
+			expected := descriptor.Build
+			expected.RunImage = "example/run:hardened"
+			h.AssertEq(t, withProfile.Build, expected)
+		})
+
+		it("fails when the profile is not declared", func() {
+			_, err := ApplyProfile(descriptor, "staging")
+			h.AssertError(t, err, "profile 'staging' not found, must be one of dev, prod")
+
+			_, err = ApplyProfile(types.Descriptor{}, "staging")
+			h.AssertError(t, err, "profile 'staging' not found, project descriptor declares no profiles")
+		})
+	})
 }
 
 func createTmpProjectTomlFile(projectToml string) (*os.File, error) {
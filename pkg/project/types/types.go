@@ -28,6 +28,7 @@ type Build struct {
 	Buildpacks []Buildpack `toml:"buildpacks"`
 	Env        []EnvVar    `toml:"env"`
 	Builder    string      `toml:"builder"`
+	RunImage   string      `toml:"run-image"`
 	Pre        GroupAddition
 	Post       GroupAddition
 }
@@ -49,6 +50,8 @@ type Descriptor struct {
 	Build         Build                  `toml:"build"`
 	Metadata      map[string]interface{} `toml:"metadata"`
 	SchemaVersion *api.Version
+	// Profiles are named overrides of Build, selected with ApplyProfile.
+	Profiles map[string]Build
 }
 
 type GroupAddition struct {
@@ -0,0 +1,85 @@
+package v03
+
+import (
+	"github.com/BurntSushi/toml"
+	"github.com/buildpacks/lifecycle/api"
+
+	"github.com/buildpacks/pack/pkg/project/types"
+)
+
+type Buildpacks struct {
+	Profile
+	// Profiles override the build configuration above, e.g. [io.buildpacks.profiles.dev].
+	Profiles map[string]Profile `toml:"profiles"`
+}
+
+type Profile struct {
+	Include  []string            `toml:"include"`
+	Exclude  []string            `toml:"exclude"`
+	Group    []types.Buildpack   `toml:"group"`
+	Build    Build               `toml:"build"`
+	Builder  string              `toml:"builder"`
+	RunImage string              `toml:"run-image"`
+	Pre      types.GroupAddition `toml:"pre"`
+	Post     types.GroupAddition `toml:"post"`
+}
+
+type Build struct {
+	Env []types.EnvVar `toml:"env"`
+}
+
+type Project struct {
+	Name          string                 `toml:"name"`
+	Licenses      []types.License        `toml:"licenses"`
+	Metadata      map[string]interface{} `toml:"metadata"`
+	SchemaVersion string                 `toml:"schema-version"`
+}
+
+type IO struct {
+	Buildpacks Buildpacks `toml:"buildpacks"`
+}
+
+type Descriptor struct {
+	Project Project `toml:"_"`
+	IO      IO      `toml:"io"`
+}
+
+func NewDescriptor(projectTomlContents string) (types.Descriptor, toml.MetaData, error) {
+	versionedDescriptor := &Descriptor{}
+	tomlMetaData, err := toml.Decode(projectTomlContents, &versionedDescriptor)
+	if err != nil {
+		return types.Descriptor{}, tomlMetaData, err
+	}
+
+	var profiles map[string]types.Build
+	for name, profile := range versionedDescriptor.IO.Buildpacks.Profiles {
+		if profiles == nil {
+			profiles = map[string]types.Build{}
+		}
+		profiles[name] = profile.build()
+	}
+
+	return types.Descriptor{
+		Project: types.Project{
+			Name:     versionedDescriptor.Project.Name,
+			Licenses: versionedDescriptor.Project.Licenses,
+		},
+		Build:         versionedDescriptor.IO.Buildpacks.Profile.build(),
+		Profiles:      profiles,
+		Metadata:      versionedDescriptor.Project.Metadata,
+		SchemaVersion: api.MustParse("0.3"),
+	}, tomlMetaData, nil
+}
+
+func (p Profile) build() types.Build {
+	return types.Build{
+		Include:    p.Include,
+		Exclude:    p.Exclude,
+		Buildpacks: p.Group,
+		Env:        p.Build.Env,
+		Builder:    p.Builder,
+		RunImage:   p.RunImage,
+		Pre:        p.Pre,
+		Post:       p.Post,
+	}
+}
@@ -0,0 +1,53 @@
+package sbom
+
+import (
+	"encoding/json"
+	"io"
+)
+
+type cycloneDXDocument struct {
+	Components []cycloneDXComponent `json:"components"`
+}
+
+type cycloneDXComponent struct {
+	Name     string `json:"name"`
+	Version  string `json:"version"`
+	PURL     string `json:"purl"`
+	Licenses []struct {
+		License struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"license"`
+		Expression string `json:"expression"`
+	} `json:"licenses"`
+	Components []cycloneDXComponent `json:"components"`
+}
+
+func parseCycloneDX(r io.Reader) ([]Package, error) {
+	var doc cycloneDXDocument
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	var packages []Package
+	var collect func(components []cycloneDXComponent)
+	collect = func(components []cycloneDXComponent) {
+		for _, component := range components {
+			pkg := Package{Name: component.Name, Version: component.Version, PURL: component.PURL}
+			for _, license := range component.Licenses {
+				switch {
+				case license.Expression != "":
+					pkg.Licenses = append(pkg.Licenses, license.Expression)
+				case license.License.ID != "":
+					pkg.Licenses = append(pkg.Licenses, license.License.ID)
+				case license.License.Name != "":
+					pkg.Licenses = append(pkg.Licenses, license.License.Name)
+				}
+			}
+			packages = append(packages, pkg)
+			collect(component.Components)
+		}
+	}
+	collect(doc.Components)
+	return packages, nil
+}
@@ -0,0 +1,247 @@
+package sbom
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/Masterminds/semver"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// Policy lists the packages, licenses and advisories that are not allowed in an image.
+type Policy struct {
+	Deny DenyRules `toml:"deny"`
+	// Advisories is the path to a JSON advisory database. Relative paths are resolved from the policy file.
+	Advisories string `toml:"advisories"`
+
+	advisories []Advisory
+}
+
+type DenyRules struct {
+	Packages []DeniedPackage `toml:"packages"`
+	// Licenses are SPDX license IDs, compared case-insensitively with the IDs in each package's license expressions.
+	Licenses []string `toml:"licenses"`
+}
+
+type DeniedPackage struct {
+	Name string `toml:"name"`
+	// Versions is a semver range, e.g. "< 2.17.0" or ">= 1.0, < 1.4". All versions are denied when it is empty.
+	Versions string `toml:"versions"`
+	Reason   string `toml:"reason"`
+
+	versions *semver.Constraints
+}
+
+// Advisory is an entry of a locally supplied advisory database.
+type Advisory struct {
+	ID       string `json:"id"`
+	Package  string `json:"package"`
+	Versions string `json:"versions"`
+	Severity string `json:"severity,omitempty"`
+	Summary  string `json:"summary,omitempty"`
+
+	versions *semver.Constraints
+}
+
+// Rule identifies the rule of a policy that a package violates.
+type Rule string
+
+const (
+	RuleDeniedPackage Rule = "denied-package"
+	RuleDeniedLicense Rule = "denied-license"
+	RuleAdvisory      Rule = "advisory"
+	// RuleUnmatchedVersion reports a package named by a rule with a version range, whose version can't be compared
+	// with the range because it isn't a semantic version.
+	RuleUnmatchedVersion Rule = "unmatched-version"
+)
+
+// Violation is a package that doesn't comply with a policy.
+type Violation struct {
+	Rule     Rule      `json:"rule"`
+	Package  Package   `json:"package"`
+	Details  string    `json:"details"`
+	Advisory *Advisory `json:"advisory,omitempty"`
+}
+
+// Report is the result of checking the packages of an image against a policy.
+type Report struct {
+	Packages   int         `json:"packages"`
+	Violations []Violation `json:"violations"`
+}
+
+func (r *Report) Passed() bool {
+	return len(r.Violations) == 0
+}
+
+// ReadPolicy reads and validates a policy file, along with the advisory database it references.
+func ReadPolicy(path string) (Policy, error) {
+	var policy Policy
+	md, err := toml.DecodeFile(path, &policy)
+	if err != nil {
+		return Policy{}, errors.Wrapf(err, "reading policy %s", style.Symbol(path))
+	}
+	if undecoded := md.Undecoded(); len(undecoded) > 0 {
+		return Policy{}, errors.Errorf("%s in %s", config.FormatUndecodedKeys(undecoded), style.Symbol(path))
+	}
+
+	for i, pkg := range policy.Deny.Packages {
+		if pkg.Name == "" {
+			return Policy{}, errors.Errorf("missing %s for denied package in %s", style.Symbol("name"), style.Symbol(path))
+		}
+		if policy.Deny.Packages[i].versions, err = parseVersions(pkg.Versions); err != nil {
+			return Policy{}, errors.Wrapf(err, "invalid versions for denied package %s", style.Symbol(pkg.Name))
+		}
+	}
+
+	if policy.Advisories == "" {
+		return policy, nil
+	}
+	advisoriesPath := policy.Advisories
+	if !filepath.IsAbs(advisoriesPath) {
+		advisoriesPath = filepath.Join(filepath.Dir(path), advisoriesPath)
+	}
+	if policy.advisories, err = readAdvisories(advisoriesPath); err != nil {
+		return Policy{}, err
+	}
+	return policy, nil
+}
+
+func readAdvisories(path string) ([]Advisory, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading advisory database")
+	}
+
+	var advisories []Advisory
+	if err := json.Unmarshal(contents, &advisories); err != nil {
+		return nil, errors.Wrapf(err, "parsing advisory database %s", style.Symbol(path))
+	}
+	for i, advisory := range advisories {
+		if advisory.ID == "" || advisory.Package == "" {
+			return nil, errors.Errorf("advisory database %s has an entry without an %s or %s", style.Symbol(path), style.Symbol("id"), style.Symbol("package"))
+		}
+		if advisories[i].versions, err = parseVersions(advisory.Versions); err != nil {
+			return nil, errors.Wrapf(err, "invalid versions for advisory %s", style.Symbol(advisory.ID))
+		}
+	}
+	return advisories, nil
+}
+
+func parseVersions(versions string) (*semver.Constraints, error) {
+	if versions == "" {
+		return nil, nil
+	}
+	return semver.NewConstraint(versions)
+}
+
+// Check evaluates packages against a policy. A version range only matches packages whose version is a valid
+// semantic version; other packages named by a rule with a version range are reported as unmatched.
+func Check(packages []Package, policy Policy) Report {
+	report := Report{Packages: len(packages), Violations: []Violation{}}
+	for _, pkg := range packages {
+		for _, denied := range policy.Deny.Packages {
+			if denied.Name != pkg.Name {
+				continue
+			}
+			matched, ok := inRange(pkg.Version, denied.versions)
+			if !ok {
+				report.Violations = append(report.Violations, unmatchedVersion(pkg, "denied versions "+denied.Versions))
+			}
+			if matched {
+				details := "package is denied"
+				if denied.Reason != "" {
+					details += ": " + denied.Reason
+				}
+				report.Violations = append(report.Violations, Violation{
+					Rule:    RuleDeniedPackage,
+					Package: pkg,
+					Details: details,
+				})
+			}
+		}
+
+		for _, license := range deniedLicenses(pkg.Licenses, policy.Deny.Licenses) {
+			report.Violations = append(report.Violations, Violation{
+				Rule:    RuleDeniedLicense,
+				Package: pkg,
+				Details: "license " + license + " is denied",
+			})
+		}
+
+		for i, advisory := range policy.advisories {
+			if advisory.Package != pkg.Name {
+				continue
+			}
+			matched, ok := inRange(pkg.Version, advisory.versions)
+			if !ok {
+				report.Violations = append(report.Violations, unmatchedVersion(pkg, "versions "+advisory.Versions+" of advisory "+advisory.ID))
+			}
+			if matched {
+				details := advisory.ID
+				if advisory.Severity != "" {
+					details += " (" + advisory.Severity + ")"
+				}
+				if advisory.Summary != "" {
+					details += ": " + advisory.Summary
+				}
+				report.Violations = append(report.Violations, Violation{
+					Rule:     RuleAdvisory,
+					Package:  pkg,
+					Details:  details,
+					Advisory: &policy.advisories[i],
+				})
+			}
+		}
+	}
+	return report
+}
+
+// inRange reports whether version is in the range versions, where a nil range matches all versions. ok is false when
+// version can't be compared with the range.
+func inRange(version string, versions *semver.Constraints) (matched, ok bool) {
+	if versions == nil {
+		return true, true
+	}
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return false, false
+	}
+	return versions.Check(v), true
+}
+
+func unmatchedVersion(pkg Package, versions string) Violation {
+	return Violation{
+		Rule:    RuleUnmatchedVersion,
+		Package: pkg,
+		Details: "version is not a semantic version, cannot be compared with " + versions,
+	}
+}
+
+// deniedLicenses returns the denied licenses referenced by license expressions such as "MIT OR (GPL-2.0 WITH Classpath-exception-2.0)"
+func deniedLicenses(expressions, denied []string) []string {
+	var found []string
+	for _, license := range denied {
+		for _, expression := range expressions {
+			if references(expression, license) {
+				found = append(found, license)
+				break
+			}
+		}
+	}
+	return found
+}
+
+func references(expression, license string) bool {
+	for _, id := range strings.FieldsFunc(expression, func(r rune) bool { return r == ' ' || r == '(' || r == ')' }) {
+		if strings.EqualFold(id, license) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,165 @@
+package sbom_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/sbom"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestPolicy(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Policy", testPolicy, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testPolicy(t *testing.T, when spec.G, it spec.S) {
+	var (
+		assert = h.NewAssertionManager(t)
+		tmpDir string
+	)
+
+	it.Before(func() {
+		tmpDir = t.TempDir()
+	})
+
+	writeFile := func(name, contents string) string {
+		path := filepath.Join(tmpDir, name)
+		assert.Nil(os.WriteFile(path, []byte(contents), 0600))
+		return path
+	}
+
+	when("#ReadPolicy", func() {
+		when("the policy has unknown keys", func() {
+			it("returns an error", func() {
+				path := writeFile("policy.toml", `
+[deny]
+package = "log4j-core"
+`)
+				_, err := sbom.ReadPolicy(path)
+				assert.ErrorContains(err, "unknown configuration element 'deny.package'")
+			})
+		})
+
+		when("a version range is invalid", func() {
+			it("returns an error", func() {
+				path := writeFile("policy.toml", `
+[[deny.packages]]
+name = "log4j-core"
+versions = "not a range"
+`)
+				_, err := sbom.ReadPolicy(path)
+				assert.ErrorContains(err, "invalid versions for denied package 'log4j-core'")
+			})
+		})
+
+		when("a denied package has no name", func() {
+			it("returns an error", func() {
+				path := writeFile("policy.toml", `
+[[deny.packages]]
+versions = "< 1.0.0"
+`)
+				_, err := sbom.ReadPolicy(path)
+				assert.ErrorContains(err, "missing 'name' for denied package")
+			})
+		})
+
+		when("the advisory database is not valid", func() {
+			it("returns an error", func() {
+				writeFile("advisories.json", `[{"package": "log4j-core"}]`)
+				path := writeFile("policy.toml", `advisories = "advisories.json"`)
+
+				_, err := sbom.ReadPolicy(path)
+				assert.ErrorContains(err, "has an entry without an 'id' or 'package'")
+			})
+		})
+	})
+
+	when("#Check", func() {
+		var (
+			log4j   = sbom.Package{Name: "log4j-core", Version: "2.14.1", Licenses: []string{"Apache-2.0"}}
+			openssl = sbom.Package{Name: "openssl", Version: "1:3.0.2-0ubuntu1", Licenses: []string{"Apache-2.0"}}
+			mysql   = sbom.Package{Name: "mysql-connector", Version: "8.0.0", Licenses: []string{"(GPL-2.0 WITH Universal-FOSS-exception-1.0)"}}
+		)
+
+		readPolicy := func(contents string) sbom.Policy {
+			policy, err := sbom.ReadPolicy(writeFile("policy.toml", contents))
+			assert.Nil(err)
+			return policy
+		}
+
+		it("reports packages denied by name and version range", func() {
+			policy := readPolicy(`
+[[deny.packages]]
+name = "log4j-core"
+versions = ">= 2.0.0, < 2.17.0"
+reason = "Log4Shell"
+`)
+			result := sbom.Check([]sbom.Package{log4j, {Name: "log4j-core", Version: "2.17.1"}}, policy)
+
+			assert.Equal(result.Packages, 2)
+			assert.Equal(result.Violations, []sbom.Violation{
+				{Rule: sbom.RuleDeniedPackage, Package: log4j, Details: "package is denied: Log4Shell"},
+			})
+		})
+
+		it("reports packages without a semantic version as unmatched by version ranges", func() {
+			policy := readPolicy(`
+[[deny.packages]]
+name = "openssl"
+versions = "< 3.0.0"
+
+[[deny.packages]]
+name = "openssl"
+`)
+			result := sbom.Check([]sbom.Package{openssl}, policy)
+
+			assert.Equal(result.Violations, []sbom.Violation{
+				{Rule: sbom.RuleUnmatchedVersion, Package: openssl, Details: "version is not a semantic version, cannot be compared with denied versions < 3.0.0"},
+				{Rule: sbom.RuleDeniedPackage, Package: openssl, Details: "package is denied"},
+			})
+		})
+
+		it("reports denied licenses, including in license expressions", func() {
+			policy := readPolicy(`
+[deny]
+licenses = ["gpl-2.0"]
+`)
+			result := sbom.Check([]sbom.Package{log4j, mysql}, policy)
+
+			assert.Equal(result.Violations, []sbom.Violation{
+				{Rule: sbom.RuleDeniedLicense, Package: mysql, Details: "license gpl-2.0 is denied"},
+			})
+		})
+
+		it("reports packages affected by advisories", func() {
+			writeFile("advisories.json", `[
+  {"id": "CVE-2021-44228", "package": "log4j-core", "versions": ">= 2.0.0, < 2.15.0", "severity": "critical", "summary": "Remote code execution"},
+  {"id": "CVE-2021-45105", "package": "log4j-core", "versions": "2.16.0"}
+]`)
+			policy := readPolicy(`advisories = "advisories.json"`)
+
+			result := sbom.Check([]sbom.Package{log4j}, policy)
+
+			assert.Equal(len(result.Violations), 1)
+			assert.Equal(result.Violations[0].Rule, sbom.RuleAdvisory)
+			assert.Equal(result.Violations[0].Details, "CVE-2021-44228 (critical): Remote code execution")
+			assert.Equal(result.Violations[0].Advisory.ID, "CVE-2021-44228")
+		})
+
+		it("passes when no package violates the policy", func() {
+			result := sbom.Check([]sbom.Package{log4j}, readPolicy(`
+[deny]
+licenses = ["AGPL-3.0"]
+`))
+
+			assert.Equal(result.Passed(), true)
+		})
+	})
+}
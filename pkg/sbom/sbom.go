@@ -0,0 +1,182 @@
+package sbom
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// Format is a format of SBOM file supported by the lifecycle.
+type Format string
+
+const (
+	CycloneDX Format = "cyclonedx"
+	SPDX      Format = "spdx"
+	Syft      Format = "syft"
+)
+
+// PredicateType returns the in-toto predicate type of SBOM attestations in this format.
+func (f Format) PredicateType() string {
+	switch f {
+	case CycloneDX:
+		return "https://cyclonedx.org/bom"
+	case SPDX:
+		return "https://spdx.dev/Document"
+	default:
+		return "https://syft.dev/bom"
+	}
+}
+
+// formats lists the SBOM file names, by order of preference when a buildpack provides several formats
+var formats = []struct {
+	fileName string
+	format   Format
+	parse    func(io.Reader) ([]Package, error)
+}{
+	{"sbom.cdx.json", CycloneDX, parseCycloneDX},
+	{"sbom.spdx.json", SPDX, parseSPDX},
+	{"sbom.syft.json", Syft, parseSyft},
+}
+
+// Package is a package listed in an SBOM file.
+type Package struct {
+	Name     string   `json:"name"`
+	Version  string   `json:"version,omitempty"`
+	Licenses []string `json:"licenses,omitempty"`
+	PURL     string   `json:"purl,omitempty"`
+	// Buildpack is the escaped ID of the buildpack that contributed the SBOM, as found in the layer.
+	Buildpack string `json:"buildpack"`
+	// Scope is either `launch` or `build`.
+	Scope  string `json:"scope"`
+	Format Format `json:"format"`
+}
+
+// File is an SBOM file contributed by a buildpack.
+type File struct {
+	Path      string
+	Format    Format
+	Buildpack string
+	Scope     string
+	Contents  []byte
+}
+
+// Read lists the packages in the SBOM layer of an app image, read as an uncompressed tar. Buildpacks contribute
+// SBOM files at layers/sbom/<launch|build>/<escaped buildpack ID>[/<layer>]/sbom.<cdx|spdx|syft>.json; when a
+// buildpack provides the same SBOM in several formats, only the CycloneDX, then SPDX, then Syft one is read.
+func Read(layer io.Reader) ([]Package, error) {
+	files, err := ReadFiles(layer)
+	if err != nil {
+		return nil, err
+	}
+
+	var packages []Package
+	for _, file := range files {
+		found, err := parse(file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing %s", style.Symbol(file.Path))
+		}
+		for _, pkg := range found {
+			pkg.Scope, pkg.Buildpack, pkg.Format = file.Scope, file.Buildpack, file.Format
+			packages = append(packages, pkg)
+		}
+	}
+
+	sort.SliceStable(packages, func(i, j int) bool {
+		if packages[i].Name != packages[j].Name {
+			return packages[i].Name < packages[j].Name
+		}
+		return packages[i].Version < packages[j].Version
+	})
+	return packages, nil
+}
+
+// ReadFiles returns the SBOM files in the SBOM layer of an app image, read as an uncompressed tar, ordered by path.
+// Like Read, it only returns the preferred format of each SBOM.
+func ReadFiles(layer io.Reader) ([]File, error) {
+	contents := map[string]map[string][]byte{}
+
+	tr := tar.NewReader(layer)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "reading SBOM layer")
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		dir, fileName := path.Split(path.Clean("/" + header.Name))
+		if !isSBOMFile(fileName) {
+			continue
+		}
+		fileContents, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading %s", style.Symbol(header.Name))
+		}
+		if contents[dir] == nil {
+			contents[dir] = map[string][]byte{}
+		}
+		contents[dir][fileName] = fileContents
+	}
+
+	var dirs []string
+	for dir := range contents {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+
+	var files []File
+	for _, dir := range dirs {
+		scope, buildpack := location(dir)
+		for _, f := range formats {
+			if fileContents, ok := contents[dir][f.fileName]; ok {
+				files = append(files, File{Path: path.Join(dir, f.fileName), Format: f.format, Buildpack: buildpack, Scope: scope, Contents: fileContents})
+				break
+			}
+		}
+	}
+	return files, nil
+}
+
+func parse(file File) ([]Package, error) {
+	for _, f := range formats {
+		if f.format == file.Format {
+			return f.parse(bytes.NewReader(file.Contents))
+		}
+	}
+	return nil, errors.Errorf("unsupported format %s", style.Symbol(string(file.Format)))
+}
+
+func isSBOMFile(fileName string) bool {
+	for _, f := range formats {
+		if f.fileName == fileName {
+			return true
+		}
+	}
+	return false
+}
+
+// location returns the scope and buildpack of an SBOM file from its directory, e.g. /layers/sbom/launch/some_buildpack/some-layer/
+func location(dir string) (scope, buildpack string) {
+	parts := strings.Split(strings.Trim(dir, "/"), "/")
+	for i, part := range parts {
+		if part == "sbom" && i+1 < len(parts) {
+			scope = parts[i+1]
+			if i+2 < len(parts) {
+				buildpack = parts[i+2]
+			}
+			return scope, buildpack
+		}
+	}
+	return "", ""
+}
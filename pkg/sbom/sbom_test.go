@@ -0,0 +1,143 @@
+package sbom_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/sbom"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestSBOM(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "SBOM", testSBOM, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+const (
+	cycloneDXFile = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.4",
+  "metadata": {"component": {"name": "/layers/some-layer"}},
+  "components": [
+    {
+      "name": "express",
+      "version": "4.18.2",
+      "purl": "pkg:npm/express@4.18.2",
+      "licenses": [{"license": {"id": "MIT"}}],
+      "components": [
+        {"name": "body-parser", "version": "1.20.1", "licenses": [{"expression": "MIT OR ISC"}]}
+      ]
+    }
+  ]
+}`
+	spdxFile = `{
+  "spdxVersion": "SPDX-2.3",
+  "packages": [
+    {"SPDXID": "SPDXRef-DocumentRoot-Directory-layers", "name": "/layers"},
+    {
+      "SPDXID": "SPDXRef-openssl",
+      "name": "openssl",
+      "versionInfo": "3.0.2",
+      "licenseConcluded": "Apache-2.0",
+      "licenseDeclared": "NOASSERTION",
+      "externalRefs": [{"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:deb/ubuntu/openssl@3.0.2"}]
+    }
+  ]
+}`
+	syftFile = `{
+  "artifacts": [
+    {"name": "go", "version": "1.22.1", "purl": "pkg:generic/go@1.22.1", "licenses": [{"value": "BSD-3-Clause", "spdxExpression": "BSD-3-Clause"}]},
+    {"name": "gcc", "version": "12.0", "licenses": ["GPL-3.0"]}
+  ]
+}`
+)
+
+func testSBOM(t *testing.T, when spec.G, it spec.S) {
+	var assert = h.NewAssertionManager(t)
+
+	sbomLayer := func(files map[string]string) *bytes.Buffer {
+		buf := &bytes.Buffer{}
+		tw := tar.NewWriter(buf)
+		for name, contents := range files {
+			assert.Nil(tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(contents))}))
+			_, err := tw.Write([]byte(contents))
+			assert.Nil(err)
+		}
+		assert.Nil(tw.Close())
+		return buf
+	}
+
+	when("#Read", func() {
+		it("reads CycloneDX files, including nested components", func() {
+			packages, err := sbom.Read(sbomLayer(map[string]string{
+				"/layers/sbom/launch/some-org_node/node_modules/sbom.cdx.json": cycloneDXFile,
+			}))
+			assert.Nil(err)
+
+			assert.Equal(packages, []sbom.Package{
+				{Name: "body-parser", Version: "1.20.1", Licenses: []string{"MIT OR ISC"}, Buildpack: "some-org_node", Scope: "launch", Format: sbom.CycloneDX},
+				{Name: "express", Version: "4.18.2", Licenses: []string{"MIT"}, PURL: "pkg:npm/express@4.18.2", Buildpack: "some-org_node", Scope: "launch", Format: sbom.CycloneDX},
+			})
+		})
+
+		it("reads SPDX files, ignoring the document root", func() {
+			packages, err := sbom.Read(sbomLayer(map[string]string{
+				"layers/sbom/build/some-buildpack/sbom.spdx.json": spdxFile,
+			}))
+			assert.Nil(err)
+
+			assert.Equal(packages, []sbom.Package{
+				{Name: "openssl", Version: "3.0.2", Licenses: []string{"Apache-2.0"}, PURL: "pkg:deb/ubuntu/openssl@3.0.2", Buildpack: "some-buildpack", Scope: "build", Format: sbom.SPDX},
+			})
+		})
+
+		it("reads Syft files, with either form of licenses", func() {
+			packages, err := sbom.Read(sbomLayer(map[string]string{
+				"/layers/sbom/launch/some-buildpack/sbom.syft.json": syftFile,
+			}))
+			assert.Nil(err)
+
+			assert.Equal(packages, []sbom.Package{
+				{Name: "gcc", Version: "12.0", Licenses: []string{"GPL-3.0"}, Buildpack: "some-buildpack", Scope: "launch", Format: sbom.Syft},
+				{Name: "go", Version: "1.22.1", Licenses: []string{"BSD-3-Clause"}, PURL: "pkg:generic/go@1.22.1", Buildpack: "some-buildpack", Scope: "launch", Format: sbom.Syft},
+			})
+		})
+
+		when("a buildpack provides several formats", func() {
+			it("only reads the preferred one", func() {
+				packages, err := sbom.Read(sbomLayer(map[string]string{
+					"/layers/sbom/launch/some-buildpack/sbom.syft.json": syftFile,
+					"/layers/sbom/launch/some-buildpack/sbom.spdx.json": spdxFile,
+				}))
+				assert.Nil(err)
+
+				assert.Equal(len(packages), 1)
+				assert.Equal(packages[0].Format, sbom.SPDX)
+			})
+		})
+
+		it("ignores other files", func() {
+			packages, err := sbom.Read(sbomLayer(map[string]string{
+				"/layers/sbom/launch/sbom.legacy.json": `[{"name": "some-bom-entry"}]`,
+			}))
+			assert.Nil(err)
+
+			assert.Equal(len(packages), 0)
+		})
+
+		when("a file is not valid", func() {
+			it("returns an error", func() {
+				_, err := sbom.Read(sbomLayer(map[string]string{
+					"/layers/sbom/launch/some-buildpack/sbom.cdx.json": "not json",
+				}))
+				assert.ErrorContains(err, "parsing '/layers/sbom/launch/some-buildpack/sbom.cdx.json'")
+			})
+		})
+	})
+}
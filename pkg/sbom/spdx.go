@@ -0,0 +1,52 @@
+package sbom
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+type spdxDocument struct {
+	Packages []struct {
+		SPDXID           string `json:"SPDXID"`
+		Name             string `json:"name"`
+		VersionInfo      string `json:"versionInfo"`
+		LicenseConcluded string `json:"licenseConcluded"`
+		LicenseDeclared  string `json:"licenseDeclared"`
+		ExternalRefs     []struct {
+			ReferenceType    string `json:"referenceType"`
+			ReferenceLocator string `json:"referenceLocator"`
+		} `json:"externalRefs"`
+	} `json:"packages"`
+}
+
+func parseSPDX(r io.Reader) ([]Package, error) {
+	var doc spdxDocument
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	var packages []Package
+	for _, spdxPackage := range doc.Packages {
+		// the package describing the scanned directory or image isn't part of its contents
+		if strings.HasPrefix(spdxPackage.SPDXID, "SPDXRef-DocumentRoot") {
+			continue
+		}
+
+		pkg := Package{Name: spdxPackage.Name, Version: spdxPackage.VersionInfo}
+		for _, license := range []string{spdxPackage.LicenseDeclared, spdxPackage.LicenseConcluded} {
+			if license != "" && license != "NOASSERTION" && license != "NONE" {
+				pkg.Licenses = []string{license}
+				break
+			}
+		}
+		for _, ref := range spdxPackage.ExternalRefs {
+			if ref.ReferenceType == "purl" {
+				pkg.PURL = ref.ReferenceLocator
+				break
+			}
+		}
+		packages = append(packages, pkg)
+	}
+	return packages, nil
+}
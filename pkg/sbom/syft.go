@@ -0,0 +1,58 @@
+package sbom
+
+import (
+	"encoding/json"
+	"io"
+)
+
+type syftDocument struct {
+	Artifacts []struct {
+		Name     string          `json:"name"`
+		Version  string          `json:"version"`
+		PURL     string          `json:"purl"`
+		Licenses json.RawMessage `json:"licenses"`
+	} `json:"artifacts"`
+}
+
+func parseSyft(r io.Reader) ([]Package, error) {
+	var doc syftDocument
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	var packages []Package
+	for _, artifact := range doc.Artifacts {
+		packages = append(packages, Package{
+			Name:     artifact.Name,
+			Version:  artifact.Version,
+			PURL:     artifact.PURL,
+			Licenses: syftLicenses(artifact.Licenses),
+		})
+	}
+	return packages, nil
+}
+
+// syftLicenses reads licenses as a list of strings, as written by Syft before schema 8, or a list of objects
+func syftLicenses(raw json.RawMessage) []string {
+	var names []string
+	if err := json.Unmarshal(raw, &names); err == nil {
+		return names
+	}
+	names = nil
+
+	var licenses []struct {
+		Value          string `json:"value"`
+		SPDXExpression string `json:"spdxExpression"`
+	}
+	if err := json.Unmarshal(raw, &licenses); err != nil {
+		return nil
+	}
+	for _, license := range licenses {
+		if license.SPDXExpression != "" {
+			names = append(names, license.SPDXExpression)
+		} else if license.Value != "" {
+			names = append(names, license.Value)
+		}
+	}
+	return names
+}